@@ -1,10 +1,19 @@
 package common
 
 import (
+	"gomailapi2/internal/client/ews"
+	"gomailapi2/internal/client/imap"
+	"gomailapi2/internal/client/imap/generic"
+	"gomailapi2/internal/client/imap/gmail"
 	"gomailapi2/internal/client/imap/outlook"
+	"gomailapi2/internal/client/imap/yahoo"
+	"gomailapi2/internal/client/jmap"
+	"gomailapi2/internal/client/pop3"
+	"gomailapi2/internal/client/smtp"
 	"gomailapi2/internal/config"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/types"
+	"strings"
 )
 
 // 订阅配置常量
@@ -13,9 +22,17 @@ const (
 	HeartbeatIntervalSeconds = 60 // 心跳间隔（秒）
 )
 
+// 邮件列表分页配置
+const (
+	DefaultListLimit = 10 // 默认每页邮件数量
+	MaxListLimit     = 50 // 每页最大邮件数量
+)
+
 // 全局变量
 var (
 	GraphNotificationURL string // Graph webhook 通知 URL
+	GmailPushTopic       string // Gmail 推送通知 Pub/Sub 主题
+	OAuthRedirectURL     string // OAuth 授权码流程的默认回调地址
 )
 
 // InitGraphNotificationURL 初始化 Graph webhook 通知 URL
@@ -23,6 +40,34 @@ func InitGraphNotificationURL(cfg *config.WebhookConfig) {
 	GraphNotificationURL = cfg.BaseURL + "/gomailapi2/graph/webhook"
 }
 
+// InitGmailPushTopic 初始化 Gmail 推送通知 Pub/Sub 主题
+func InitGmailPushTopic(cfg *config.WebhookConfig) {
+	GmailPushTopic = cfg.GmailTopic
+}
+
+// InitOAuthRedirectURL 初始化 OAuth 授权码流程的默认回调地址（未配置 base_url 时为空，需要在请求中指定）
+func InitOAuthRedirectURL(cfg *config.WebhookConfig) {
+	if cfg.BaseURL == "" {
+		return
+	}
+	OAuthRedirectURL = cfg.BaseURL + "/gomailapi2/oauth/callback"
+}
+
+// GmailNotificationKey Gmail 推送通知只携带邮箱地址，使用邮箱地址作为通知通道的键
+func GmailNotificationKey(email string) string {
+	return "gmail:" + strings.ToLower(email)
+}
+
+// InitMailConfig 初始化邮件获取配置
+func InitMailConfig(cfg *config.MailConfig) {
+	imap.SetMaxPartSize(cfg.MaxPartSize)
+	pop3.SetMaxPartSize(cfg.MaxPartSize)
+	pop3.SetScanLimit(cfg.Pop3ScanLimit)
+	jmap.SetMaxPartSize(cfg.MaxPartSize)
+	jmap.SetAllowedHosts(cfg.JmapAllowedHosts)
+	ews.SetMaxPartSize(cfg.MaxPartSize)
+}
+
 // GetTokens 获取访问令牌和刷新令牌
 func GetTokens(tokenProvider *token.TokenProvider, refreshNeeded bool, mailInfo *types.MailInfo) (string, string, error) {
 	var accessToken, refreshToken string
@@ -37,6 +82,14 @@ func GetTokens(tokenProvider *token.TokenProvider, refreshNeeded bool, mailInfo
 	return accessToken, refreshToken, err
 }
 
+// NormalizeListLimit 规范化每页邮件数量（未指定时使用默认值，超出上限时截断）
+func NormalizeListLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultListLimit
+	}
+	return min(limit, MaxListLimit)
+}
+
 // MailInfoToCredentials 将 mailInfo 转换为 credentials
 func MailInfoToCredentials(mailInfo *types.MailInfo) *outlook.Credentials {
 	return &outlook.Credentials{
@@ -45,3 +98,107 @@ func MailInfoToCredentials(mailInfo *types.MailInfo) *outlook.Credentials {
 		RefreshToken: mailInfo.RefreshToken,
 	}
 }
+
+// NewImapClient 根据服务提供商创建 IMAP 客户端（未知的服务提供商按微软处理）
+func NewImapClient(mailInfo *types.MailInfo, accessToken string) *imap.CommonImapClient {
+	switch mailInfo.ServiceProvider {
+	case types.ServiceProviderGoogle:
+		return gmail.NewGmailImapClient(mailInfo.Email, accessToken).CommonImapClient
+	case types.ServiceProviderGeneric:
+		return generic.NewGenericImapClient(mailInfoToGenericCredentials(mailInfo), toGenericServer(mailInfo.Imap)).CommonImapClient
+	case types.ServiceProviderYahoo:
+		return yahoo.NewYahooImapClient(yahoo.YahooServer, mailInfo.Email, accessToken).CommonImapClient
+	case types.ServiceProviderAOL:
+		return yahoo.NewYahooImapClient(yahoo.AOLServer, mailInfo.Email, accessToken).CommonImapClient
+	default:
+		return outlook.NewOutlookImapClient(MailInfoToCredentials(mailInfo), accessToken).CommonImapClient
+	}
+}
+
+// NewSmtpClient 根据服务提供商创建 SMTP 客户端（未知的服务提供商按微软处理）
+func NewSmtpClient(mailInfo *types.MailInfo, accessToken string) *smtp.CommonSmtpClient {
+	switch mailInfo.ServiceProvider {
+	case types.ServiceProviderGoogle:
+		return gmail.NewGmailSmtpClient(mailInfo.Email, accessToken)
+	case types.ServiceProviderGeneric:
+		return generic.NewGenericSmtpClient(mailInfoToGenericCredentials(mailInfo), toGenericServer(mailInfo.Smtp))
+	case types.ServiceProviderYahoo:
+		return yahoo.NewYahooSmtpClient(yahoo.YahooServer, mailInfo.Email, accessToken)
+	case types.ServiceProviderAOL:
+		return yahoo.NewYahooSmtpClient(yahoo.AOLServer, mailInfo.Email, accessToken)
+	default:
+		return outlook.NewOutlookSmtpClient(MailInfoToCredentials(mailInfo), accessToken)
+	}
+}
+
+// NewPop3Client 根据服务提供商创建 POP3 客户端（未知的服务提供商按微软处理）
+func NewPop3Client(mailInfo *types.MailInfo, accessToken string) *pop3.CommonPop3Client {
+	switch mailInfo.ServiceProvider {
+	case types.ServiceProviderGoogle:
+		return gmail.NewGmailPop3Client(mailInfo.Email, accessToken)
+	case types.ServiceProviderGeneric:
+		return generic.NewGenericPop3Client(mailInfoToGenericCredentials(mailInfo), toGenericServer(mailInfo.Pop3))
+	default:
+		return outlook.NewOutlookPop3Client(MailInfoToCredentials(mailInfo), accessToken)
+	}
+}
+
+// NewJmapClient 创建 JMAP 客户端（仅 GENERIC 账户，使用密码或 API 令牌认证）
+func NewJmapClient(mailInfo *types.MailInfo) *jmap.Client {
+	username := mailInfo.Username
+	if username == "" {
+		username = mailInfo.Email
+	}
+	return jmap.NewClient(mailInfo.JmapURL, &jmap.Credentials{
+		Username:  username,
+		Password:  mailInfo.Password,
+		UseBearer: mailInfo.AuthMechanism == types.AuthMechanismBearer,
+	})
+}
+
+// NewEwsClient 创建 EWS 客户端：GENERIC 账户使用密码（Basic、NTLM）或 API 令牌认证，微软账户使用 accessToken
+// 微软账户未指定 ewsUrl 时使用 Exchange Online 的 EWS 地址
+func NewEwsClient(mailInfo *types.MailInfo, accessToken string) *ews.Client {
+	endpoint := mailInfo.EwsURL
+	if endpoint == "" {
+		endpoint = ews.ExchangeOnlineURL
+	}
+
+	username := mailInfo.Username
+	if username == "" {
+		username = mailInfo.Email
+	}
+	if mailInfo.UsesPasswordAuth() && mailInfo.AuthMechanism == types.AuthMechanismBearer {
+		accessToken = mailInfo.Password
+	}
+
+	return ews.NewClient(endpoint, &ews.Credentials{
+		Email:       mailInfo.Email,
+		Username:    username,
+		Password:    mailInfo.Password,
+		UseNTLM:     mailInfo.AuthMechanism == types.AuthMechanismNTLM,
+		AccessToken: accessToken,
+	})
+}
+
+// mailInfoToGenericCredentials 将 mailInfo 转换为通用邮件服务器的登录信息
+func mailInfoToGenericCredentials(mailInfo *types.MailInfo) *generic.Credentials {
+	return &generic.Credentials{
+		Email:    mailInfo.Email,
+		Username: mailInfo.Username,
+		Password: mailInfo.Password,
+		UsePlain: mailInfo.AuthMechanism == types.AuthMechanismPlain,
+	}
+}
+
+// toGenericServer 将服务器配置转换为通用邮件服务器地址，未配置时返回 nil
+func toGenericServer(settings *types.ServerSettings) *generic.Server {
+	if settings == nil || settings.Host == "" {
+		return nil
+	}
+	return &generic.Server{
+		Host:     settings.Host,
+		Port:     settings.Port,
+		StartTLS: settings.Security == types.SecurityModeSTARTTLS,
+	}
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"fmt"
+	"gomailapi2/internal/client/imap"
+	"gomailapi2/internal/client/smtp"
+	"gomailapi2/internal/domain"
+	"gomailapi2/internal/types"
+	"io"
+)
+
+// ReplyEmailViaSmtp 为 IMAP 类型的账户回复/转发邮件：通过 IMAP 获取原邮件（转发时同时下载原邮件的附件），
+// 生成回复邮件后通过 SMTP 发送，返回生成的 Message-ID
+func ReplyEmailViaSmtp(mailInfo *types.MailInfo, accessToken, emailID, folder string, reply *domain.ReplyEmail) (string, error) {
+	if err := reply.Validate(); err != nil {
+		return "", err
+	}
+
+	imapClient := NewImapClient(mailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	original, err := imapClient.FetchEmailByID(emailID, folder)
+	if err != nil {
+		return "", fmt.Errorf("获取原邮件失败: %w", err)
+	}
+
+	email := smtp.ComposeReply(original, mailInfo.Email, reply)
+
+	if reply.Mode == domain.ReplyModeForward {
+		attachments, err := fetchOutgoingAttachments(imapClient, emailID, folder, original.Attachments)
+		if err != nil {
+			return "", err
+		}
+		email.Attachments = append(attachments, email.Attachments...)
+	}
+
+	smtpClient := NewSmtpClient(mailInfo, accessToken)
+	return smtpClient.SendMail(email)
+}
+
+// fetchOutgoingAttachments 下载原邮件的附件，转换为待发送的附件
+func fetchOutgoingAttachments(imapClient *imap.CommonImapClient, emailID, folder string, attachments []*domain.Attachment) ([]*domain.OutgoingAttachment, error) {
+	result := make([]*domain.OutgoingAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		content, err := imapClient.FetchAttachment(emailID, folder, attachment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("下载原邮件附件 %s 失败: %w", attachment.Name, err)
+		}
+
+		data, err := io.ReadAll(content.Content)
+		content.Content.Close()
+		if err != nil {
+			return nil, fmt.Errorf("读取原邮件附件 %s 失败: %w", attachment.Name, err)
+		}
+
+		// 没有文件名的内联附件使用分段路径作为文件名
+		name := attachment.Name
+		if name == "" {
+			name = "attachment-" + attachment.ID
+		}
+
+		result = append(result, &domain.OutgoingAttachment{
+			Name:        name,
+			ContentType: attachment.ContentType,
+			Content:     data,
+			ContentID:   attachment.ContentID,
+			IsInline:    attachment.IsInline,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/store/tokenstore"
+	"gomailapi2/internal/types"
+	pb "gomailapi2/proto/pb"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// RegisterAccount 保存账户（加密），返回账户 ID 等基本信息和访问密钥
+func (s *MailServer) RegisterAccount(ctx context.Context, req *pb.RegisterAccountRequest) (*pb.Account, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Msg("gRPC 收到保存账户请求")
+
+	account, secret, err := s.tokenProvider.RegisterAccount(protoToMailInfo(req.MailInfo))
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("保存账户失败")
+		return nil, accountStatusError(err, codes.InvalidArgument)
+	}
+
+	response := accountToProto(account)
+	response.Secret = secret
+	return response, nil
+}
+
+// GetAccountHistory 获取已保存账户的基本信息和 refreshToken 变更记录
+func (s *MailServer) GetAccountHistory(ctx context.Context, req *pb.AccountRequest) (*pb.AccountHistoryResponse, error) {
+	account, err := s.tokenProvider.GetAccount(req.AccountId, req.AccountSecret)
+	if err != nil {
+		return nil, accountStatusError(err, codes.Internal)
+	}
+
+	history, err := s.tokenProvider.AccountHistory(req.AccountId, req.AccountSecret)
+	if err != nil {
+		log.Error().Err(err).Str("accountId", req.AccountId).Msg("获取账户变更记录失败")
+		return nil, accountStatusError(err, codes.Internal)
+	}
+
+	response := &pb.AccountHistoryResponse{
+		Account: accountToProto(account),
+		History: make([]*pb.TokenRotation, 0, len(history)),
+	}
+	for _, rotation := range history {
+		response.History = append(response.History, &pb.TokenRotation{
+			Time:                rotation.Time.Unix(),
+			Source:              rotation.Source,
+			Fingerprint:         rotation.Fingerprint,
+			PreviousFingerprint: rotation.PreviousFingerprint,
+		})
+	}
+	return response, nil
+}
+
+// DeleteAccount 删除已保存的账户及其变更记录
+func (s *MailServer) DeleteAccount(ctx context.Context, req *pb.AccountRequest) (*pb.DeleteAccountResponse, error) {
+	if err := s.tokenProvider.DeleteAccount(req.AccountId, req.AccountSecret); err != nil {
+		log.Error().Err(err).Str("accountId", req.AccountId).Msg("删除账户失败")
+		return nil, accountStatusError(err, codes.Internal)
+	}
+	return &pb.DeleteAccountResponse{Success: true}, nil
+}
+
+// resolveAccountsUnary 一元 RPC 拦截器：使用已保存的账户信息替换请求中只包含 account_id 的 MailInfo（需要 account_secret）
+// RegisterAccount 自行校验 account_id 和 account_secret（重新注册），不替换其中的 MailInfo
+func (s *MailServer) resolveAccountsUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if info.FullMethod == pb.MailService_RegisterAccount_FullMethodName {
+		return handler(ctx, req)
+	}
+	if err := s.resolveAccounts(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// resolveAccountsStream 流式 RPC 拦截器：在读取请求时替换只包含 account_id 的 MailInfo
+func (s *MailServer) resolveAccountsStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &accountResolvingStream{ServerStream: stream, server: s})
+}
+
+// accountResolvingStream 读取请求后替换其中只包含 account_id 的 MailInfo
+type accountResolvingStream struct {
+	grpc.ServerStream
+	server *MailServer
+}
+
+// RecvMsg 读取请求并替换 MailInfo
+func (s *accountResolvingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.server.resolveAccounts(m)
+}
+
+// resolveAccounts 替换请求中的 mail_info 和 mail_infos（只处理设置了 account_id 的项）
+func (s *MailServer) resolveAccounts(req any) error {
+	var mailInfos []*pb.MailInfo
+	if r, ok := req.(interface{ GetMailInfo() *pb.MailInfo }); ok {
+		mailInfos = append(mailInfos, r.GetMailInfo())
+	}
+	if r, ok := req.(interface{ GetMailInfos() []*pb.MailInfo }); ok {
+		mailInfos = append(mailInfos, r.GetMailInfos()...)
+	}
+
+	for _, protoMailInfo := range mailInfos {
+		if protoMailInfo == nil || protoMailInfo.AccountId == "" {
+			continue
+		}
+
+		mailInfo := &types.MailInfo{AccountID: protoMailInfo.AccountId, AccountSecret: protoMailInfo.AccountSecret}
+		if err := s.tokenProvider.ResolveAccount(mailInfo); err != nil {
+			log.Error().Err(err).Str("accountId", protoMailInfo.AccountId).Msg("读取已保存的账户失败")
+			return accountStatusError(err, codes.Internal)
+		}
+
+		proto.Reset(protoMailInfo)
+		proto.Merge(protoMailInfo, mailInfoToProto(mailInfo))
+	}
+	return nil
+}
+
+// accountToProto 将已保存账户的基本信息转换为 proto Account
+func accountToProto(account *tokenstore.Account) *pb.Account {
+	return &pb.Account{
+		Id:              account.ID,
+		Email:           account.Email,
+		ServiceProvider: typesToProtoServiceProvider(account.ServiceProvider),
+		ClientId:        account.ClientID,
+		ProtoType:       typesToProtoProtocolType(account.ProtocolType),
+		Fingerprint:     account.Fingerprint,
+		Rotations:       int32(account.Rotations),
+		CreatedAt:       account.CreatedAt.Unix(),
+		UpdatedAt:       account.UpdatedAt.Unix(),
+	}
+}
+
+// accountStatusError 将账户相关错误转换为 gRPC 状态，其他错误使用 defaultCode
+func accountStatusError(err error, defaultCode codes.Code) error {
+	switch {
+	case errors.Is(err, tokenstore.ErrAccountNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tokenstore.ErrInvalidSecret):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, token.ErrTokenStoreDisabled):
+		return status.Error(codes.Unimplemented, err.Error())
+	default:
+		return status.Error(defaultCode, err.Error())
+	}
+}
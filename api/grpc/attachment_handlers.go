@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"gomailapi2/api/common"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/domain"
+	pb "gomailapi2/proto/pb"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadAttachment 下载附件（分块流式返回）
+func (s *MailServer) DownloadAttachment(req *pb.DownloadAttachmentRequest, stream pb.MailService_DownloadAttachmentServer) error {
+	// 验证请求
+	if req.MailInfo == nil {
+		return status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.EmailId == "" || req.AttachmentId == "" {
+		return status.Error(codes.InvalidArgument, "邮件 ID 和附件 ID 不能为空")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("emailID", req.EmailId).
+		Str("attachmentID", req.AttachmentId).
+		Str("folder", req.Folder).
+		Msg("gRPC 收到下载附件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	var attachment *domain.AttachmentContent
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		attachment, err = graph.GetAttachment(stream.Context(), accessToken, req.EmailId, req.AttachmentId)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		attachment, err = imapClient.FetchAttachment(req.EmailId, req.Folder, req.AttachmentId)
+	default:
+		return status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("attachmentID", req.AttachmentId).Msg("下载附件失败")
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer attachment.Content.Close()
+
+	if err := sendAttachmentChunks(stream, attachment); err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("attachmentID", req.AttachmentId).Msg("发送附件数据失败")
+		return err
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Str("attachmentID", req.AttachmentId).Msg("附件下载完成")
+	return nil
+}
+
+// sendAttachmentChunks 将附件内容分块发送，第一个数据块携带附件元数据
+func sendAttachmentChunks(stream pb.MailService_DownloadAttachmentServer, attachment *domain.AttachmentContent) error {
+	return sendChunks(stream.Context(), attachment.Content, func(data []byte, first bool) error {
+		chunk := &pb.AttachmentChunk{Data: data}
+		if first {
+			chunk.Attachment = domainAttachmentToProto(attachment.Attachment)
+		}
+		return stream.Send(chunk)
+	})
+}
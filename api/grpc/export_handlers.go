@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"gomailapi2/api/common"
+	"gomailapi2/internal/client/gmailapi"
+	"gomailapi2/internal/client/graph"
+	pb "gomailapi2/proto/pb"
+	"io"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportMail 导出邮件原始内容（分块流式返回）
+func (s *MailServer) ExportMail(req *pb.ExportMailRequest, stream pb.MailService_ExportMailServer) error {
+	// 验证请求
+	if req.MailInfo == nil {
+		return status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.EmailId == "" {
+		return status.Error(codes.InvalidArgument, "邮件 ID 不能为空")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("emailID", req.EmailId).
+		Str("folder", req.Folder).
+		Msg("gRPC 收到导出邮件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	var content io.ReadCloser
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		content, err = graph.GetRawEmail(stream.Context(), accessToken, req.EmailId)
+	case pb.ProtocolType_GMAIL_API:
+		content, err = gmailapi.GetRawEmail(stream.Context(), accessToken, req.EmailId)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		content, err = imapClient.FetchRawEmail(req.EmailId, req.Folder)
+	default:
+		return status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("导出邮件失败")
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer content.Close()
+
+	err = sendChunks(stream.Context(), content, func(data []byte, first bool) error {
+		return stream.Send(&pb.RawMailChunk{Data: data})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("发送邮件原始内容失败")
+		return err
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("邮件导出完成")
+	return nil
+}
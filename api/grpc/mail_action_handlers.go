@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/domain"
+	pb "gomailapi2/proto/pb"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MarkMail 修改邮件状态（已读/未读、旗标）
+func (s *MailServer) MarkMail(ctx context.Context, req *pb.MarkMailRequest) (*pb.MarkMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.EmailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "邮件 ID 不能为空")
+	}
+	if req.IsRead == nil && req.IsFlagged == nil {
+		return nil, status.Error(codes.InvalidArgument, "is_read 和 is_flagged 至少指定一个")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("emailID", req.EmailId).
+		Str("folder", req.Folder).
+		Msg("gRPC 收到修改邮件状态请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	update := &domain.EmailFlagsUpdate{
+		IsRead:    req.IsRead,
+		IsFlagged: req.IsFlagged,
+	}
+
+	// 根据协议类型处理请求
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		err = graph.UpdateEmailFlags(ctx, accessToken, req.EmailId, update)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		err = imapClient.UpdateFlags(req.EmailId, req.Folder, update)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("修改邮件状态失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.MarkMailResponse{}, nil
+}
+
+// MoveMail 移动邮件到其他文件夹
+func (s *MailServer) MoveMail(ctx context.Context, req *pb.MoveMailRequest) (*pb.MoveMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.EmailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "邮件 ID 不能为空")
+	}
+	if req.TargetFolder == "" {
+		return nil, status.Error(codes.InvalidArgument, "目标文件夹不能为空")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("emailID", req.EmailId).
+		Str("folder", req.Folder).
+		Str("targetFolder", req.TargetFolder).
+		Msg("gRPC 收到移动邮件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	var newEmailID string
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		newEmailID, err = graph.MoveEmail(ctx, accessToken, req.EmailId, req.TargetFolder)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		newEmailID, err = imapClient.MoveEmail(req.EmailId, req.Folder, req.TargetFolder)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("移动邮件失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.MoveMailResponse{EmailId: newEmailID}, nil
+}
+
+// DeleteMail 删除邮件（permanent 为 false 时移动到已删除邮件）
+func (s *MailServer) DeleteMail(ctx context.Context, req *pb.DeleteMailRequest) (*pb.DeleteMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.EmailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "邮件 ID 不能为空")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("emailID", req.EmailId).
+		Str("folder", req.Folder).
+		Bool("permanent", req.Permanent).
+		Msg("gRPC 收到删除邮件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		err = graph.DeleteEmail(ctx, accessToken, req.EmailId, req.Permanent)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		err = imapClient.DeleteEmail(req.EmailId, req.Folder, req.Permanent)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("删除邮件失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DeleteMailResponse{}, nil
+}
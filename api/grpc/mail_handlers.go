@@ -3,8 +3,8 @@ package grpc
 import (
 	"context"
 	"gomailapi2/api/common"
+	"gomailapi2/internal/client/gmailapi"
 	"gomailapi2/internal/client/graph"
-	"gomailapi2/internal/client/imap/outlook"
 	"gomailapi2/internal/domain"
 	pb "gomailapi2/proto/pb"
 
@@ -42,10 +42,19 @@ func (s *MailServer) GetLatestMail(ctx context.Context, req *pb.GetNewMailReques
 	var email *domain.Email
 	switch req.MailInfo.ProtoType {
 	case pb.ProtocolType_GRAPH:
-		email, err = graph.GetLatestEmail(ctx, accessToken)
+		email, err = graph.GetLatestEmail(ctx, accessToken, req.Folder)
+	case pb.ProtocolType_GMAIL_API:
+		email, err = gmailapi.GetLatestEmail(ctx, accessToken, req.Folder)
 	case pb.ProtocolType_IMAP:
-		imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(mailInfo), accessToken)
-		email, err = imapClient.FetchLatestEmail()
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		email, err = imapClient.FetchLatestEmail(req.Folder)
+	case pb.ProtocolType_POP3:
+		pop3Client := common.NewPop3Client(mailInfo, accessToken)
+		email, err = pop3Client.FetchLatestEmail(req.Folder)
+	case pb.ProtocolType_JMAP:
+		email, err = common.NewJmapClient(mailInfo).GetLatestEmail(ctx, req.Folder)
+	case pb.ProtocolType_EWS:
+		email, err = common.NewEwsClient(mailInfo, accessToken).GetLatestEmail(ctx, req.Folder)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
 	}
@@ -107,9 +116,18 @@ func (s *MailServer) FindMail(ctx context.Context, req *pb.FindMailRequest) (*pb
 	switch req.MailInfo.ProtoType {
 	case pb.ProtocolType_GRAPH:
 		email, err = graph.GetEmailByID(ctx, accessToken, req.EmailId)
+	case pb.ProtocolType_GMAIL_API:
+		email, err = gmailapi.GetEmailByID(ctx, accessToken, req.EmailId)
 	case pb.ProtocolType_IMAP:
-		imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(mailInfo), accessToken)
-		email, err = imapClient.FetchEmailByID(req.EmailId)
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		email, err = imapClient.FetchEmailByID(req.EmailId, req.Folder)
+	case pb.ProtocolType_POP3:
+		pop3Client := common.NewPop3Client(mailInfo, accessToken)
+		email, err = pop3Client.FetchEmailByID(req.EmailId, req.Folder)
+	case pb.ProtocolType_JMAP:
+		email, err = common.NewJmapClient(mailInfo).GetEmailByID(ctx, req.EmailId)
+	case pb.ProtocolType_EWS:
+		email, err = common.NewEwsClient(mailInfo, accessToken).GetEmailByID(ctx, req.EmailId)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
 	}
@@ -129,6 +147,167 @@ func (s *MailServer) FindMail(ctx context.Context, req *pb.FindMailRequest) (*pb
 	}, nil
 }
 
+// ListMail 分页获取邮件列表
+func (s *MailServer) ListMail(ctx context.Context, req *pb.ListMailRequest) (*pb.ListMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+
+	limit := common.NormalizeListLimit(int(req.Limit))
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("folder", req.Folder).
+		Int("limit", limit).
+		Str("cursor", req.Cursor).
+		Msg("gRPC 收到获取邮件列表请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	var page *domain.EmailPage
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		page, err = graph.ListEmails(ctx, accessToken, req.Folder, limit, req.Cursor)
+	case pb.ProtocolType_GMAIL_API:
+		page, err = gmailapi.ListEmails(ctx, accessToken, req.Folder, limit, req.Cursor)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		page, err = imapClient.ListEmails(req.Folder, limit, req.Cursor)
+	case pb.ProtocolType_POP3:
+		pop3Client := common.NewPop3Client(mailInfo, accessToken)
+		page, err = pop3Client.ListEmails(req.Folder, limit, req.Cursor)
+	case pb.ProtocolType_JMAP:
+		page, err = common.NewJmapClient(mailInfo).ListEmails(ctx, req.Folder, limit, req.Cursor)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取邮件列表失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功获取邮件列表")
+	return domainEmailPageToProto(page), nil
+}
+
+// SearchMail 按条件搜索邮件
+func (s *MailServer) SearchMail(ctx context.Context, req *pb.SearchMailRequest) (*pb.ListMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+
+	criteria, err := protoToSearchCriteria(req.Criteria)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := common.NormalizeListLimit(int(req.Limit))
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("folder", criteria.Folder).
+		Int("limit", limit).
+		Str("cursor", req.Cursor).
+		Msg("gRPC 收到搜索邮件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	var page *domain.EmailPage
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		page, err = graph.SearchEmails(ctx, accessToken, criteria, limit, req.Cursor)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		page, err = imapClient.SearchEmails(criteria, limit, req.Cursor)
+	case pb.ProtocolType_JMAP:
+		page, err = common.NewJmapClient(mailInfo).SearchEmails(ctx, criteria, limit, req.Cursor)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("搜索邮件失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功搜索邮件")
+	return domainEmailPageToProto(page), nil
+}
+
+// ListFolders 获取文件夹列表
+func (s *MailServer) ListFolders(ctx context.Context, req *pb.ListFoldersRequest) (*pb.ListFoldersResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Msg("gRPC 收到获取文件夹列表请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	var folders []*domain.Folder
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		folders, err = graph.ListFolders(ctx, accessToken)
+	case pb.ProtocolType_IMAP:
+		imapClient := common.NewImapClient(mailInfo, accessToken)
+		defer imapClient.Disconnect()
+		folders, err = imapClient.ListFolders()
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取文件夹列表失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Int("count", len(folders)).Msg("成功获取文件夹列表")
+	return &pb.ListFoldersResponse{
+		Folders: domainFoldersToProto(folders),
+	}, nil
+}
+
 // GetJunkMail 获取垃圾邮件
 func (s *MailServer) GetJunkMail(ctx context.Context, req *pb.GetNewJunkMailRequest) (*pb.GetNewJunkMailResponse, error) {
 	// 验证请求
@@ -157,9 +336,13 @@ func (s *MailServer) GetJunkMail(ctx context.Context, req *pb.GetNewJunkMailRequ
 	switch req.MailInfo.ProtoType {
 	case pb.ProtocolType_GRAPH:
 		email, err = graph.GetLatestEmailFromJunk(ctx, accessToken)
+	case pb.ProtocolType_GMAIL_API:
+		email, err = gmailapi.GetLatestEmailFromJunk(ctx, accessToken)
 	case pb.ProtocolType_IMAP:
-		imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(mailInfo), accessToken)
+		imapClient := common.NewImapClient(mailInfo, accessToken)
 		email, err = imapClient.FetchLatestJunkEmail()
+	case pb.ProtocolType_EWS:
+		email, err = common.NewEwsClient(mailInfo, accessToken).GetLatestEmailFromJunk(ctx)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
 	}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/types"
+	pb "gomailapi2/proto/pb"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeviceAuthorization 设备码授权流：先推送用户代码和验证页面地址，用户完成登录后推送邮箱信息
+func (s *MailServer) DeviceAuthorization(req *pb.DeviceAuthorizationRequest, stream pb.MailService_DeviceAuthorizationServer) error {
+	log.Info().
+		Str("clientId", req.ClientId).
+		Str("provider", req.ServiceProvider.String()).
+		Msg("gRPC 收到设备码授权请求")
+
+	mailInfo, device, err := s.oauthService.StartDeviceAuthorization(&dto.DeviceAuthorizationRequest{
+		ServiceProvider: protoServiceProviderToTypes(req.ServiceProvider),
+		ClientID:        req.ClientId,
+		Tenant:          req.Tenant,
+		Scope:           req.Scope,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("clientId", req.ClientId).Msg("发起设备码授权失败")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := stream.Send(&pb.DeviceAuthorizationEvent{
+		EventType:       "device_code",
+		UserCode:        &device.UserCode,
+		VerificationUri: &device.VerificationURI,
+		ExpiresIn:       &device.ExpiresIn,
+		Message:         &device.Message,
+	}); err != nil {
+		log.Error().Err(err).Str("eventType", "device_code").Msg("发送 gRPC 事件失败")
+		return err
+	}
+
+	// 在后台轮询 token endpoint，客户端断开时停止轮询
+	type result struct {
+		mailInfo *types.MailInfo
+		err      error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		mailInfo, err := s.oauthService.WaitDeviceAuthorization(stream.Context(), mailInfo, device)
+		resultChan <- result{mailInfo: mailInfo, err: err}
+	}()
+
+	heartbeat := time.NewTicker(common.HeartbeatIntervalSeconds * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case res := <-resultChan:
+			if res.err != nil {
+				log.Error().Err(res.err).Str("clientId", req.ClientId).Msg("设备码授权失败")
+				return status.Error(codes.Unauthenticated, res.err.Error())
+			}
+			if err := stream.Send(&pb.DeviceAuthorizationEvent{
+				EventType: "complete",
+				MailInfo:  mailInfoToProto(res.mailInfo),
+			}); err != nil {
+				log.Error().Err(err).Str("eventType", "complete").Msg("发送 gRPC 事件失败")
+				return err
+			}
+			return nil
+
+		case <-heartbeat.C:
+			if err := stream.Send(&pb.DeviceAuthorizationEvent{EventType: "heartbeat"}); err != nil {
+				log.Error().Err(err).Str("eventType", "heartbeat").Msg("发送 gRPC 事件失败")
+				return err
+			}
+
+		case <-stream.Context().Done():
+			log.Info().Str("clientId", req.ClientId).Msg("gRPC 客户端断开连接 (设备码授权)")
+			return nil
+		}
+	}
+}
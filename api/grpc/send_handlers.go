@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/internal/client/graph"
+	pb "gomailapi2/proto/pb"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SendMail 发送邮件（IMAP 账户通过 SMTP 发送，Graph 账户通过 sendMail 发送）
+func (s *MailServer) SendMail(ctx context.Context, req *pb.SendMailRequest) (*pb.SendMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.Message == nil {
+		return nil, status.Error(codes.InvalidArgument, "邮件内容不能为空")
+	}
+
+	message := protoToOutgoingEmail(req.Message)
+	if err := message.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Int("recipients", len(message.To)+len(message.Cc)+len(message.Bcc)).
+		Int("attachments", len(message.Attachments)).
+		Str("inReplyTo", message.InReplyTo).
+		Msg("gRPC 收到发送邮件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	response := &pb.SendMailResponse{}
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		err = graph.SendEmail(ctx, accessToken, message)
+	case pb.ProtocolType_IMAP:
+		smtpClient := common.NewSmtpClient(mailInfo, accessToken)
+		var messageID string
+		messageID, err = smtpClient.SendMail(message)
+		if err == nil {
+			response.MessageId = &messageID
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("发送邮件失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Msg("邮件发送成功")
+	return response, nil
+}
+
+// ReplyMail 回复、回复全部或转发邮件（IMAP 账户通过 SMTP 发送）
+func (s *MailServer) ReplyMail(ctx context.Context, req *pb.ReplyMailRequest) (*pb.SendMailResponse, error) {
+	// 验证请求
+	if req.MailInfo == nil {
+		return nil, status.Error(codes.InvalidArgument, "MailInfo 不能为空")
+	}
+	if req.EmailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "邮件 ID 不能为空")
+	}
+
+	reply := protoToReplyEmail(req)
+	if err := reply.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("protocol", req.MailInfo.ProtoType.String()).
+		Str("provider", req.MailInfo.ServiceProvider.String()).
+		Str("emailID", req.EmailId).
+		Str("folder", req.Folder).
+		Str("mode", string(reply.Mode)).
+		Msg("gRPC 收到回复邮件请求")
+
+	// 转换 MailInfo
+	mailInfo := protoToMailInfo(req.MailInfo)
+
+	// 获取访问令牌
+	accessToken, err := s.tokenProvider.GetAccessToken(mailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("获取访问令牌失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// 根据协议类型处理请求
+	response := &pb.SendMailResponse{}
+	switch req.MailInfo.ProtoType {
+	case pb.ProtocolType_GRAPH:
+		err = graph.ReplyEmail(ctx, accessToken, req.EmailId, reply)
+	case pb.ProtocolType_IMAP:
+		var messageID string
+		messageID, err = common.ReplyEmailViaSmtp(mailInfo, accessToken, req.EmailId, req.Folder, reply)
+		if err == nil {
+			response.MessageId = &messageID
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "不支持的协议类型")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("回复邮件失败")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Info().Str("email", req.MailInfo.Email).Str("emailID", req.EmailId).Msg("邮件回复成功")
+	return response, nil
+}
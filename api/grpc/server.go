@@ -18,6 +18,7 @@ type MailServer struct {
 	pb.UnimplementedMailServiceServer
 	tokenProvider   *token.TokenProvider
 	protocolService *service.ProtocolService
+	oauthService    *service.OAuthService
 	nfManager       *manager.NotificationManager
 	imapManager     *manager.ImapSubscriptionManager
 	server          *grpc.Server
@@ -27,12 +28,14 @@ type MailServer struct {
 func NewMailServer(
 	tokenProvider *token.TokenProvider,
 	protocolService *service.ProtocolService,
+	oauthService *service.OAuthService,
 	nfManager *manager.NotificationManager,
 	imapManager *manager.ImapSubscriptionManager,
 ) *MailServer {
 	return &MailServer{
 		tokenProvider:   tokenProvider,
 		protocolService: protocolService,
+		oauthService:    oauthService,
 		nfManager:       nfManager,
 		imapManager:     imapManager,
 	}
@@ -48,7 +51,11 @@ func (ms *MailServer) StartServer(port int) error {
 	}
 
 	// 创建 gRPC 服务器
-	ms.server = grpc.NewServer()
+	ms.server = grpc.NewServer(
+		// 请求中的 MailInfo 只包含 account_id 时，使用已保存的账户信息
+		grpc.UnaryInterceptor(ms.resolveAccountsUnary),
+		grpc.StreamInterceptor(ms.resolveAccountsStream),
+	)
 
 	// 注册邮件服务
 	pb.RegisterMailServiceServer(ms.server, ms)
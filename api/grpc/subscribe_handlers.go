@@ -3,8 +3,9 @@ package grpc
 import (
 	"context"
 	"gomailapi2/api/common"
+	"gomailapi2/internal/client/gmailapi"
 	"gomailapi2/internal/client/graph"
-	"gomailapi2/internal/client/imap/outlook"
+	"gomailapi2/internal/domain"
 	"gomailapi2/internal/manager"
 	"gomailapi2/internal/types"
 	pb "gomailapi2/proto/pb"
@@ -44,6 +45,12 @@ func (s *MailServer) SubscribeMail(req *pb.SubscribeMailRequest, stream pb.MailS
 		return s.handleImapSubscriptionStream(stream, req, accessToken, refreshToken, mailInfo)
 	case pb.ProtocolType_GRAPH:
 		return s.handleGraphSubscriptionStream(stream, req, accessToken, refreshToken)
+	case pb.ProtocolType_GMAIL_API:
+		return s.handleGmailSubscriptionStream(stream, req, accessToken, refreshToken)
+	case pb.ProtocolType_JMAP:
+		return s.handleJmapSubscriptionStream(stream, req, refreshToken, mailInfo)
+	case pb.ProtocolType_EWS:
+		return s.handleEwsSubscriptionStream(stream, req, accessToken, refreshToken, mailInfo)
 	default:
 		return status.Error(codes.InvalidArgument, "不支持的协议类型: "+req.MailInfo.ProtoType.String())
 	}
@@ -57,7 +64,7 @@ func (s *MailServer) handleImapSubscriptionStream(
 	mailInfo *types.MailInfo,
 ) error {
 	// 创建 IMAP 客户端
-	imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(mailInfo), accessToken)
+	imapClient := common.NewImapClient(mailInfo, accessToken)
 
 	// 创建新订阅
 	subscription, err := s.imapManager.CreateSubscription(imapClient, req.MailInfo.Email)
@@ -141,6 +148,142 @@ func (s *MailServer) handleGraphSubscriptionStream(
 	return s.listenForGraphNotificationsStream(stream, notifyChan, response.ID, req.MailInfo.Email, accessToken)
 }
 
+// handleGmailSubscriptionStream 处理 Gmail API 协议订阅流
+func (s *MailServer) handleGmailSubscriptionStream(
+	stream pb.MailService_SubscribeMailServer,
+	req *pb.SubscribeMailRequest,
+	accessToken, refreshToken string,
+) error {
+	// Gmail 推送通知只携带邮箱地址，同一邮箱同时只能有一个订阅
+	key := common.GmailNotificationKey(req.MailInfo.Email)
+	if s.nfManager.HasChannel(key) {
+		return status.Error(codes.AlreadyExists, "该邮箱已有进行中的 Gmail 订阅")
+	}
+
+	// 先注册邮件通知通道，避免遗漏开始监听后立即到达的通知
+	notifyChan := s.nfManager.RegisterChannel(key)
+
+	// 清理函数
+	defer func() {
+		s.nfManager.RemoveChannel(key)
+		log.Info().
+			Str("email", req.MailInfo.Email).
+			Msg("清理 Gmail 订阅通知通道")
+	}()
+
+	// 开始监听收件箱
+	response, err := gmailapi.Watch(context.Background(), accessToken, common.GmailPushTopic)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("创建 Gmail 订阅失败")
+		return status.Error(codes.Internal, "创建订阅失败: "+err.Error())
+	}
+
+	// 结束后，自动停止监听
+	defer func() {
+		if err := gmailapi.StopWatch(context.Background(), accessToken); err != nil {
+			log.Warn().Err(err).Str("email", req.MailInfo.Email).Msg("停止 Gmail 监听失败")
+		}
+	}()
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Str("historyID", response.HistoryID).
+		Msg("成功创建 Gmail 订阅")
+
+	// 发送订阅成功消息
+	if err := s.sendSubscriptionSuccess(stream, req.RefreshNeeded, refreshToken); err != nil {
+		return err
+	}
+
+	// 开始监听 Gmail 通知
+	return s.listenForGmailNotificationsStream(stream, notifyChan, response.HistoryID, req.MailInfo.Email, accessToken)
+}
+
+// handleJmapSubscriptionStream 处理 JMAP 协议订阅流（通过 JMAP 推送的 EventSource 监听收件箱）
+func (s *MailServer) handleJmapSubscriptionStream(
+	stream pb.MailService_SubscribeMailServer,
+	req *pb.SubscribeMailRequest,
+	refreshToken string,
+	mailInfo *types.MailInfo,
+) error {
+	// 流结束（收到邮件、超时或客户端断开）时关闭推送连接
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	emailChan := make(chan *domain.Email, 1)
+	errChan := make(chan error, 1)
+
+	// 先建立推送连接，连接成功后才发送订阅成功消息
+	subscription, err := common.NewJmapClient(mailInfo).Subscribe(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("创建 JMAP 订阅失败")
+		return status.Error(codes.Internal, "创建订阅失败: "+err.Error())
+	}
+	defer subscription.Close()
+
+	go func() {
+		errChan <- subscription.Listen(ctx, emailChan)
+	}()
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Msg("成功创建 JMAP 订阅")
+
+	// 发送订阅成功消息
+	if err := s.sendSubscriptionSuccess(stream, req.RefreshNeeded, refreshToken); err != nil {
+		return err
+	}
+
+	// 开始监听 JMAP 邮件
+	return s.listenForJmapEmailsStream(stream, emailChan, errChan, req.MailInfo.Email)
+}
+
+// handleEwsSubscriptionStream 处理 EWS 协议订阅流（通过拉取订阅监听收件箱）
+func (s *MailServer) handleEwsSubscriptionStream(
+	stream pb.MailService_SubscribeMailServer,
+	req *pb.SubscribeMailRequest,
+	accessToken, refreshToken string,
+	mailInfo *types.MailInfo,
+) error {
+	// 流结束（收到邮件、超时或客户端断开）时取消订阅
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	emailChan := make(chan *domain.Email, 1)
+	errChan := make(chan error, 1)
+
+	// 先创建订阅，创建成功后才发送订阅成功消息
+	subscription, err := common.NewEwsClient(mailInfo, accessToken).Subscribe(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("email", req.MailInfo.Email).Msg("创建 EWS 订阅失败")
+		return status.Error(codes.Internal, "创建订阅失败: "+err.Error())
+	}
+
+	// 结束后，先停止拉取再取消订阅
+	defer func() {
+		cancel()
+		if err := subscription.Close(); err != nil {
+			log.Warn().Err(err).Str("email", req.MailInfo.Email).Msg("取消 EWS 订阅失败")
+		}
+	}()
+
+	go func() {
+		errChan <- subscription.Listen(ctx, emailChan)
+	}()
+
+	log.Info().
+		Str("email", req.MailInfo.Email).
+		Msg("成功创建 EWS 订阅")
+
+	// 发送订阅成功消息
+	if err := s.sendSubscriptionSuccess(stream, req.RefreshNeeded, refreshToken); err != nil {
+		return err
+	}
+
+	// 开始监听 EWS 邮件
+	return s.listenForEwsEmailsStream(stream, emailChan, errChan, req.MailInfo.Email)
+}
+
 // listenForImapEmailsStream 监听 IMAP 邮件流
 func (s *MailServer) listenForImapEmailsStream(
 	stream pb.MailService_SubscribeMailServer,
@@ -202,6 +345,134 @@ func (s *MailServer) listenForImapEmailsStream(
 	}
 }
 
+// listenForJmapEmailsStream 监听 JMAP 推送的新邮件流
+func (s *MailServer) listenForJmapEmailsStream(
+	stream pb.MailService_SubscribeMailServer,
+	emailChan <-chan *domain.Email,
+	errChan <-chan error,
+	email string,
+) error {
+	// 设置超时和心跳
+	timeout := time.NewTimer(common.TimeoutMinutes * time.Minute)
+	defer timeout.Stop()
+
+	heartbeat := time.NewTicker(common.HeartbeatIntervalSeconds * time.Second)
+	defer heartbeat.Stop()
+
+	log.Info().
+		Str("email", email).
+		Msg("开始 gRPC 等待新邮件 (JMAP)")
+
+	for {
+		select {
+		case emailData := <-emailChan:
+			log.Info().
+				Str("email", email).
+				Msg("通过 gRPC 流收到新邮件 (JMAP)")
+
+			// 发送邮件数据
+			if err := s.sendEmailEvent(stream, emailData); err != nil {
+				return err
+			}
+
+			// 发送完成消息
+			if err := s.sendCompleteEvent(stream, "邮件推送完成 (JMAP)"); err != nil {
+				return err
+			}
+			return nil
+
+		case err := <-errChan:
+			if err == nil {
+				return nil
+			}
+			log.Error().Err(err).Str("email", email).Msg("JMAP 推送监听失败")
+			return status.Error(codes.Internal, err.Error())
+
+		case <-timeout.C:
+			log.Info().
+				Str("email", email).
+				Msg("gRPC JMAP 订阅超时")
+			return status.Error(codes.DeadlineExceeded, "订阅超时")
+
+		case <-heartbeat.C:
+			// 发送心跳
+			if err := s.sendHeartbeatEvent(stream); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			log.Info().
+				Str("email", email).
+				Msg("gRPC JMAP 客户端断开连接")
+			return nil
+		}
+	}
+}
+
+// listenForEwsEmailsStream 监听 EWS 拉取订阅的新邮件流
+func (s *MailServer) listenForEwsEmailsStream(
+	stream pb.MailService_SubscribeMailServer,
+	emailChan <-chan *domain.Email,
+	errChan <-chan error,
+	email string,
+) error {
+	// 设置超时和心跳
+	timeout := time.NewTimer(common.TimeoutMinutes * time.Minute)
+	defer timeout.Stop()
+
+	heartbeat := time.NewTicker(common.HeartbeatIntervalSeconds * time.Second)
+	defer heartbeat.Stop()
+
+	log.Info().
+		Str("email", email).
+		Msg("开始 gRPC 等待新邮件 (EWS)")
+
+	for {
+		select {
+		case emailData := <-emailChan:
+			log.Info().
+				Str("email", email).
+				Msg("通过 gRPC 流收到新邮件 (EWS)")
+
+			// 发送邮件数据
+			if err := s.sendEmailEvent(stream, emailData); err != nil {
+				return err
+			}
+
+			// 发送完成消息
+			if err := s.sendCompleteEvent(stream, "邮件推送完成 (EWS)"); err != nil {
+				return err
+			}
+			return nil
+
+		case err := <-errChan:
+			if err == nil {
+				return nil
+			}
+			log.Error().Err(err).Str("email", email).Msg("EWS 订阅监听失败")
+			return status.Error(codes.Internal, err.Error())
+
+		case <-timeout.C:
+			log.Info().
+				Str("email", email).
+				Msg("gRPC EWS 订阅超时")
+			return status.Error(codes.DeadlineExceeded, "订阅超时")
+
+		case <-heartbeat.C:
+			// 发送心跳
+			if err := s.sendHeartbeatEvent(stream); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			log.Info().
+				Str("email", email).
+				Msg("gRPC EWS 客户端断开连接")
+			return nil
+		}
+	}
+}
+
 // listenForGraphNotificationsStream 监听 Graph 通知流
 func (s *MailServer) listenForGraphNotificationsStream(
 	stream pb.MailService_SubscribeMailServer,
@@ -271,3 +542,75 @@ func (s *MailServer) listenForGraphNotificationsStream(
 		}
 	}
 }
+
+// listenForGmailNotificationsStream 监听 Gmail 推送通知流，收到通知后查询 startHistoryID 之后新增的邮件
+func (s *MailServer) listenForGmailNotificationsStream(
+	stream pb.MailService_SubscribeMailServer,
+	notifyChan chan string,
+	startHistoryID, email, accessToken string,
+) error {
+	// 设置超时和心跳
+	timeout := time.NewTimer(common.TimeoutMinutes * time.Minute)
+	defer timeout.Stop()
+
+	heartbeat := time.NewTicker(common.HeartbeatIntervalSeconds * time.Second)
+	defer heartbeat.Stop()
+
+	log.Info().
+		Str("email", email).
+		Str("historyID", startHistoryID).
+		Msg("开始 gRPC 等待新邮件 (Gmail)")
+
+	for {
+		select {
+		case historyID := <-notifyChan:
+			log.Info().
+				Str("email", email).
+				Str("historyID", historyID).
+				Msg("通过 gRPC 流收到新邮件通知 (Gmail)")
+
+			// 获取新增邮件详情
+			emailData, err := gmailapi.GetLatestAddedEmail(context.Background(), accessToken, startHistoryID)
+			if err != nil {
+				log.Error().Err(err).Str("email", email).Msg("获取邮件详情失败")
+				if err := s.sendErrorEvent(stream, err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			if emailData == nil {
+				// 已读、标签变更等通知，没有新邮件，继续等待
+				continue
+			}
+
+			// 发送邮件数据
+			if err := s.sendEmailEvent(stream, emailData); err != nil {
+				return err
+			}
+
+			// 发送完成消息
+			if err := s.sendCompleteEvent(stream, "邮件推送完成 (Gmail)"); err != nil {
+				return err
+			}
+			return nil
+
+		case <-timeout.C:
+			log.Info().
+				Str("email", email).
+				Msg("gRPC Gmail 订阅超时")
+			return status.Error(codes.DeadlineExceeded, "订阅超时")
+
+		case <-heartbeat.C:
+			// 发送心跳
+			if err := s.sendHeartbeatEvent(stream); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			log.Info().
+				Str("email", email).
+				Msg("gRPC Gmail 客户端断开连接")
+			return nil
+		}
+	}
+}
@@ -1,21 +1,136 @@
 package grpc
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"gomailapi2/internal/domain"
 	"gomailapi2/internal/types"
 	pb "gomailapi2/proto/pb"
+	"io"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// streamChunkSize 流式传输（附件、邮件原始内容）的数据块大小
+const streamChunkSize = 64 * 1024
+
 // protoToMailInfo 将 proto MailInfo 转换为内部 MailInfo
 func protoToMailInfo(protoMailInfo *pb.MailInfo) *types.MailInfo {
 	return &types.MailInfo{
+		AccountID:       protoMailInfo.AccountId,
+		AccountSecret:   protoMailInfo.AccountSecret,
 		Email:           protoMailInfo.Email,
 		ClientID:        protoMailInfo.ClientId,
+		ClientSecret:    protoMailInfo.ClientSecret,
 		RefreshToken:    protoMailInfo.RefreshToken,
 		ProtocolType:    protoProtocolTypeToTypes(protoMailInfo.ProtoType),
 		ServiceProvider: protoServiceProviderToTypes(protoMailInfo.ServiceProvider),
+		Username:        protoMailInfo.Username,
+		Password:        protoMailInfo.Password,
+		AuthMechanism:   protoAuthMechanismToTypes(protoMailInfo.AuthMechanism),
+		Imap:            protoToServerSettings(protoMailInfo.Imap),
+		Smtp:            protoToServerSettings(protoMailInfo.Smtp),
+		Pop3:            protoToServerSettings(protoMailInfo.Pop3),
+		JmapURL:         protoMailInfo.JmapUrl,
+		EwsURL:          protoMailInfo.EwsUrl,
+
+		Tenant:            protoMailInfo.Tenant,
+		ClientCertificate: protoMailInfo.ClientCertificate,
+		ClientPrivateKey:  protoMailInfo.ClientPrivateKey,
+	}
+}
+
+// mailInfoToProto 将内部 MailInfo 转换为 proto MailInfo
+func mailInfoToProto(mailInfo *types.MailInfo) *pb.MailInfo {
+	return &pb.MailInfo{
+		AccountId:       mailInfo.AccountID,
+		AccountSecret:   mailInfo.AccountSecret,
+		Email:           mailInfo.Email,
+		ClientId:        mailInfo.ClientID,
+		ClientSecret:    mailInfo.ClientSecret,
+		RefreshToken:    mailInfo.RefreshToken,
+		ProtoType:       typesToProtoProtocolType(mailInfo.ProtocolType),
+		ServiceProvider: typesToProtoServiceProvider(mailInfo.ServiceProvider),
+		Username:        mailInfo.Username,
+		Password:        mailInfo.Password,
+		AuthMechanism:   typesToProtoAuthMechanism(mailInfo.AuthMechanism),
+		Imap:            serverSettingsToProto(mailInfo.Imap),
+		Smtp:            serverSettingsToProto(mailInfo.Smtp),
+		Pop3:            serverSettingsToProto(mailInfo.Pop3),
+		JmapUrl:         mailInfo.JmapURL,
+		EwsUrl:          mailInfo.EwsURL,
+
+		Tenant:            mailInfo.Tenant,
+		ClientCertificate: mailInfo.ClientCertificate,
+		ClientPrivateKey:  mailInfo.ClientPrivateKey,
+	}
+}
+
+// protoAuthMechanismToTypes 将 proto AuthMechanism 转换为内部 AuthMechanism
+func protoAuthMechanismToTypes(mechanism pb.AuthMechanism) types.AuthMechanism {
+	switch mechanism {
+	case pb.AuthMechanism_PLAIN:
+		return types.AuthMechanismPlain
+	case pb.AuthMechanism_BEARER:
+		return types.AuthMechanismBearer
+	case pb.AuthMechanism_NTLM:
+		return types.AuthMechanismNTLM
+	default:
+		return types.AuthMechanismLogin
+	}
+}
+
+// typesToProtoAuthMechanism 将内部 AuthMechanism 转换为 proto AuthMechanism
+func typesToProtoAuthMechanism(mechanism types.AuthMechanism) pb.AuthMechanism {
+	switch mechanism {
+	case types.AuthMechanismPlain:
+		return pb.AuthMechanism_PLAIN
+	case types.AuthMechanismBearer:
+		return pb.AuthMechanism_BEARER
+	case types.AuthMechanismNTLM:
+		return pb.AuthMechanism_NTLM
+	default:
+		return pb.AuthMechanism_LOGIN
+	}
+}
+
+// protoToServerSettings 将 proto ServerSettings 转换为内部 ServerSettings
+func protoToServerSettings(settings *pb.ServerSettings) *types.ServerSettings {
+	if settings == nil {
+		return nil
+	}
+
+	security := types.SecurityModeTLS
+	if settings.Security == pb.SecurityMode_STARTTLS {
+		security = types.SecurityModeSTARTTLS
+	}
+
+	return &types.ServerSettings{
+		Host:     settings.Host,
+		Port:     int(settings.Port),
+		Security: security,
+	}
+}
+
+// serverSettingsToProto 将内部 ServerSettings 转换为 proto ServerSettings
+func serverSettingsToProto(settings *types.ServerSettings) *pb.ServerSettings {
+	if settings == nil {
+		return nil
+	}
+
+	security := pb.SecurityMode_TLS
+	if settings.Security == types.SecurityModeSTARTTLS {
+		security = pb.SecurityMode_STARTTLS
+	}
+
+	return &pb.ServerSettings{
+		Host:     settings.Host,
+		Port:     int32(settings.Port),
+		Security: security,
 	}
 }
 
@@ -26,6 +141,14 @@ func protoProtocolTypeToTypes(protoType pb.ProtocolType) types.ProtocolType {
 		return types.ProtocolTypeIMAP
 	case pb.ProtocolType_GRAPH:
 		return types.ProtocolTypeGraph
+	case pb.ProtocolType_GMAIL_API:
+		return types.ProtocolTypeGmailAPI
+	case pb.ProtocolType_POP3:
+		return types.ProtocolTypePOP3
+	case pb.ProtocolType_JMAP:
+		return types.ProtocolTypeJMAP
+	case pb.ProtocolType_EWS:
+		return types.ProtocolTypeEWS
 	default:
 		return types.ProtocolTypeIMAP // 默认值
 	}
@@ -38,6 +161,14 @@ func typesToProtoProtocolType(protoType types.ProtocolType) pb.ProtocolType {
 		return pb.ProtocolType_IMAP
 	case types.ProtocolTypeGraph:
 		return pb.ProtocolType_GRAPH
+	case types.ProtocolTypeGmailAPI:
+		return pb.ProtocolType_GMAIL_API
+	case types.ProtocolTypePOP3:
+		return pb.ProtocolType_POP3
+	case types.ProtocolTypeJMAP:
+		return pb.ProtocolType_JMAP
+	case types.ProtocolTypeEWS:
+		return pb.ProtocolType_EWS
 	}
 	return pb.ProtocolType_IMAP
 }
@@ -49,11 +180,150 @@ func protoServiceProviderToTypes(protoProvider pb.ServiceProvider) types.Service
 		return types.ServiceProviderMicrosoft
 	case pb.ServiceProvider_GOOGLE:
 		return types.ServiceProviderGoogle
+	case pb.ServiceProvider_GENERIC:
+		return types.ServiceProviderGeneric
+	case pb.ServiceProvider_YAHOO:
+		return types.ServiceProviderYahoo
+	case pb.ServiceProvider_AOL:
+		return types.ServiceProviderAOL
 	default:
 		return types.ServiceProviderMicrosoft // 默认值
 	}
 }
 
+// typesToProtoServiceProvider 将内部 ServiceProvider 转换为 proto ServiceProvider
+func typesToProtoServiceProvider(provider types.ServiceProvider) pb.ServiceProvider {
+	switch provider {
+	case types.ServiceProviderGoogle:
+		return pb.ServiceProvider_GOOGLE
+	case types.ServiceProviderGeneric:
+		return pb.ServiceProvider_GENERIC
+	case types.ServiceProviderYahoo:
+		return pb.ServiceProvider_YAHOO
+	case types.ServiceProviderAOL:
+		return pb.ServiceProvider_AOL
+	default:
+		return pb.ServiceProvider_MICROSOFT
+	}
+}
+
+// protoToSearchCriteria 将 proto SearchCriteria 转换为 domain.SearchCriteria
+func protoToSearchCriteria(protoCriteria *pb.SearchCriteria) (*domain.SearchCriteria, error) {
+	criteria := &domain.SearchCriteria{}
+	if protoCriteria == nil {
+		return criteria, nil
+	}
+
+	criteria.From = protoCriteria.From
+	criteria.To = protoCriteria.To
+	criteria.Subject = protoCriteria.Subject
+	criteria.Body = protoCriteria.Body
+	criteria.UnreadOnly = protoCriteria.UnreadOnly
+	criteria.Folder = protoCriteria.Folder
+
+	if protoCriteria.Since != "" {
+		since, err := time.Parse(time.RFC3339, protoCriteria.Since)
+		if err != nil {
+			return nil, fmt.Errorf("since 时间格式错误: %w", err)
+		}
+		criteria.Since = since
+	}
+
+	if protoCriteria.Before != "" {
+		before, err := time.Parse(time.RFC3339, protoCriteria.Before)
+		if err != nil {
+			return nil, fmt.Errorf("before 时间格式错误: %w", err)
+		}
+		criteria.Before = before
+	}
+
+	return criteria, nil
+}
+
+// protoToOutgoingEmail 将 proto OutgoingEmail 转换为 domain.OutgoingEmail
+func protoToOutgoingEmail(protoEmail *pb.OutgoingEmail) *domain.OutgoingEmail {
+	if protoEmail == nil {
+		return nil
+	}
+
+	email := &domain.OutgoingEmail{
+		To:        protoToAddresses(protoEmail.To),
+		Cc:        protoToAddresses(protoEmail.Cc),
+		Bcc:       protoToAddresses(protoEmail.Bcc),
+		ReplyTo:   protoToAddresses(protoEmail.ReplyTo),
+		Subject:   protoEmail.Subject,
+		Text:      protoEmail.Text,
+		HTML:      protoEmail.Html,
+		InReplyTo: protoEmail.InReplyTo,
+	}
+
+	email.Attachments = protoToOutgoingAttachments(protoEmail.Attachments)
+
+	return email
+}
+
+// protoToReplyEmail 将 proto ReplyMailRequest 转换为 domain.ReplyEmail
+func protoToReplyEmail(req *pb.ReplyMailRequest) *domain.ReplyEmail {
+	var mode domain.ReplyMode
+	switch req.Mode {
+	case pb.ReplyMode_REPLY:
+		mode = domain.ReplyModeReply
+	case pb.ReplyMode_REPLY_ALL:
+		mode = domain.ReplyModeReplyAll
+	case pb.ReplyMode_FORWARD:
+		mode = domain.ReplyModeForward
+	}
+
+	return &domain.ReplyEmail{
+		Mode:        mode,
+		To:          protoToAddresses(req.To),
+		Cc:          protoToAddresses(req.Cc),
+		Bcc:         protoToAddresses(req.Bcc),
+		Text:        req.Text,
+		HTML:        req.Html,
+		Attachments: protoToOutgoingAttachments(req.Attachments),
+	}
+}
+
+// protoToOutgoingAttachments 将 proto OutgoingAttachment 列表转换为 domain.OutgoingAttachment 列表
+func protoToOutgoingAttachments(attachments []*pb.OutgoingAttachment) []*domain.OutgoingAttachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	result := make([]*domain.OutgoingAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, &domain.OutgoingAttachment{
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			Content:     attachment.Content,
+			ContentID:   attachment.ContentId,
+			IsInline:    attachment.IsInline,
+		})
+	}
+	return result
+}
+
+// protoToAddresses 将 proto EmailAddress 列表转换为 domain.EmailAddress 列表
+func protoToAddresses(addresses []*pb.EmailAddress) []*domain.EmailAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	result := make([]*domain.EmailAddress, 0, len(addresses))
+	for _, address := range addresses {
+		if address == nil {
+			result = append(result, nil)
+			continue
+		}
+		result = append(result, &domain.EmailAddress{
+			Name:    address.Name,
+			Address: address.Address,
+		})
+	}
+	return result
+}
+
 // domainEmailToProto 将 domain.Email 转换为 proto Email
 func domainEmailToProto(email *domain.Email) *pb.Email {
 	if email == nil {
@@ -61,27 +331,97 @@ func domainEmailToProto(email *domain.Email) *pb.Email {
 	}
 
 	result := &pb.Email{
-		Id:      email.ID,
-		Subject: email.Subject,
-		Date:    email.Date,
-		Text:    email.Text,
-		Html:    email.HTML,
+		Id:            email.ID,
+		Subject:       email.Subject,
+		From:          domainAddressToProto(email.From),
+		To:            domainAddressToProto(email.To),
+		Date:          email.Date,
+		Text:          email.Text,
+		Html:          email.HTML,
+		Truncated:     email.Truncated,
+		Sender:        domainAddressToProto(email.Sender),
+		ToRecipients:  domainAddressesToProto(email.ToRecipients),
+		CcRecipients:  domainAddressesToProto(email.CcRecipients),
+		BccRecipients: domainAddressesToProto(email.BccRecipients),
+		ReplyTo:       domainAddressesToProto(email.ReplyTo),
+		References:    email.References,
 	}
 
-	if email.From != nil {
-		result.From = &pb.EmailAddress{
-			Name:    email.From.Name,
-			Address: email.From.Address,
-		}
+	for _, attachment := range email.Attachments {
+		result.Attachments = append(result.Attachments, domainAttachmentToProto(attachment))
 	}
 
-	if email.To != nil {
-		result.To = &pb.EmailAddress{
-			Name:    email.To.Name,
-			Address: email.To.Address,
-		}
+	return result
+}
+
+// domainAddressToProto 将 domain.EmailAddress 转换为 proto EmailAddress
+func domainAddressToProto(address *domain.EmailAddress) *pb.EmailAddress {
+	if address == nil {
+		return nil
+	}
+
+	return &pb.EmailAddress{
+		Name:    address.Name,
+		Address: address.Address,
+	}
+}
+
+// domainAddressesToProto 将 domain.EmailAddress 列表转换为 proto EmailAddress 列表
+func domainAddressesToProto(addresses []*domain.EmailAddress) []*pb.EmailAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	result := make([]*pb.EmailAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, domainAddressToProto(address))
+	}
+	return result
+}
+
+// domainAttachmentToProto 将 domain.Attachment 转换为 proto Attachment
+func domainAttachmentToProto(attachment *domain.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:          attachment.ID,
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		ContentId:   attachment.ContentID,
+		IsInline:    attachment.IsInline,
+	}
+}
+
+// domainEmailPageToProto 将 domain.EmailPage 转换为 proto ListMailResponse
+func domainEmailPageToProto(page *domain.EmailPage) *pb.ListMailResponse {
+	response := &pb.ListMailResponse{
+		Emails: make([]*pb.Email, 0, len(page.Emails)),
+	}
+
+	for _, email := range page.Emails {
+		response.Emails = append(response.Emails, domainEmailToProto(email))
+	}
+
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
 	}
 
+	return response
+}
+
+// domainFoldersToProto 将 domain.Folder 列表转换为 proto Folder 列表
+func domainFoldersToProto(folders []*domain.Folder) []*pb.Folder {
+	result := make([]*pb.Folder, 0, len(folders))
+	for _, folder := range folders {
+		result = append(result, &pb.Folder{
+			Id:          folder.ID,
+			Name:        folder.Name,
+			Path:        folder.Path,
+			ParentId:    folder.ParentID,
+			Role:        folder.Role,
+			TotalCount:  int32(folder.TotalCount),
+			UnreadCount: int32(folder.UnreadCount),
+		})
+	}
 	return result
 }
 
@@ -166,3 +506,32 @@ func (s *MailServer) sendErrorEvent(stream pb.MailService_SubscribeMailServer, e
 
 	return nil
 }
+
+// sendChunks 将内容按固定大小分块读取并通过 send 发送，first 表示是否为第一个数据块
+// 内容为空时也会发送一个空数据块，以便调用方携带元数据
+func sendChunks(ctx context.Context, r io.Reader, send func(data []byte, first bool) error) error {
+	buf := make([]byte, streamChunkSize)
+	first := true
+
+	for {
+		if ctx.Err() != nil {
+			return status.Error(codes.Canceled, "客户端已取消下载")
+		}
+
+		n, readErr := io.ReadFull(r, buf)
+		if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return status.Error(codes.Internal, "读取内容失败: "+readErr.Error())
+		}
+
+		if n > 0 || first {
+			if err := send(buf[:n], first); err != nil {
+				return err
+			}
+			first = false
+		}
+
+		if readErr != nil {
+			return nil
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"gomailapi2/internal/domain"
+	"gomailapi2/internal/store/tokenstore"
 	"gomailapi2/internal/types"
 )
 
@@ -11,13 +13,83 @@ type GetNewJunkMailRequest struct {
 
 // FindMailRequest 查找邮件请求
 type FindMailRequest struct {
-	MailInfo *types.MailInfo `json:"mailInfo"` // 邮箱信息
+	MailInfo *types.MailInfo `json:"mailInfo"`         // 邮箱信息
+	Folder   string          `json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
 }
 
 // GetNewMailRequest 获取最新一封邮件请求
 type GetNewMailRequest struct {
 	MailInfo      *types.MailInfo `json:"mailInfo"`                // 邮箱信息
 	RefreshNeeded bool            `json:"refreshNeeded,omitempty"` // 是否需要刷新 refreshToken
+	Folder        string          `json:"folder,omitempty"`        // 文件夹（角色或文件夹 ID），为空表示收件箱
+}
+
+// ExportMailRequest 导出邮件原始内容请求（邮件 ID 从 URL 路径参数获取）
+type ExportMailRequest struct {
+	MailInfo *types.MailInfo `json:"mailInfo"`         // 邮箱信息
+	Folder   string          `json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
+}
+
+// DownloadAttachmentRequest 下载附件请求（邮件 ID 和附件 ID 从 URL 路径参数获取）
+type DownloadAttachmentRequest struct {
+	MailInfo *types.MailInfo `json:"mailInfo"`         // 邮箱信息
+	Folder   string          `json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
+}
+
+// MarkMailRequest 修改邮件状态请求（邮件 ID 从 URL 路径参数获取，isRead 和 isFlagged 至少指定一个）
+type MarkMailRequest struct {
+	MailInfo  *types.MailInfo `json:"mailInfo"`            // 邮箱信息
+	Folder    string          `json:"folder,omitempty"`    // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
+	IsRead    *bool           `json:"isRead,omitempty"`    // 标记为已读/未读，为空表示不修改
+	IsFlagged *bool           `json:"isFlagged,omitempty"` // 添加/取消星标，为空表示不修改
+}
+
+// MoveMailRequest 移动邮件请求（邮件 ID 从 URL 路径参数获取）
+type MoveMailRequest struct {
+	MailInfo     *types.MailInfo `json:"mailInfo"`         // 邮箱信息
+	Folder       string          `json:"folder,omitempty"` // 邮件当前所在文件夹，为空表示收件箱（仅 IMAP 需要）
+	TargetFolder string          `json:"targetFolder"`     // 目标文件夹（角色或文件夹 ID）
+}
+
+// DeleteMailRequest 删除邮件请求（邮件 ID 从 URL 路径参数获取）
+type DeleteMailRequest struct {
+	MailInfo  *types.MailInfo `json:"mailInfo"`            // 邮箱信息
+	Folder    string          `json:"folder,omitempty"`    // 邮件当前所在文件夹，为空表示收件箱（仅 IMAP 需要）
+	Permanent bool            `json:"permanent,omitempty"` // 是否彻底删除，默认移动到已删除文件夹
+}
+
+// SendMailRequest 发送邮件请求（发件人为 MailInfo 对应的账户）
+type SendMailRequest struct {
+	MailInfo *types.MailInfo       `json:"mailInfo"` // 邮箱信息
+	Message  *domain.OutgoingEmail `json:"message"`  // 待发送的邮件
+}
+
+// ReplyMailRequest 回复/转发邮件请求（邮件 ID 从 URL 路径参数获取）
+type ReplyMailRequest struct {
+	MailInfo *types.MailInfo    `json:"mailInfo"`         // 邮箱信息
+	Folder   string             `json:"folder,omitempty"` // 原邮件所在文件夹，为空表示收件箱（仅 IMAP 需要）
+	Reply    *domain.ReplyEmail `json:"reply"`            // 回复/转发的内容
+}
+
+// ListMailRequest 分页获取邮件列表请求
+type ListMailRequest struct {
+	MailInfo *types.MailInfo `json:"mailInfo"`         // 邮箱信息
+	Folder   string          `json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱
+	Limit    int             `json:"limit,omitempty"`  // 每页数量，默认 10，最大 50
+	Cursor   string          `json:"cursor,omitempty"` // 分页游标（上一页返回的 nextCursor），为空表示从最新的邮件开始
+}
+
+// ListFoldersRequest 获取文件夹列表请求
+type ListFoldersRequest struct {
+	MailInfo *types.MailInfo `json:"mailInfo"` // 邮箱信息
+}
+
+// SearchMailRequest 按条件分页搜索邮件请求
+type SearchMailRequest struct {
+	MailInfo *types.MailInfo        `json:"mailInfo"`         // 邮箱信息
+	Criteria *domain.SearchCriteria `json:"criteria"`         // 搜索条件
+	Limit    int                    `json:"limit,omitempty"`  // 每页数量，默认 10，最大 50
+	Cursor   string                 `json:"cursor,omitempty"` // 分页游标（上一页返回的 nextCursor），为空表示从最新的邮件开始
 }
 
 // SubscribeMailRequest 订阅 -> 获取新到的一封邮件
@@ -53,6 +125,7 @@ type BatchRefreshResult struct {
 	Email           string `json:"email"`                     // 邮箱地址
 	NewRefreshToken string `json:"newRefreshToken,omitempty"` // 新的 refreshToken
 	Error           string `json:"error,omitempty"`           // 错误信息（失败时）
+	Code            string `json:"code,omitempty"`            // 令牌错误码（失败时），如 MFA_REQUIRED
 }
 
 // DetectProtocolTypeRequest 检测协议类型请求
@@ -83,3 +156,59 @@ type BatchDetectProtocolTypeResponse struct {
 	FailCount    int                             `json:"failCount"`    // 失败检测的数量
 	Results      []BatchDetectProtocolTypeResult `json:"results"`      // 详细结果列表
 }
+
+// OAuthStartRequest 开始 OAuth 授权码流程请求（仅支持微软和 Google 账户）
+type OAuthStartRequest struct {
+	ServiceProvider types.ServiceProvider `json:"serviceProvider"`        // 服务提供商（MICROSOFT 或 GOOGLE）
+	ClientID        string                `json:"clientId"`               // 应用的 clientId
+	ClientSecret    string                `json:"clientSecret,omitempty"` // 客户端密钥（Google 账户必填，微软机密客户端可选）
+	Tenant          string                `json:"tenant,omitempty"`       // 微软租户，为空时使用 consumers（个人账户）
+	Scope           string                `json:"scope,omitempty"`        // 申请的权限（空格分隔），为空时使用默认权限
+	RedirectURI     string                `json:"redirectUri,omitempty"`  // 回调地址（需要在应用中注册并指向 /gomailapi2/oauth/callback），为空时使用 {webhook.base_url}/gomailapi2/oauth/callback
+	LoginHint       string                `json:"loginHint,omitempty"`    // 预先填写的登录邮箱
+}
+
+// OAuthStartResponse 开始 OAuth 授权码流程响应
+type OAuthStartResponse struct {
+	AuthURL   string `json:"authUrl"`   // 授权地址，在浏览器中打开完成登录和授权
+	State     string `json:"state"`     // 授权请求的 state
+	ExpiresIn int    `json:"expiresIn"` // 授权请求的有效期（秒），超时后回调失败
+}
+
+// OAuthCallbackResponse OAuth 回调响应
+type OAuthCallbackResponse struct {
+	MailInfo *types.MailInfo `json:"mailInfo"` // 可直接使用的邮箱信息（邮箱地址、clientId、refreshToken 和检测到的协议类型）
+}
+
+// DeviceAuthorizationRequest 设备码授权请求（仅支持微软账户）
+type DeviceAuthorizationRequest struct {
+	ServiceProvider types.ServiceProvider `json:"serviceProvider"`  // 服务提供商（MICROSOFT）
+	ClientID        string                `json:"clientId"`         // 应用的 clientId（需要在应用中允许公共客户端流）
+	Tenant          string                `json:"tenant,omitempty"` // 微软租户，为空时使用 consumers（个人账户）
+	Scope           string                `json:"scope,omitempty"`  // 申请的权限（空格分隔），为空时使用默认权限
+}
+
+// DeviceCodeResponse 设备码信息（提示用户在验证页面输入代码）
+type DeviceCodeResponse struct {
+	UserCode        string `json:"userCode"`        // 用户在验证页面输入的代码
+	VerificationURI string `json:"verificationUri"` // 验证页面地址
+	ExpiresIn       int64  `json:"expiresIn"`       // 设备码有效期（秒）
+	Message         string `json:"message"`         // 提示用户的说明文字
+}
+
+// RegisterAccountRequest 保存账户请求（启用 refresh token 存储时可用）
+type RegisterAccountRequest struct {
+	MailInfo *types.MailInfo `json:"mailInfo"` // 邮箱信息（包含 refreshToken 或密码）
+}
+
+// RegisterAccountResponse 保存账户响应
+type RegisterAccountResponse struct {
+	*tokenstore.Account
+	Secret string `json:"secret"` // 账户的访问密钥，只在保存时返回，之后使用 accountId 时必须同时提供
+}
+
+// AccountHistoryResponse 账户的 refreshToken 变更记录
+type AccountHistoryResponse struct {
+	Account *tokenstore.Account   `json:"account"` // 账户基本信息
+	History []tokenstore.Rotation `json:"history"` // 变更记录（按时间倒序）
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/store/tokenstore"
+	"gomailapi2/internal/types"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleRegisterAccount 保存账户（加密），返回账户 ID 和访问密钥；之后的请求可以只传 accountId 和 accountSecret 代替邮箱信息
+func HandleRegisterAccount(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request dto.RegisterAccountRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		account, secret, err := tokenProvider.RegisterAccount(request.MailInfo)
+		if err != nil {
+			log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("保存账户失败")
+			c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.RegisterAccountResponse{
+			Account: account,
+			Secret:  secret,
+		})
+	}
+}
+
+// accountSecretHeader 账户端点传递访问密钥的请求头
+const accountSecretHeader = "X-Account-Secret"
+
+// HandleGetAccount 获取已保存账户的基本信息（不包含凭据）
+func HandleGetAccount(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		account, err := tokenProvider.GetAccount(c.Param("accountID"), c.GetHeader(accountSecretHeader))
+		if err != nil {
+			c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, account)
+	}
+}
+
+// HandleAccountHistory 获取已保存账户的 refreshToken 变更记录
+func HandleAccountHistory(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountID := c.Param("accountID")
+		secret := c.GetHeader(accountSecretHeader)
+
+		account, err := tokenProvider.GetAccount(accountID, secret)
+		if err != nil {
+			c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		history, err := tokenProvider.AccountHistory(accountID, secret)
+		if err != nil {
+			log.Error().Err(err).Str("accountId", accountID).Msg("获取账户变更记录失败")
+			c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AccountHistoryResponse{
+			Account: account,
+			History: history,
+		})
+	}
+}
+
+// HandleDeleteAccount 删除已保存的账户及其变更记录
+func HandleDeleteAccount(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountID := c.Param("accountID")
+
+		if err := tokenProvider.DeleteAccount(accountID, c.GetHeader(accountSecretHeader)); err != nil {
+			log.Error().Err(err).Str("accountId", accountID).Msg("删除账户失败")
+			c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"accountId": accountID})
+	}
+}
+
+// maxRequestBodySize ResolveAccounts 读取的请求体的最大字节数（发送邮件的附件以 base64 编码，需要留出余量）
+const maxRequestBodySize = 40 << 20
+
+// ResolveAccounts 中间件：请求体中的 mailInfo（或 mailInfos）只包含 accountId 时，校验 accountSecret 后使用已保存的账户信息替换
+// 之后的处理器不需要关心请求使用的是账户 ID 还是完整的邮箱信息
+// 处理器使用 ShouldBindJSON 解析请求体（不检查 Content-Type），因此这里也不检查，避免绕过密钥校验
+func ResolveAccounts(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize))
+		c.Request.Body.Close()
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("请求体不能超过 %d 字节", maxBytesErr.Limit)})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resolved, err := resolveAccountsInBody(tokenProvider, body)
+		if err != nil {
+			log.Error().Err(err).Msg("读取已保存的账户失败")
+			c.AbortWithStatusJSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		if resolved != nil {
+			body = resolved
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Request.ContentLength = int64(len(body))
+		c.Next()
+	}
+}
+
+// resolveAccountsInBody 替换请求体中使用 accountId 的 mailInfo 和 mailInfos，没有需要替换的内容时返回 nil
+// 请求体不是 JSON 对象时不处理，由处理器返回解析错误
+func resolveAccountsInBody(tokenProvider *token.TokenProvider, body []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, nil
+	}
+
+	changed := false
+
+	if raw, ok := fields["mailInfo"]; ok {
+		var mailInfo types.MailInfo
+		if err := json.Unmarshal(raw, &mailInfo); err == nil && mailInfo.AccountID != "" {
+			if err := tokenProvider.ResolveAccount(&mailInfo); err != nil {
+				return nil, err
+			}
+			if fields["mailInfo"], err = json.Marshal(&mailInfo); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+
+	if raw, ok := fields["mailInfos"]; ok {
+		var mailInfos []*types.MailInfo
+		if err := json.Unmarshal(raw, &mailInfos); err == nil {
+			listChanged := false
+			for _, mailInfo := range mailInfos {
+				if mailInfo == nil || mailInfo.AccountID == "" {
+					continue
+				}
+				if err := tokenProvider.ResolveAccount(mailInfo); err != nil {
+					return nil, err
+				}
+				listChanged = true
+			}
+			if listChanged {
+				if fields["mailInfos"], err = json.Marshal(mailInfos); err != nil {
+					return nil, err
+				}
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
+
+// accountErrorStatus 账户相关错误对应的 HTTP 状态码
+func accountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tokenstore.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, tokenstore.ErrInvalidSecret):
+		return http.StatusUnauthorized
+	case errors.Is(err, token.ErrTokenStoreDisabled):
+		return http.StatusNotImplemented
+	default:
+		return http.StatusBadRequest
+	}
+}
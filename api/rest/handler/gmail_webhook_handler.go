@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"gomailapi2/api/common"
+	"gomailapi2/internal/client/gmailapi"
+	"gomailapi2/internal/manager"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleGmailWebhook 处理 Gmail 通过 Pub/Sub 推送订阅发送的通知
+// Pub/Sub 对非 2xx 响应会不断重试，无法处理的通知同样返回 204 确认，避免重复推送
+func HandleGmailWebhook(nfManager *manager.NotificationManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 读取请求体
+		body, err := c.GetRawData()
+		if err != nil {
+			log.Error().Err(err).Msg("读取 Gmail 推送内容失败")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "无法读取请求体"})
+			return
+		}
+
+		pushData, err := gmailapi.ParsePushNotification(body)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(body)).Msg("解析 Gmail 推送内容失败")
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		log.Info().
+			Str("email", pushData.EmailAddress).
+			Uint64("historyID", pushData.HistoryID).
+			Msg("收到 Gmail 推送通知")
+
+		// 通知内容只有 historyId，新邮件由订阅方通过 history.list 获取
+		key := common.GmailNotificationKey(pushData.EmailAddress)
+		if !nfManager.SendNotification(key, strconv.FormatUint(pushData.HistoryID, 10)) {
+			log.Warn().
+				Str("email", pushData.EmailAddress).
+				Msg("发送 Gmail 邮件通知失败，可能是订阅已结束或通道不存在")
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/domain"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedDownloadAttachment 统一处理下载附件的请求，支持 Graph API 和 IMAP 协议
+func HandleUnifiedDownloadAttachment(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从路径中获取 emailID 和 attachmentID
+		emailID := c.Param("emailID")
+		attachmentID := c.Param("attachmentID")
+		if emailID == "" || attachmentID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "邮件 ID 和附件 ID 不能为空"})
+			return
+		}
+
+		// 解析请求
+		request, err := parseDownloadAttachmentRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Str("emailID", emailID).
+			Str("attachmentID", attachmentID).
+			Str("folder", request.Folder).
+			Msg("收到下载附件请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphDownloadAttachment(c, request, tokenProvider, emailID, attachmentID)
+		case types.ProtocolTypeIMAP:
+			handleImapDownloadAttachment(c, request, tokenProvider, emailID, attachmentID)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphDownloadAttachment 处理 Graph API 协议的附件下载
+func handleGraphDownloadAttachment(c *gin.Context, request *dto.DownloadAttachmentRequest, tokenProvider *token.TokenProvider, emailID, attachmentID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 下载附件
+	attachment, err := graph.GetAttachment(context.Background(), accessToken, emailID, attachmentID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("attachmentID", attachmentID).Msg("通过 Graph API 下载附件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer attachment.Content.Close()
+
+	writeAttachment(c, attachment)
+}
+
+// handleImapDownloadAttachment 处理 IMAP 协议的附件下载
+func handleImapDownloadAttachment(c *gin.Context, request *dto.DownloadAttachmentRequest, tokenProvider *token.TokenProvider, emailID, attachmentID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 下载附件
+	attachment, err := imapClient.FetchAttachment(emailID, request.Folder, attachmentID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("attachmentID", attachmentID).Msg("通过 IMAP 下载附件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer attachment.Content.Close()
+
+	writeAttachment(c, attachment)
+}
+
+// writeAttachment 以文件下载的形式返回附件内容
+func writeAttachment(c *gin.Context, attachment *domain.AttachmentContent) {
+	contentType := attachment.Attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	filename := attachment.Attachment.Name
+	if filename == "" {
+		filename = "attachment"
+	}
+
+	// 解码后的实际大小未知，不设置 Content-Length
+	c.DataFromReader(http.StatusOK, -1, contentType, attachment.Content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": filename}),
+	})
+
+	log.Info().Str("attachmentID", attachment.Attachment.ID).Str("name", attachment.Attachment.Name).Msg("附件下载完成")
+}
+
+// parseDownloadAttachmentRequest 解析下载附件请求
+func parseDownloadAttachmentRequest(c *gin.Context) (*dto.DownloadAttachmentRequest, error) {
+	var request dto.DownloadAttachmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析下载附件请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
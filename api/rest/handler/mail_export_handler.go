@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/gmailapi"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedExportMail 统一处理导出邮件原始内容（.eml）的请求，支持 Graph API、Gmail API 和 IMAP 协议
+func HandleUnifiedExportMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从路径中获取 emailID
+		emailID := c.Param("emailID")
+		if emailID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "邮件 ID 不能为空"})
+			return
+		}
+
+		// 解析请求
+		request, err := parseExportMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Str("emailID", emailID).
+			Str("folder", request.Folder).
+			Msg("收到导出邮件请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphExportMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeGmailAPI:
+			handleGmailExportMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeIMAP:
+			handleImapExportMail(c, request, tokenProvider, emailID)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphExportMail 处理 Graph API 协议的邮件导出
+func handleGraphExportMail(c *gin.Context, request *dto.ExportMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取邮件原始内容
+	content, err := graph.GetRawEmail(context.Background(), accessToken, emailID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 Graph API 导出邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	writeEml(c, emailID, content)
+}
+
+// handleGmailExportMail 处理 Gmail API 协议的邮件导出
+func handleGmailExportMail(c *gin.Context, request *dto.ExportMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Gmail API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取邮件原始内容
+	content, err := gmailapi.GetRawEmail(context.Background(), accessToken, emailID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 Gmail API 导出邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	writeEml(c, emailID, content)
+}
+
+// handleImapExportMail 处理 IMAP 协议的邮件导出
+func handleImapExportMail(c *gin.Context, request *dto.ExportMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 获取邮件原始内容
+	content, err := imapClient.FetchRawEmail(emailID, request.Folder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 IMAP 导出邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	writeEml(c, emailID, content)
+}
+
+// writeEml 以 .eml 文件下载的形式返回邮件原始内容
+func writeEml(c *gin.Context, emailID string, content io.Reader) {
+	// 邮件 ID 可能包含文件名中不允许的字符，统一替换为下划线
+	filename := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune(".-_@", r) {
+			return r
+		}
+		return '_'
+	}, emailID) + ".eml"
+
+	c.DataFromReader(http.StatusOK, -1, "message/rfc822", content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": filename}),
+	})
+
+	log.Info().Str("emailID", emailID).Msg("邮件导出完成")
+}
+
+// parseExportMailRequest 解析导出邮件请求
+func parseExportMailRequest(c *gin.Context) (*dto.ExportMailRequest, error) {
+	var request dto.ExportMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析导出邮件请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"gomailapi2/api/common"
 	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/gmailapi"
 	"gomailapi2/internal/client/graph"
-	"gomailapi2/internal/client/imap/outlook"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/types"
 	"net/http"
@@ -14,7 +14,7 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// HandleUnifiedFindMail 统一处理查找邮件的请求，支持 Graph API 和 IMAP 协议
+// HandleUnifiedFindMail 统一处理查找邮件的请求，支持 Graph API、Gmail API、IMAP、POP3、JMAP 和 EWS 协议
 func HandleUnifiedFindMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从路径中获取 emailID
@@ -42,14 +42,23 @@ func HandleUnifiedFindMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 			Str("protocol", string(request.MailInfo.ProtocolType)).
 			Str("provider", string(request.MailInfo.ServiceProvider)).
 			Str("emailID", emailID).
+			Str("folder", request.Folder).
 			Msg("收到查找邮件请求")
 
 		// 根据协议类型处理请求
 		switch request.MailInfo.ProtocolType {
 		case types.ProtocolTypeGraph:
 			handleGraphFindMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeGmailAPI:
+			handleGmailFindMail(c, request, tokenProvider, emailID)
 		case types.ProtocolTypeIMAP:
 			handleImapFindMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypePOP3:
+			handlePop3FindMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeJMAP:
+			handleJmapFindMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeEWS:
+			handleEwsFindMail(c, request, tokenProvider, emailID)
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
@@ -65,7 +74,7 @@ func handleGraphFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProv
 	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
 		return
 	}
 
@@ -81,6 +90,28 @@ func handleGraphFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProv
 	c.JSON(http.StatusOK, gin.H{"email": email})
 }
 
+// handleGmailFindMail 处理 Gmail API 协议的邮件查找
+func handleGmailFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Gmail API 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 根据邮件 ID 查找邮件
+	email, err := gmailapi.GetEmailByID(context.Background(), accessToken, emailID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 Gmail API 查找邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 Gmail API 查找邮件")
+	c.JSON(http.StatusOK, gin.H{"email": email})
+}
+
 // CAAct-4UR-Q_MgMpp4t6-M-V_i7++Efn2yKDR1vRc975bcgan-A@mail.gmail.com
 // handleImapFindMail 处理 IMAP 协议的邮件查找
 func handleImapFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvider *token.TokenProvider, emailID string) {
@@ -88,15 +119,15 @@ func handleImapFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvi
 	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
 		return
 	}
 
 	// 创建 IMAP 客户端
-	imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(request.MailInfo), accessToken)
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
 
 	// 根据邮件 ID 查找邮件
-	email, err := imapClient.FetchEmailByID(emailID)
+	email, err := imapClient.FetchEmailByID(emailID, request.Folder)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 IMAP 查找邮件失败")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -107,6 +138,80 @@ func handleImapFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvi
 	c.JSON(http.StatusOK, gin.H{"email": email})
 }
 
+// handlePop3FindMail 处理 POP3 协议的邮件查找（逐封获取邮件头比对 Message-ID，邮件较多时较慢）
+func handlePop3FindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 POP3 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 POP3 客户端
+	pop3Client := common.NewPop3Client(request.MailInfo, accessToken)
+
+	// 根据邮件 ID 查找邮件
+	email, err := pop3Client.FetchEmailByID(emailID, request.Folder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 POP3 查找邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 POP3 查找邮件")
+	c.JSON(http.StatusOK, gin.H{"email": email})
+}
+
+// handleJmapFindMail 处理 JMAP 协议的邮件查找（邮件 ID 为 JMAP 邮件 ID）
+func handleJmapFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// JMAP 使用密码或 API 令牌认证，这里只校验账户配置
+	if _, err := tokenProvider.GetAccessToken(request.MailInfo); err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 JMAP 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 JMAP 客户端
+	jmapClient := common.NewJmapClient(request.MailInfo)
+
+	// 根据邮件 ID 查找邮件
+	email, err := jmapClient.GetEmailByID(context.Background(), emailID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 JMAP 查找邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 JMAP 查找邮件")
+	c.JSON(http.StatusOK, gin.H{"email": email})
+}
+
+// handleEwsFindMail 处理 EWS 协议的邮件查找（邮件 ID 为 URL 安全编码的 EWS ItemId）
+func handleEwsFindMail(c *gin.Context, request *dto.FindMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 EWS 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 EWS 客户端
+	ewsClient := common.NewEwsClient(request.MailInfo, accessToken)
+
+	// 根据邮件 ID 查找邮件
+	email, err := ewsClient.GetEmailByID(context.Background(), emailID)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 EWS 查找邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 EWS 查找邮件")
+	c.JSON(http.StatusOK, gin.H{"email": email})
+}
+
 // parseFindMailRequest 解析查找邮件请求
 func parseFindMailRequest(c *gin.Context) (*dto.FindMailRequest, error) {
 	var request dto.FindMailRequest
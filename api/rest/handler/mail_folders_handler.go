@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedListFolders 统一处理获取文件夹列表的请求，支持 Graph API 和 IMAP 协议
+func HandleUnifiedListFolders(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 解析请求
+		request, err := parseListFoldersRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Msg("收到获取文件夹列表请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphListFolders(c, request, tokenProvider)
+		case types.ProtocolTypeIMAP:
+			handleImapListFolders(c, request, tokenProvider)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphListFolders 处理 Graph API 协议的文件夹列表获取
+func handleGraphListFolders(c *gin.Context, request *dto.ListFoldersRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取文件夹列表
+	folders, err := graph.ListFolders(context.Background(), accessToken)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Graph API 获取文件夹列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(folders)).Msg("成功通过 Graph API 获取文件夹列表")
+	c.JSON(http.StatusOK, gin.H{"folders": folders})
+}
+
+// handleImapListFolders 处理 IMAP 协议的文件夹列表获取
+func handleImapListFolders(c *gin.Context, request *dto.ListFoldersRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 获取文件夹列表
+	folders, err := imapClient.ListFolders()
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 IMAP 获取文件夹列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(folders)).Msg("成功通过 IMAP 获取文件夹列表")
+	c.JSON(http.StatusOK, gin.H{"folders": folders})
+}
+
+// parseListFoldersRequest 解析获取文件夹列表请求
+func parseListFoldersRequest(c *gin.Context) (*dto.ListFoldersRequest, error) {
+	var request dto.ListFoldersRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析获取文件夹列表请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
@@ -3,8 +3,8 @@ package handler
 import (
 	"context"
 	"gomailapi2/api/common"
+	"gomailapi2/internal/client/gmailapi"
 	"gomailapi2/internal/client/graph"
-	"gomailapi2/internal/client/imap/outlook"
 	"gomailapi2/internal/provider/token"
 
 	"gomailapi2/api/rest/dto"
@@ -15,7 +15,7 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// HandleUnifiedJunkMail 统一处理获取垃圾邮件的请求，支持 Graph API 和 IMAP 协议
+// HandleUnifiedJunkMail 统一处理获取垃圾邮件的请求，支持 Graph API、Gmail API、IMAP 和 EWS 协议
 func HandleUnifiedJunkMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 解析请求
@@ -41,8 +41,12 @@ func HandleUnifiedJunkMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 		switch request.MailInfo.ProtocolType {
 		case types.ProtocolTypeGraph:
 			handleGraphJunkMail(c, request, tokenProvider)
+		case types.ProtocolTypeGmailAPI:
+			handleGmailJunkMail(c, request, tokenProvider)
 		case types.ProtocolTypeIMAP:
 			handleImapJunkMail(c, request, tokenProvider)
+		case types.ProtocolTypeEWS:
+			handleEwsJunkMail(c, request, tokenProvider)
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
@@ -57,7 +61,7 @@ func handleGraphJunkMail(c *gin.Context, request *dto.GetNewJunkMailRequest, tok
 	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
 		return
 	}
 
@@ -73,18 +77,40 @@ func handleGraphJunkMail(c *gin.Context, request *dto.GetNewJunkMailRequest, tok
 	c.JSON(http.StatusOK, email)
 }
 
+// handleGmailJunkMail 处理 Gmail API 协议的垃圾邮件获取
+func handleGmailJunkMail(c *gin.Context, request *dto.GetNewJunkMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Gmail API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取垃圾邮件
+	email, err := gmailapi.GetLatestEmailFromJunk(context.Background(), accessToken)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Gmail API 获取垃圾邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 Gmail API 获取垃圾邮件")
+	c.JSON(http.StatusOK, email)
+}
+
 // handleImapJunkMail 处理 IMAP 协议的垃圾邮件获取
 func handleImapJunkMail(c *gin.Context, request *dto.GetNewJunkMailRequest, tokenProvider *token.TokenProvider) {
 	// 获取令牌
 	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
 		return
 	}
 
 	// 创建 IMAP 客户端
-	imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(request.MailInfo), accessToken)
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
 
 	// 获取垃圾邮件
 	email, err := imapClient.FetchLatestJunkEmail()
@@ -98,6 +124,31 @@ func handleImapJunkMail(c *gin.Context, request *dto.GetNewJunkMailRequest, toke
 	c.JSON(http.StatusOK, email)
 }
 
+// handleEwsJunkMail 处理 EWS 协议的垃圾邮件获取
+func handleEwsJunkMail(c *gin.Context, request *dto.GetNewJunkMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 EWS 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 EWS 客户端
+	ewsClient := common.NewEwsClient(request.MailInfo, accessToken)
+
+	// 获取垃圾邮件
+	email, err := ewsClient.GetLatestEmailFromJunk(context.Background())
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 EWS 获取垃圾邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 EWS 获取垃圾邮件")
+	c.JSON(http.StatusOK, email)
+}
+
 // parseJunkMailRequest 解析获取垃圾邮件请求
 func parseJunkMailRequest(c *gin.Context) (*dto.GetNewJunkMailRequest, error) {
 	var request dto.GetNewJunkMailRequest
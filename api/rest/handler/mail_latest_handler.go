@@ -4,8 +4,8 @@ import (
 	"context"
 	"gomailapi2/api/common"
 	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/gmailapi"
 	"gomailapi2/internal/client/graph"
-	"gomailapi2/internal/client/imap/outlook"
 	"gomailapi2/internal/domain"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/types"
@@ -15,7 +15,7 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// HandleUnifiedLatestMail 统一处理获取最新邮件的请求，支持 Graph API 和 IMAP 协议
+// HandleUnifiedLatestMail 统一处理获取最新邮件的请求，支持 Graph API、Gmail API、IMAP、POP3、JMAP 和 EWS 协议
 func HandleUnifiedLatestMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 解析请求
@@ -36,14 +36,23 @@ func HandleUnifiedLatestMail(tokenProvider *token.TokenProvider) gin.HandlerFunc
 			Str("protocol", string(request.MailInfo.ProtocolType)).
 			Str("provider", string(request.MailInfo.ServiceProvider)).
 			Bool("refreshNeeded", request.RefreshNeeded).
+			Str("folder", request.Folder).
 			Msg("收到获取最新邮件请求")
 
 		// 根据协议类型处理请求
 		switch request.MailInfo.ProtocolType {
 		case types.ProtocolTypeGraph:
 			handleGraphLatestMail(c, request, tokenProvider)
+		case types.ProtocolTypeGmailAPI:
+			handleGmailLatestMail(c, request, tokenProvider)
 		case types.ProtocolTypeIMAP:
 			handleImapLatestMail(c, request, tokenProvider)
+		case types.ProtocolTypePOP3:
+			handlePop3LatestMail(c, request, tokenProvider)
+		case types.ProtocolTypeJMAP:
+			handleJmapLatestMail(c, request, tokenProvider)
+		case types.ProtocolTypeEWS:
+			handleEwsLatestMail(c, request, tokenProvider)
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
@@ -58,12 +67,12 @@ func handleGraphLatestMail(c *gin.Context, request *dto.GetNewMailRequest, token
 	accessToken, refreshToken, err := common.GetTokens(tokenProvider, request.RefreshNeeded, request.MailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
 		return
 	}
 
 	// 获取最新邮件
-	email, err := graph.GetLatestEmail(context.Background(), accessToken)
+	email, err := graph.GetLatestEmail(context.Background(), accessToken, request.Folder)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Graph API 获取最新邮件失败")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -76,21 +85,45 @@ func handleGraphLatestMail(c *gin.Context, request *dto.GetNewMailRequest, token
 	c.JSON(http.StatusOK, response)
 }
 
+// handleGmailLatestMail 处理 Gmail API 协议的最新邮件获取
+func handleGmailLatestMail(c *gin.Context, request *dto.GetNewMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, refreshToken, err := common.GetTokens(tokenProvider, request.RefreshNeeded, request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Gmail API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取最新邮件
+	email, err := gmailapi.GetLatestEmail(context.Background(), accessToken, request.Folder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Gmail API 获取最新邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := buildResponse(email, refreshToken)
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 Gmail API 获取最新邮件")
+	c.JSON(http.StatusOK, response)
+}
+
 // handleImapLatestMail 处理 IMAP 协议的最新邮件获取
 func handleImapLatestMail(c *gin.Context, request *dto.GetNewMailRequest, tokenProvider *token.TokenProvider) {
 	// 获取令牌
 	accessToken, refreshToken, err := common.GetTokens(tokenProvider, request.RefreshNeeded, request.MailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
 		return
 	}
 
 	// 创建 IMAP 客户端
-	imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(request.MailInfo), accessToken)
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
 
 	// 获取最新邮件
-	email, err := imapClient.FetchLatestEmail()
+	email, err := imapClient.FetchLatestEmail(request.Folder)
 	if err != nil {
 		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 IMAP 获取最新邮件失败")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -103,6 +136,87 @@ func handleImapLatestMail(c *gin.Context, request *dto.GetNewMailRequest, tokenP
 	c.JSON(http.StatusOK, response)
 }
 
+// handlePop3LatestMail 处理 POP3 协议的最新邮件获取（只能获取收件箱）
+func handlePop3LatestMail(c *gin.Context, request *dto.GetNewMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取令牌
+	accessToken, refreshToken, err := common.GetTokens(tokenProvider, request.RefreshNeeded, request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 POP3 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 POP3 客户端
+	pop3Client := common.NewPop3Client(request.MailInfo, accessToken)
+
+	// 获取最新邮件
+	email, err := pop3Client.FetchLatestEmail(request.Folder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 POP3 获取最新邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := buildResponse(email, refreshToken)
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 POP3 获取最新邮件")
+	c.JSON(http.StatusOK, response)
+}
+
+// handleJmapLatestMail 处理 JMAP 协议的最新邮件获取
+func handleJmapLatestMail(c *gin.Context, request *dto.GetNewMailRequest, tokenProvider *token.TokenProvider) {
+	// JMAP 使用密码或 API 令牌认证，这里只校验账户配置
+	_, refreshToken, err := common.GetTokens(tokenProvider, request.RefreshNeeded, request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 JMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 JMAP 客户端
+	jmapClient := common.NewJmapClient(request.MailInfo)
+
+	// 获取最新邮件
+	email, err := jmapClient.GetLatestEmail(context.Background(), request.Folder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 JMAP 获取最新邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := buildResponse(email, refreshToken)
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 JMAP 获取最新邮件")
+	c.JSON(http.StatusOK, response)
+}
+
+// handleEwsLatestMail 处理 EWS 协议的最新邮件获取
+func handleEwsLatestMail(c *gin.Context, request *dto.GetNewMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取令牌（密码认证账户只校验配置）
+	accessToken, refreshToken, err := common.GetTokens(tokenProvider, request.RefreshNeeded, request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 EWS 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 EWS 客户端
+	ewsClient := common.NewEwsClient(request.MailInfo, accessToken)
+
+	// 获取最新邮件
+	email, err := ewsClient.GetLatestEmail(context.Background(), request.Folder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 EWS 获取最新邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := buildResponse(email, refreshToken)
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 EWS 获取最新邮件")
+	c.JSON(http.StatusOK, response)
+}
+
 func buildResponse(email *domain.Email, refreshToken string) gin.H {
 	response := gin.H{
 		"email": email,
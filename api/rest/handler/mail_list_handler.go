@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/gmailapi"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedListMail 统一处理分页获取邮件列表的请求，支持 Graph API、Gmail API、IMAP、POP3 和 JMAP 协议
+func HandleUnifiedListMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 解析请求
+		request, err := parseListMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		request.Limit = common.NormalizeListLimit(request.Limit)
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Str("folder", request.Folder).
+			Int("limit", request.Limit).
+			Str("cursor", request.Cursor).
+			Msg("收到获取邮件列表请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphListMail(c, request, tokenProvider)
+		case types.ProtocolTypeGmailAPI:
+			handleGmailListMail(c, request, tokenProvider)
+		case types.ProtocolTypeIMAP:
+			handleImapListMail(c, request, tokenProvider)
+		case types.ProtocolTypePOP3:
+			handlePop3ListMail(c, request, tokenProvider)
+		case types.ProtocolTypeJMAP:
+			handleJmapListMail(c, request, tokenProvider)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphListMail 处理 Graph API 协议的邮件列表获取
+func handleGraphListMail(c *gin.Context, request *dto.ListMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取邮件列表
+	page, err := graph.ListEmails(context.Background(), accessToken, request.Folder, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Graph API 获取邮件列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 Graph API 获取邮件列表")
+	c.JSON(http.StatusOK, page)
+}
+
+// handleGmailListMail 处理 Gmail API 协议的邮件列表获取
+func handleGmailListMail(c *gin.Context, request *dto.ListMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Gmail API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 获取邮件列表
+	page, err := gmailapi.ListEmails(context.Background(), accessToken, request.Folder, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Gmail API 获取邮件列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 Gmail API 获取邮件列表")
+	c.JSON(http.StatusOK, page)
+}
+
+// handleImapListMail 处理 IMAP 协议的邮件列表获取
+func handleImapListMail(c *gin.Context, request *dto.ListMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 获取邮件列表
+	page, err := imapClient.ListEmails(request.Folder, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 IMAP 获取邮件列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 IMAP 获取邮件列表")
+	c.JSON(http.StatusOK, page)
+}
+
+// handlePop3ListMail 处理 POP3 协议的邮件列表获取（只能获取收件箱）
+func handlePop3ListMail(c *gin.Context, request *dto.ListMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 POP3 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 POP3 客户端
+	pop3Client := common.NewPop3Client(request.MailInfo, accessToken)
+
+	// 获取邮件列表
+	page, err := pop3Client.ListEmails(request.Folder, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 POP3 获取邮件列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 POP3 获取邮件列表")
+	c.JSON(http.StatusOK, page)
+}
+
+// handleJmapListMail 处理 JMAP 协议的邮件列表获取
+func handleJmapListMail(c *gin.Context, request *dto.ListMailRequest, tokenProvider *token.TokenProvider) {
+	// JMAP 使用密码或 API 令牌认证，这里只校验账户配置
+	if _, err := tokenProvider.GetAccessToken(request.MailInfo); err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 JMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 JMAP 客户端
+	jmapClient := common.NewJmapClient(request.MailInfo)
+
+	// 获取邮件列表
+	page, err := jmapClient.ListEmails(context.Background(), request.Folder, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 JMAP 获取邮件列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 JMAP 获取邮件列表")
+	c.JSON(http.StatusOK, page)
+}
+
+// parseListMailRequest 解析获取邮件列表请求
+func parseListMailRequest(c *gin.Context) (*dto.ListMailRequest, error) {
+	var request dto.ListMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析获取邮件列表请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/domain"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedMarkMail 统一处理修改邮件状态的请求，支持 Graph API 和 IMAP 协议
+func HandleUnifiedMarkMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从路径中获取 emailID
+		emailID := c.Param("emailID")
+		if emailID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "邮件 ID 不能为空"})
+			return
+		}
+
+		// 解析请求
+		request, err := parseMarkMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		// 至少需要修改一种状态
+		if request.IsRead == nil && request.IsFlagged == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "isRead 和 isFlagged 至少指定一个"})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Str("emailID", emailID).
+			Str("folder", request.Folder).
+			Msg("收到修改邮件状态请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphMarkMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeIMAP:
+			handleImapMarkMail(c, request, tokenProvider, emailID)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphMarkMail 处理 Graph API 协议的邮件状态修改
+func handleGraphMarkMail(c *gin.Context, request *dto.MarkMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 修改邮件状态
+	err = graph.UpdateEmailFlags(context.Background(), accessToken, emailID, markRequestToUpdate(request))
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 Graph API 修改邮件状态失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 Graph API 修改邮件状态")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件状态修改成功"})
+}
+
+// handleImapMarkMail 处理 IMAP 协议的邮件状态修改
+func handleImapMarkMail(c *gin.Context, request *dto.MarkMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 修改邮件状态
+	err = imapClient.UpdateFlags(emailID, request.Folder, markRequestToUpdate(request))
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 IMAP 修改邮件状态失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 IMAP 修改邮件状态")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件状态修改成功"})
+}
+
+// parseMarkMailRequest 解析修改邮件状态请求
+func parseMarkMailRequest(c *gin.Context) (*dto.MarkMailRequest, error) {
+	var request dto.MarkMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析修改邮件状态请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
+
+// markRequestToUpdate 将修改邮件状态请求转换为状态修改
+func markRequestToUpdate(request *dto.MarkMailRequest) *domain.EmailFlagsUpdate {
+	return &domain.EmailFlagsUpdate{
+		IsRead:    request.IsRead,
+		IsFlagged: request.IsFlagged,
+	}
+}
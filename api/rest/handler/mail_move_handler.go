@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedMoveMail 统一处理移动邮件的请求，支持 Graph API 和 IMAP 协议
+func HandleUnifiedMoveMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从路径中获取 emailID
+		emailID := c.Param("emailID")
+		if emailID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "邮件 ID 不能为空"})
+			return
+		}
+
+		// 解析请求
+		request, err := parseMoveMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		// 验证目标文件夹
+		if request.TargetFolder == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "目标文件夹不能为空"})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Str("emailID", emailID).
+			Str("folder", request.Folder).
+			Str("targetFolder", request.TargetFolder).
+			Msg("收到移动邮件请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphMoveMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeIMAP:
+			handleImapMoveMail(c, request, tokenProvider, emailID)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphMoveMail 处理 Graph API 协议的邮件移动
+func handleGraphMoveMail(c *gin.Context, request *dto.MoveMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 移动邮件
+	newEmailID, err := graph.MoveEmail(context.Background(), accessToken, emailID, request.TargetFolder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 Graph API 移动邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 Graph API 移动邮件")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件移动成功", "emailId": newEmailID})
+}
+
+// handleImapMoveMail 处理 IMAP 协议的邮件移动
+func handleImapMoveMail(c *gin.Context, request *dto.MoveMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 移动邮件
+	newEmailID, err := imapClient.MoveEmail(emailID, request.Folder, request.TargetFolder)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 IMAP 移动邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 IMAP 移动邮件")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件移动成功", "emailId": newEmailID})
+}
+
+// parseMoveMailRequest 解析移动邮件请求
+func parseMoveMailRequest(c *gin.Context) (*dto.MoveMailRequest, error) {
+	var request dto.MoveMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析移动邮件请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedReplyMail 统一处理回复、回复全部和转发邮件的请求，支持 Graph API 和 IMAP 协议（IMAP 账户通过 SMTP 发送）
+func HandleUnifiedReplyMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从路径中获取 emailID
+		emailID := c.Param("emailID")
+		if emailID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "邮件 ID 不能为空"})
+			return
+		}
+
+		// 解析请求
+		request, err := parseReplyMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		// 验证回复内容
+		if request.Reply == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "回复内容不能为空"})
+			return
+		}
+		if err := request.Reply.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Str("emailID", emailID).
+			Str("folder", request.Folder).
+			Str("mode", string(request.Reply.Mode)).
+			Msg("收到回复邮件请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphReplyMail(c, request, tokenProvider, emailID)
+		case types.ProtocolTypeIMAP:
+			handleSmtpReplyMail(c, request, tokenProvider, emailID)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphReplyMail 处理 Graph API 协议的邮件回复
+func handleGraphReplyMail(c *gin.Context, request *dto.ReplyMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 回复邮件
+	if err := graph.ReplyEmail(context.Background(), accessToken, emailID, request.Reply); err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 Graph API 回复邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("成功通过 Graph API 回复邮件")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件发送成功"})
+}
+
+// handleSmtpReplyMail 处理 IMAP 类型账户的邮件回复（通过 IMAP 获取原邮件，通过 SMTP 发送）
+func handleSmtpReplyMail(c *gin.Context, request *dto.ReplyMailRequest, tokenProvider *token.TokenProvider, emailID string) {
+	// 获取访问令牌（IMAP 和 SMTP 使用相同的访问令牌）
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 回复邮件
+	messageID, err := common.ReplyEmailViaSmtp(request.MailInfo, accessToken, emailID, request.Folder, request.Reply)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Str("emailID", emailID).Msg("通过 SMTP 回复邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("emailID", emailID).Str("messageID", messageID).Msg("成功通过 SMTP 回复邮件")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件发送成功", "messageId": messageID})
+}
+
+// parseReplyMailRequest 解析回复邮件请求
+func parseReplyMailRequest(c *gin.Context) (*dto.ReplyMailRequest, error) {
+	var request dto.ReplyMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析回复邮件请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/domain"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedSearchMail 统一处理按条件搜索邮件的请求，支持 Graph API、IMAP 和 JMAP 协议
+func HandleUnifiedSearchMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 解析请求
+		request, err := parseSearchMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		// 未指定搜索条件时等同于获取邮件列表
+		if request.Criteria == nil {
+			request.Criteria = &domain.SearchCriteria{}
+		}
+
+		request.Limit = common.NormalizeListLimit(request.Limit)
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Int("limit", request.Limit).
+			Str("folder", request.Criteria.Folder).
+			Str("cursor", request.Cursor).
+			Msg("收到搜索邮件请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphSearchMail(c, request, tokenProvider)
+		case types.ProtocolTypeIMAP:
+			handleImapSearchMail(c, request, tokenProvider)
+		case types.ProtocolTypeJMAP:
+			handleJmapSearchMail(c, request, tokenProvider)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphSearchMail 处理 Graph API 协议的邮件搜索
+func handleGraphSearchMail(c *gin.Context, request *dto.SearchMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 搜索邮件
+	page, err := graph.SearchEmails(context.Background(), accessToken, request.Criteria, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Graph API 搜索邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 Graph API 搜索邮件")
+	c.JSON(http.StatusOK, page)
+}
+
+// handleImapSearchMail 处理 IMAP 协议的邮件搜索
+func handleImapSearchMail(c *gin.Context, request *dto.SearchMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 IMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 IMAP 客户端
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
+	defer imapClient.Disconnect()
+
+	// 搜索邮件
+	page, err := imapClient.SearchEmails(request.Criteria, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 IMAP 搜索邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 IMAP 搜索邮件")
+	c.JSON(http.StatusOK, page)
+}
+
+// handleJmapSearchMail 处理 JMAP 协议的邮件搜索
+func handleJmapSearchMail(c *gin.Context, request *dto.SearchMailRequest, tokenProvider *token.TokenProvider) {
+	// JMAP 使用密码或 API 令牌认证，这里只校验账户配置
+	if _, err := tokenProvider.GetAccessToken(request.MailInfo); err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 JMAP 访问令牌失败")
+		c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 JMAP 客户端
+	jmapClient := common.NewJmapClient(request.MailInfo)
+
+	// 搜索邮件
+	page, err := jmapClient.SearchEmails(context.Background(), request.Criteria, request.Limit, request.Cursor)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 JMAP 搜索邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Int("count", len(page.Emails)).Msg("成功通过 JMAP 搜索邮件")
+	c.JSON(http.StatusOK, page)
+}
+
+// parseSearchMailRequest 解析搜索邮件请求
+func parseSearchMailRequest(c *gin.Context) (*dto.SearchMailRequest, error) {
+	var request dto.SearchMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析搜索邮件请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"gomailapi2/api/common"
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/graph"
+	"gomailapi2/internal/provider/token"
+	"gomailapi2/internal/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleUnifiedSendMail 统一处理发送邮件的请求，IMAP 账户通过 SMTP 发送，Graph 账户通过 Graph API 发送
+func HandleUnifiedSendMail(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 解析请求
+		request, err := parseSendMailRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 验证 MailInfo
+		if request.MailInfo == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MailInfo 不能为空"})
+			return
+		}
+
+		// 验证邮件内容
+		if request.Message == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "邮件内容不能为空"})
+			return
+		}
+		if err := request.Message.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Str("protocol", string(request.MailInfo.ProtocolType)).
+			Str("provider", string(request.MailInfo.ServiceProvider)).
+			Int("recipients", len(request.Message.To)+len(request.Message.Cc)+len(request.Message.Bcc)).
+			Int("attachments", len(request.Message.Attachments)).
+			Str("inReplyTo", request.Message.InReplyTo).
+			Msg("收到发送邮件请求")
+
+		// 根据协议类型处理请求
+		switch request.MailInfo.ProtocolType {
+		case types.ProtocolTypeGraph:
+			handleGraphSendMail(c, request, tokenProvider)
+		case types.ProtocolTypeIMAP:
+			handleSmtpSendMail(c, request, tokenProvider)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "不支持的协议类型: " + string(request.MailInfo.ProtocolType),
+			})
+		}
+	}
+}
+
+// handleGraphSendMail 处理 Graph API 协议的邮件发送
+func handleGraphSendMail(c *gin.Context, request *dto.SendMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 Graph API 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 发送邮件
+	if err := graph.SendEmail(context.Background(), accessToken, request.Message); err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 Graph API 发送邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Msg("成功通过 Graph API 发送邮件")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件发送成功"})
+}
+
+// handleSmtpSendMail 处理 IMAP 类型账户的邮件发送（通过 SMTP XOAUTH2 发送）
+func handleSmtpSendMail(c *gin.Context, request *dto.SendMailRequest, tokenProvider *token.TokenProvider) {
+	// 获取访问令牌（与 IMAP 使用相同的访问令牌）
+	accessToken, err := tokenProvider.GetAccessToken(request.MailInfo)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("获取 SMTP 访问令牌失败")
+		c.JSON(http.StatusUnauthorized, tokenErrorResponse(err))
+		return
+	}
+
+	// 创建 SMTP 客户端
+	smtpClient := common.NewSmtpClient(request.MailInfo, accessToken)
+
+	// 发送邮件
+	messageID, err := smtpClient.SendMail(request.Message)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("通过 SMTP 发送邮件失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Info().Str("email", request.MailInfo.Email).Str("messageID", messageID).Msg("成功通过 SMTP 发送邮件")
+	c.JSON(http.StatusOK, gin.H{"message": "邮件发送成功", "messageId": messageID})
+}
+
+// parseSendMailRequest 解析发送邮件请求
+func parseSendMailRequest(c *gin.Context) (*dto.SendMailRequest, error) {
+	var request dto.SendMailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error().Err(err).Msg("解析发送邮件请求失败")
+		return nil, err
+	}
+	return &request, nil
+}
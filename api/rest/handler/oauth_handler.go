@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/service"
+	"gomailapi2/internal/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleOAuthStart 开始 OAuth 授权码（PKCE）流程，返回在浏览器中打开的授权地址
+func HandleOAuthStart(oauthService *service.OAuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request dto.OAuthStartRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Error().Err(err).Msg("解析 OAuth 授权请求失败")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		response, err := oauthService.StartAuthorization(&request)
+		if err != nil {
+			log.Error().Err(err).Str("provider", string(request.ServiceProvider)).Msg("创建 OAuth 授权请求失败")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// HandleOAuthCallback 处理服务提供商的授权回调，使用授权码换取 refreshToken 并返回可直接使用的邮箱信息
+func HandleOAuthCallback(oauthService *service.OAuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 用户拒绝授权或授权失败时，服务提供商通过 error 参数返回错误
+		if oauthError := c.Query("error"); oauthError != "" {
+			log.Error().
+				Str("error", oauthError).
+				Str("description", c.Query("error_description")).
+				Msg("OAuth 授权失败")
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":       "授权失败: " + oauthError,
+				"description": c.Query("error_description"),
+			})
+			return
+		}
+
+		mailInfo, err := oauthService.CompleteAuthorization(c.Query("state"), c.Query("code"))
+		if err != nil {
+			log.Error().Err(err).Msg("完成 OAuth 授权失败")
+			c.JSON(http.StatusBadRequest, tokenErrorResponse(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.OAuthCallbackResponse{MailInfo: mailInfo})
+	}
+}
+
+// HandleDeviceAuthorization 设备码授权 SSE 处理器：先推送用户代码和验证页面地址，用户完成登录后推送邮箱信息
+// 事件依次为 device_code、heartbeat（等待期间）、complete 或 error
+func HandleDeviceAuthorization(oauthService *service.OAuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 设置 SSE headers
+		setupSSEHeaders(c)
+
+		var request dto.DeviceAuthorizationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Error().Err(err).Msg("解析设备码授权请求失败")
+			sendSSEError(c, err.Error())
+			return
+		}
+
+		mailInfo, device, err := oauthService.StartDeviceAuthorization(&request)
+		if err != nil {
+			log.Error().Err(err).Str("clientId", request.ClientID).Msg("发起设备码授权失败")
+			sendSSEError(c, err.Error())
+			return
+		}
+
+		sendSSEEvent(c, "device_code", dto.DeviceCodeResponse{
+			UserCode:        device.UserCode,
+			VerificationURI: device.VerificationURI,
+			ExpiresIn:       device.ExpiresIn,
+			Message:         device.Message,
+		})
+
+		// 在后台轮询 token endpoint，客户端断开时停止轮询
+		type result struct {
+			mailInfo *types.MailInfo
+			err      error
+		}
+		resultChan := make(chan result, 1)
+		go func() {
+			mailInfo, err := oauthService.WaitDeviceAuthorization(c.Request.Context(), mailInfo, device)
+			resultChan <- result{mailInfo: mailInfo, err: err}
+		}()
+
+		heartbeat := createHeartbeatTicker()
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case res := <-resultChan:
+				if res.err != nil {
+					log.Error().Err(res.err).Str("clientId", request.ClientID).Msg("设备码授权失败")
+					sendSSEError(c, res.err.Error())
+					return
+				}
+				sendSSEEvent(c, "complete", dto.OAuthCallbackResponse{MailInfo: res.mailInfo})
+				return
+
+			case <-c.Request.Context().Done():
+				log.Info().Str("clientId", request.ClientID).Msg("SSE 客户端连接断开 (设备码授权)")
+				return
+
+			case <-heartbeat.C:
+				// 发送心跳包保持连接活跃
+				sendSSEEvent(c, "heartbeat", gin.H{
+					"timestamp": time.Now().Unix(),
+				})
+			}
+		}
+	}
+}
@@ -6,8 +6,9 @@ import (
 	"fmt"
 	"gomailapi2/api/common"
 	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/client/gmailapi"
 	"gomailapi2/internal/client/graph"
-	"gomailapi2/internal/client/imap/outlook"
+	"gomailapi2/internal/domain"
 	"gomailapi2/internal/manager"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/types"
@@ -17,7 +18,7 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// HandleUnifiedSubscribeSSE 统一的邮件订阅 SSE 处理器，支持 IMAP 和 Graph 协议
+// HandleUnifiedSubscribeSSE 统一的邮件订阅 SSE 处理器，支持 IMAP、Graph、Gmail API、JMAP 和 EWS 协议
 func HandleUnifiedSubscribeSSE(
 	tokenProvider *token.TokenProvider,
 	nfManager *manager.NotificationManager,
@@ -53,6 +54,12 @@ func HandleUnifiedSubscribeSSE(
 			handleImapSubscription(c, request, accessToken, refreshToken, imapManager)
 		case types.ProtocolTypeGraph:
 			handleGraphSubscription(c, request, accessToken, refreshToken, nfManager)
+		case types.ProtocolTypeGmailAPI:
+			handleGmailSubscription(c, request, accessToken, refreshToken, nfManager)
+		case types.ProtocolTypeJMAP:
+			handleJmapSubscription(c, request, refreshToken)
+		case types.ProtocolTypeEWS:
+			handleEwsSubscription(c, request, accessToken, refreshToken)
 		default:
 			log.Error().
 				Str("protocol", string(request.MailInfo.ProtocolType)).
@@ -70,7 +77,7 @@ func handleImapSubscription(
 	imapManager *manager.ImapSubscriptionManager,
 ) {
 	// 创建 IMAP 客户端
-	imapClient := outlook.NewOutlookImapClient(common.MailInfoToCredentials(request.MailInfo), accessToken)
+	imapClient := common.NewImapClient(request.MailInfo, accessToken)
 
 	// 创建新订阅
 	subscription, err := imapManager.CreateSubscription(imapClient, request.MailInfo.Email)
@@ -154,6 +161,139 @@ func handleGraphSubscription(
 	listenForGraphNotifications(c, notifyChan, response.ID, request.MailInfo.Email, accessToken)
 }
 
+// handleGmailSubscription 处理 Gmail API 协议订阅
+func handleGmailSubscription(
+	c *gin.Context,
+	request *dto.SubscribeMailRequest,
+	accessToken, refreshToken string,
+	nfManager *manager.NotificationManager,
+) {
+	// Gmail 推送通知只携带邮箱地址，同一邮箱同时只能有一个订阅
+	key := common.GmailNotificationKey(request.MailInfo.Email)
+	if nfManager.HasChannel(key) {
+		sendSSEError(c, "该邮箱已有进行中的 Gmail 订阅")
+		return
+	}
+
+	// 先注册邮件通知通道，避免遗漏开始监听后立即到达的通知
+	notifyChan := nfManager.RegisterChannel(key)
+
+	// 清理函数
+	defer func() {
+		nfManager.RemoveChannel(key)
+		log.Info().
+			Str("email", request.MailInfo.Email).
+			Msg("清理 Gmail 订阅通知通道")
+	}()
+
+	// 开始监听收件箱
+	response, err := gmailapi.Watch(context.Background(), accessToken, common.GmailPushTopic)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("创建 Gmail 订阅失败")
+		sendSSEError(c, "创建订阅失败: "+err.Error())
+		return
+	}
+
+	// 结束后，自动停止监听
+	defer func() {
+		if err := gmailapi.StopWatch(context.Background(), accessToken); err != nil {
+			log.Warn().Err(err).Str("email", request.MailInfo.Email).Msg("停止 Gmail 监听失败")
+		}
+	}()
+
+	log.Info().
+		Str("email", request.MailInfo.Email).
+		Str("historyID", response.HistoryID).
+		Msg("成功创建 Gmail 订阅")
+
+	// 发送订阅成功消息
+	sendSubscriptionSuccess(c, request.RefreshNeeded, refreshToken)
+
+	// 开始监听 Gmail 通知
+	listenForGmailNotifications(c, notifyChan, response.HistoryID, request.MailInfo.Email, accessToken)
+}
+
+// handleJmapSubscription 处理 JMAP 协议订阅（通过 JMAP 推送的 EventSource 监听收件箱）
+func handleJmapSubscription(
+	c *gin.Context,
+	request *dto.SubscribeMailRequest,
+	refreshToken string,
+) {
+	// 请求结束（收到邮件、超时或客户端断开）时关闭推送连接
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	emailChan := make(chan *domain.Email, 1)
+	errChan := make(chan error, 1)
+
+	// 先建立推送连接，连接成功后才发送订阅成功消息
+	subscription, err := common.NewJmapClient(request.MailInfo).Subscribe(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("创建 JMAP 订阅失败")
+		sendSSEError(c, "创建订阅失败: "+err.Error())
+		return
+	}
+	defer subscription.Close()
+
+	go func() {
+		errChan <- subscription.Listen(ctx, emailChan)
+	}()
+
+	log.Info().
+		Str("email", request.MailInfo.Email).
+		Msg("成功创建 JMAP 订阅")
+
+	// 发送订阅成功消息
+	sendSubscriptionSuccess(c, request.RefreshNeeded, refreshToken)
+
+	// 开始监听 JMAP 邮件
+	listenForJmapEmails(c, emailChan, errChan, request.MailInfo.Email)
+}
+
+// handleEwsSubscription 处理 EWS 协议订阅（通过拉取订阅监听收件箱）
+func handleEwsSubscription(
+	c *gin.Context,
+	request *dto.SubscribeMailRequest,
+	accessToken, refreshToken string,
+) {
+	// 请求结束（收到邮件、超时或客户端断开）时取消订阅
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	emailChan := make(chan *domain.Email, 1)
+	errChan := make(chan error, 1)
+
+	// 先创建订阅，创建成功后才发送订阅成功消息
+	subscription, err := common.NewEwsClient(request.MailInfo, accessToken).Subscribe(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("创建 EWS 订阅失败")
+		sendSSEError(c, "创建订阅失败: "+err.Error())
+		return
+	}
+
+	// 结束后，先停止拉取再取消订阅
+	defer func() {
+		cancel()
+		if err := subscription.Close(); err != nil {
+			log.Warn().Err(err).Str("email", request.MailInfo.Email).Msg("取消 EWS 订阅失败")
+		}
+	}()
+
+	go func() {
+		errChan <- subscription.Listen(ctx, emailChan)
+	}()
+
+	log.Info().
+		Str("email", request.MailInfo.Email).
+		Msg("成功创建 EWS 订阅")
+
+	// 发送订阅成功消息
+	sendSubscriptionSuccess(c, request.RefreshNeeded, refreshToken)
+
+	// 开始监听 EWS 邮件
+	listenForEwsEmails(c, emailChan, errChan, request.MailInfo.Email)
+}
+
 // listenForImapEmails 监听 IMAP 邮件
 func listenForImapEmails(
 	c *gin.Context,
@@ -218,6 +358,142 @@ func listenForImapEmails(
 	}
 }
 
+// listenForJmapEmails 监听 JMAP 推送的新邮件
+func listenForJmapEmails(
+	c *gin.Context,
+	emailChan <-chan *domain.Email,
+	errChan <-chan error,
+	email string,
+) {
+	// 设置超时和心跳
+	timeout := createSSETimeout()
+	defer timeout.Stop()
+
+	heartbeat := createHeartbeatTicker()
+	defer heartbeat.Stop()
+
+	log.Info().
+		Str("email", email).
+		Msg("开始 SSE 等待新邮件 (JMAP)")
+
+	for {
+		select {
+		case emailData := <-emailChan:
+			log.Info().
+				Str("email", email).
+				Msg("通过 SSE 收到新邮件 (JMAP)")
+
+			// 发送邮件数据
+			sendSSEEvent(c, "email", emailData)
+
+			// 发送完成消息
+			sendSSEEvent(c, "complete", gin.H{
+				"message": "邮件推送完成 (JMAP)",
+			})
+			return
+
+		case err := <-errChan:
+			if err == nil {
+				return
+			}
+			log.Error().Err(err).Str("email", email).Msg("JMAP 推送监听失败")
+			sendSSEError(c, err.Error())
+			return
+
+		case <-timeout.C:
+			log.Info().
+				Str("email", email).
+				Msg("SSE 等待邮件超时 (JMAP)")
+
+			sendSSEEvent(c, "timeout", gin.H{
+				"message": "等待邮件超时，订阅已过期 (JMAP)",
+			})
+			return
+
+		case <-c.Request.Context().Done():
+			log.Info().
+				Str("email", email).
+				Msg("SSE 客户端连接断开 (JMAP)")
+			return
+
+		case <-heartbeat.C:
+			// 发送心跳包保持连接活跃
+			sendSSEEvent(c, "heartbeat", gin.H{
+				"timestamp": time.Now().Unix(),
+				"protocol":  "jmap",
+			})
+		}
+	}
+}
+
+// listenForEwsEmails 监听 EWS 拉取订阅的新邮件
+func listenForEwsEmails(
+	c *gin.Context,
+	emailChan <-chan *domain.Email,
+	errChan <-chan error,
+	email string,
+) {
+	// 设置超时和心跳
+	timeout := createSSETimeout()
+	defer timeout.Stop()
+
+	heartbeat := createHeartbeatTicker()
+	defer heartbeat.Stop()
+
+	log.Info().
+		Str("email", email).
+		Msg("开始 SSE 等待新邮件 (EWS)")
+
+	for {
+		select {
+		case emailData := <-emailChan:
+			log.Info().
+				Str("email", email).
+				Msg("通过 SSE 收到新邮件 (EWS)")
+
+			// 发送邮件数据
+			sendSSEEvent(c, "email", emailData)
+
+			// 发送完成消息
+			sendSSEEvent(c, "complete", gin.H{
+				"message": "邮件推送完成 (EWS)",
+			})
+			return
+
+		case err := <-errChan:
+			if err == nil {
+				return
+			}
+			log.Error().Err(err).Str("email", email).Msg("EWS 订阅监听失败")
+			sendSSEError(c, err.Error())
+			return
+
+		case <-timeout.C:
+			log.Info().
+				Str("email", email).
+				Msg("SSE 等待邮件超时 (EWS)")
+
+			sendSSEEvent(c, "timeout", gin.H{
+				"message": "等待邮件超时，订阅已过期 (EWS)",
+			})
+			return
+
+		case <-c.Request.Context().Done():
+			log.Info().
+				Str("email", email).
+				Msg("SSE 客户端连接断开 (EWS)")
+			return
+
+		case <-heartbeat.C:
+			// 发送心跳包保持连接活跃
+			sendSSEEvent(c, "heartbeat", gin.H{
+				"timestamp": time.Now().Unix(),
+				"protocol":  "ews",
+			})
+		}
+	}
+}
+
 // listenForGraphNotifications 监听 Graph 通知
 func listenForGraphNotifications(
 	c *gin.Context,
@@ -286,6 +562,77 @@ func listenForGraphNotifications(
 	}
 }
 
+// listenForGmailNotifications 监听 Gmail 推送通知，收到通知后查询 startHistoryID 之后新增的邮件
+func listenForGmailNotifications(
+	c *gin.Context,
+	notifyChan chan string,
+	startHistoryID, email, accessToken string,
+) {
+	// 设置超时和心跳
+	timeout := createSSETimeout()
+	defer timeout.Stop()
+
+	heartbeat := createHeartbeatTicker()
+	defer heartbeat.Stop()
+
+	log.Info().
+		Str("email", email).
+		Str("historyID", startHistoryID).
+		Msg("开始 SSE 等待新邮件通知 (Gmail)")
+
+	for {
+		select {
+		case historyID := <-notifyChan:
+			log.Info().
+				Str("email", email).
+				Str("historyID", historyID).
+				Msg("通过 SSE 收到新邮件通知 (Gmail)")
+
+			emailData, err := gmailapi.GetLatestAddedEmail(context.Background(), accessToken, startHistoryID)
+			if err != nil {
+				log.Error().Err(err).Msg("获取邮件详情失败 (Gmail)")
+				continue
+			}
+			if emailData == nil {
+				// 已读、标签变更等通知，没有新邮件，继续等待
+				continue
+			}
+
+			// 发送邮件数据
+			sendSSEEvent(c, "email", emailData)
+
+			// 发送完成消息
+			sendSSEEvent(c, "complete", gin.H{
+				"message": "邮件推送完成 (Gmail)",
+			})
+			return
+
+		case <-timeout.C:
+			log.Info().
+				Str("email", email).
+				Msg("SSE 等待邮件超时 (Gmail)")
+
+			sendSSEEvent(c, "timeout", gin.H{
+				"message": "等待邮件超时，订阅已过期 (Gmail)",
+			})
+			return
+
+		case <-c.Request.Context().Done():
+			log.Info().
+				Str("email", email).
+				Msg("SSE 客户端连接断开 (Gmail)")
+			return
+
+		case <-heartbeat.C:
+			// 发送心跳包保持连接活跃
+			sendSSEEvent(c, "heartbeat", gin.H{
+				"timestamp": time.Now().Unix(),
+				"protocol":  "gmail",
+			})
+		}
+	}
+}
+
 // sendSSEEvent 发送 SSE 事件（data 为 json 格式）
 func sendSSEEvent(c *gin.Context, event string, data any) {
 	jsonData, _ := json.Marshal(data)
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/origin/auth"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/types"
 	"net/http"
@@ -36,7 +37,7 @@ func HandleRefreshToken(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 		newRefreshToken, err := tokenProvider.GetRefreshToken(request.MailInfo)
 		if err != nil {
 			log.Error().Err(err).Str("email", request.MailInfo.Email).Msg("刷新 Token 失败")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(http.StatusInternalServerError, tokenErrorResponse(err))
 			return
 		}
 
@@ -47,6 +48,13 @@ func HandleRefreshToken(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 	}
 }
 
+// HandleRefresherStats 获取 access token 后台刷新的统计数据
+func HandleRefresherStats(tokenProvider *token.TokenProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, tokenProvider.RefresherStats())
+	}
+}
+
 // HandleBatchRefreshToken 处理批量 Token 刷新请求（并发处理，限制每次最多 100 个）
 func HandleBatchRefreshToken(tokenProvider *token.TokenProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -100,6 +108,7 @@ func HandleBatchRefreshToken(tokenProvider *token.TokenProvider) gin.HandlerFunc
 					log.Error().Err(err).Str("email", mailInfo.Email).Msg("批量刷新 Token 失败")
 					errorMsg := err.Error()
 					result.Error = errorMsg
+					result.Code = string(auth.GetErrorCode(err))
 
 					// 线程安全地更新失败计数
 					mu.Lock()
@@ -160,3 +169,12 @@ func parseBatchRefreshTokenRequest(c *gin.Context) (*dto.BatchRefreshTokenReques
 	}
 	return &request, nil
 }
+
+// tokenErrorResponse 构建获取令牌失败的响应，令牌错误附带错误码（如 MFA_REQUIRED），便于调用方按错误类型处理
+func tokenErrorResponse(err error) gin.H {
+	response := gin.H{"error": err.Error()}
+	if code := auth.GetErrorCode(err); code != "" {
+		response["code"] = code
+	}
+	return response
+}
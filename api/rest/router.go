@@ -15,6 +15,7 @@ import (
 func SetupRouter(
 	tokenProvider *token.TokenProvider,
 	protocolService *service.ProtocolService,
+	oauthService *service.OAuthService,
 	nfManager *manager.NotificationManager,
 	imapManager *manager.ImapSubscriptionManager,
 ) *gin.Engine {
@@ -32,30 +33,80 @@ func SetupRouter(
 	// API 路由
 	apiGroup := router.Group("gomailapi2")
 
+	// 请求中的邮箱信息只包含 accountId 时，使用已保存的账户信息（只用于接收 mailInfo 或 mailInfos 的端点）
+	resolveAccounts := handler.ResolveAccounts(tokenProvider)
+
 	// 统一邮件端点 - 推荐使用
+	mailGroup := apiGroup.Group("", resolveAccounts)
 	{
-		// 统一获取最新邮件端点（支持 IMAP 和 Graph 协议）
-		apiGroup.POST("/mail/latest", handler.HandleUnifiedLatestMail(tokenProvider))
-		// 统一查找邮件端点（支持 IMAP 和 Graph 协议）
-		apiGroup.POST("/mail/find/:emailID", handler.HandleUnifiedFindMail(tokenProvider))
-		// 统一获取垃圾邮件端点（支持 IMAP 和 Graph 协议）
-		apiGroup.POST("/mail/junk/latest", handler.HandleUnifiedJunkMail(tokenProvider))
-		// 统一邮件订阅路由（支持 IMAP 和 Graph 协议）
-		apiGroup.POST("/subscribe-sse", handler.HandleUnifiedSubscribeSSE(tokenProvider, nfManager, imapManager))
+		// 统一获取最新邮件端点（支持 IMAP、Graph 和 Gmail API 协议）
+		mailGroup.POST("/mail/latest", handler.HandleUnifiedLatestMail(tokenProvider))
+		// 统一查找邮件端点（支持 IMAP、Graph 和 Gmail API 协议）
+		mailGroup.POST("/mail/find/:emailID", handler.HandleUnifiedFindMail(tokenProvider))
+		// 统一导出邮件原始内容（.eml）端点（支持 IMAP、Graph 和 Gmail API 协议）
+		mailGroup.POST("/mail/export/:emailID", handler.HandleUnifiedExportMail(tokenProvider))
+		// 统一下载附件端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/attachment/:emailID/:attachmentID", handler.HandleUnifiedDownloadAttachment(tokenProvider))
+		// 统一分页获取邮件列表端点（支持 IMAP、Graph 和 Gmail API 协议）
+		mailGroup.POST("/mail/list", handler.HandleUnifiedListMail(tokenProvider))
+		// 统一按条件搜索邮件端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/search", handler.HandleUnifiedSearchMail(tokenProvider))
+		// 统一修改邮件状态（已读/星标）端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/mark/:emailID", handler.HandleUnifiedMarkMail(tokenProvider))
+		// 统一移动邮件端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/move/:emailID", handler.HandleUnifiedMoveMail(tokenProvider))
+		// 统一删除邮件端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/delete/:emailID", handler.HandleUnifiedDeleteMail(tokenProvider))
+		// 统一发送邮件端点（IMAP 账户通过 SMTP 发送，Graph 账户通过 sendMail 发送）
+		mailGroup.POST("/mail/send", handler.HandleUnifiedSendMail(tokenProvider))
+		// 统一回复/回复全部/转发邮件端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/reply/:emailID", handler.HandleUnifiedReplyMail(tokenProvider))
+		// 统一获取文件夹列表端点（支持 IMAP 和 Graph 协议）
+		mailGroup.POST("/mail/folders", handler.HandleUnifiedListFolders(tokenProvider))
+		// 统一获取垃圾邮件端点（支持 IMAP、Graph 和 Gmail API 协议）
+		mailGroup.POST("/mail/junk/latest", handler.HandleUnifiedJunkMail(tokenProvider))
+		// 统一邮件订阅路由（支持 IMAP、Graph 和 Gmail API 协议）
+		mailGroup.POST("/subscribe-sse", handler.HandleUnifiedSubscribeSSE(tokenProvider, nfManager, imapManager))
 		// 检测协议类型
-		apiGroup.POST("/detect-protocol", handler.HandleDetectProtocolType(protocolService))
+		mailGroup.POST("/detect-protocol", handler.HandleDetectProtocolType(protocolService))
 		// 批量检测协议类型
-		apiGroup.POST("/batch/detect-protocol", handler.HandleBatchDetectProtocolType(protocolService))
+		mailGroup.POST("/batch/detect-protocol", handler.HandleBatchDetectProtocolType(protocolService))
+	}
+
+	// 已保存账户相关端点（需要启用 refresh token 存储）
+	accountGroup := apiGroup.Group("/accounts")
+	{
+		// 保存账户
+		accountGroup.POST("", handler.HandleRegisterAccount(tokenProvider))
+		// 获取账户基本信息
+		accountGroup.GET("/:accountID", handler.HandleGetAccount(tokenProvider))
+		// 获取账户的 refreshToken 变更记录
+		accountGroup.GET("/:accountID/history", handler.HandleAccountHistory(tokenProvider))
+		// 删除账户
+		accountGroup.DELETE("/:accountID", handler.HandleDeleteAccount(tokenProvider))
 	}
 
 	// Token 相关端点
 	tokenGroup := apiGroup.Group("/token")
 	{
 		// 刷新单个 Token
-		tokenGroup.POST("/refresh", handler.HandleRefreshToken(tokenProvider))
+		tokenGroup.POST("/refresh", resolveAccounts, handler.HandleRefreshToken(tokenProvider))
 		// 批量刷新 Token
-		tokenGroup.POST("/batch/refresh", handler.HandleBatchRefreshToken(tokenProvider))
+		tokenGroup.POST("/batch/refresh", resolveAccounts, handler.HandleBatchRefreshToken(tokenProvider))
+		// access token 后台刷新统计
+		tokenGroup.GET("/refresher/stats", handler.HandleRefresherStats(tokenProvider))
+
+	}
 
+	// OAuth 授权相关端点（获取新邮箱的 refreshToken）
+	oauthGroup := apiGroup.Group("/oauth")
+	{
+		// 开始授权码（PKCE）流程，返回授权地址
+		oauthGroup.POST("/start", handler.HandleOAuthStart(oauthService))
+		// 授权回调，返回可直接使用的邮箱信息
+		oauthGroup.GET("/callback", handler.HandleOAuthCallback(oauthService))
+		// 设备码授权（SSE），推送用户代码并在用户完成登录后返回邮箱信息
+		oauthGroup.POST("/device", handler.HandleDeviceAuthorization(oauthService))
 	}
 
 	// Graph API 相关路由
@@ -65,5 +116,12 @@ func SetupRouter(
 		graphGroup.POST("/webhook", handler.HandleGraphWebhook(nfManager))
 	}
 
+	// Gmail API 相关路由
+	gmailGroup := apiGroup.Group("/gmail")
+	{
+		// Gmail Pub/Sub 推送路由
+		gmailGroup.POST("/webhook", handler.HandleGmailWebhook(nfManager))
+	}
+
 	return router
 }
@@ -11,6 +11,7 @@ import (
 	"gomailapi2/internal/manager"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/service"
+	"gomailapi2/internal/store/tokenstore"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
@@ -23,6 +24,9 @@ func main() {
 
 	// 初始化全局配置
 	common.InitGraphNotificationURL(&cfg.Webhook)
+	common.InitGmailPushTopic(&cfg.Webhook)
+	common.InitOAuthRedirectURL(&cfg.Webhook)
+	common.InitMailConfig(&cfg.Mail)
 
 	// 初始化日志
 	initLogger(cfg.Log.Level)
@@ -49,12 +53,40 @@ func main() {
 	tokenProvider := token.NewTokenProvider(cacheInstance)
 	log.Info().Msg("TokenProvider 初始化完成")
 
+	// 启动 access token 后台刷新
+	refresher, err := tokenProvider.EnableRefresher(cfg.Cache.Refresh)
+	if err != nil {
+		log.Fatal().Err(err).Msg("启动 access token 后台刷新失败")
+	}
+	defer refresher.Stop()
+
+	// 初始化 refresh token 存储
+	if cfg.TokenStore.Enabled {
+		tokenStore, err := tokenstore.NewStore(cfg.TokenStore, cfg.Cache.Redis)
+		if err != nil {
+			log.Fatal().Err(err).Msg("初始化 refresh token 存储失败")
+		}
+		defer tokenStore.Close()
+		tokenProvider.SetTokenStore(tokenStore, cfg.TokenStore.AutoRegister)
+		log.Info().Str("type", cfg.TokenStore.Type).Msg("refresh token 存储初始化完成")
+	}
+
 	// 初始化 ProtocolService
-	protocolService := service.NewProtocolService(cacheInstance)
+	protocolService := service.NewProtocolService(tokenProvider)
 	log.Info().Msg("协议检测服务初始化完成")
 
+	// 初始化 OAuth 授权服务
+	oauthService := service.NewOAuthService(protocolService, common.OAuthRedirectURL)
+	if cacheType := factory.CacheType(cfg.Cache.Type); cacheType == factory.CacheTypeRedis || cacheType == factory.CacheTypeMultiLevel {
+		if err := oauthService.EnableRedisPendingStore(cfg.Cache.Redis); err != nil {
+			log.Fatal().Err(err).Msg("初始化 OAuth 授权请求存储失败")
+		}
+		log.Info().Msg("OAuth 授权请求保存到 Redis")
+	}
+	defer oauthService.Close()
+
 	// 初始化路由
-	router := rest.SetupRouter(tokenProvider, protocolService, notificationManager, imapManager)
+	router := rest.SetupRouter(tokenProvider, protocolService, oauthService, notificationManager, imapManager)
 
 	// 启动服务器
 	address := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -17,6 +17,7 @@ import (
 	"gomailapi2/internal/manager"
 	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/service"
+	"gomailapi2/internal/store/tokenstore"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -31,6 +32,9 @@ func main() {
 
 	// 初始化全局配置
 	common.InitGraphNotificationURL(&cfg.Webhook)
+	common.InitGmailPushTopic(&cfg.Webhook)
+	common.InitOAuthRedirectURL(&cfg.Webhook)
+	common.InitMailConfig(&cfg.Mail)
 
 	log.Info().Msg("启动统一邮件服务器 (gRPC + REST)")
 
@@ -45,10 +49,38 @@ func main() {
 	// 初始化 token provider
 	tokenProvider := token.NewTokenProvider(cacheInstance)
 
+	// 启动 access token 后台刷新
+	refresher, err := tokenProvider.EnableRefresher(cfg.Cache.Refresh)
+	if err != nil {
+		log.Fatal().Err(err).Msg("启动 access token 后台刷新失败")
+	}
+	defer refresher.Stop()
+
+	// 初始化 refresh token 存储
+	if cfg.TokenStore.Enabled {
+		tokenStore, err := tokenstore.NewStore(cfg.TokenStore, cfg.Cache.Redis)
+		if err != nil {
+			log.Fatal().Err(err).Msg("初始化 refresh token 存储失败")
+		}
+		defer tokenStore.Close()
+		tokenProvider.SetTokenStore(tokenStore, cfg.TokenStore.AutoRegister)
+		log.Info().Str("type", cfg.TokenStore.Type).Msg("refresh token 存储初始化完成")
+	}
+
 	// 初始化 protocol service
-	protocolService := service.NewProtocolService(cacheInstance)
+	protocolService := service.NewProtocolService(tokenProvider)
 	log.Info().Msg("协议检测服务初始化完成")
 
+	// 初始化 OAuth 授权服务
+	oauthService := service.NewOAuthService(protocolService, common.OAuthRedirectURL)
+	if cacheType := factory.CacheType(cfg.Cache.Type); cacheType == factory.CacheTypeRedis || cacheType == factory.CacheTypeMultiLevel {
+		if err := oauthService.EnableRedisPendingStore(cfg.Cache.Redis); err != nil {
+			log.Fatal().Err(err).Msg("初始化 OAuth 授权请求存储失败")
+		}
+		log.Info().Msg("OAuth 授权请求保存到 Redis")
+	}
+	defer oauthService.Close()
+
 	// 初始化管理器 - 这里是关键，两个服务共享同一个实例
 	nfManager := manager.NewNotificationManager()
 	imapManager := manager.NewImapSubscriptionManager()
@@ -63,7 +95,7 @@ func main() {
 	grpcPort := cfg.Server.GrpcPort
 	log.Info().Int("port", grpcPort).Msg("启动 gRPC 服务器...")
 
-	mailServer := grpc.NewMailServer(tokenProvider, protocolService, nfManager, imapManager)
+	mailServer := grpc.NewMailServer(tokenProvider, protocolService, oauthService, nfManager, imapManager)
 
 	// 启动 gRPC 服务器（在 goroutine 中）
 	go func() {
@@ -75,7 +107,7 @@ func main() {
 	// 启动 REST 服务器（在 goroutine 中）
 	restServer := &http.Server{}
 
-	router := rest.SetupRouter(tokenProvider, protocolService, nfManager, imapManager)
+	router := rest.SetupRouter(tokenProvider, protocolService, oauthService, nfManager, imapManager)
 
 	restAddress := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	restServer.Addr = restAddress
@@ -1,10 +1,14 @@
 package factory
 
 import (
+	"context"
 	"fmt"
 	"gomailapi2/internal/config"
+	"time"
 
 	"gomailapi2/internal/cache/tokencache"
+
+	"github.com/rs/zerolog/log"
 )
 
 // CacheType 缓存类型
@@ -18,6 +22,8 @@ const (
 
 // NewCache 根据配置创建缓存实例
 func NewCache(cacheConfig config.CacheConfig) (tokencache.Cache, error) {
+	tokencache.SetExpiryMargin(cacheConfig.ExpiryMargin)
+
 	switch CacheType(cacheConfig.Type) {
 	case CacheTypeLocal:
 		return newLocalCache(cacheConfig.Local.Size)
@@ -50,9 +56,23 @@ func newLocalCache(size int) (tokencache.Cache, error) {
 	return tokencache.NewLocalCache(size)
 }
 
-// newRedisCache 创建 Redis 缓存实例
+// newRedisCache 创建 Redis 缓存实例，并清理旧格式（不区分 scope）的缓存键
 func newRedisCache(redisConfig config.RedisConfig) (tokencache.Cache, error) {
-	return tokencache.NewRedisClient(redisConfig)
+	redisCache, err := tokencache.NewRedisClient(redisConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// 迁移失败不影响启动，旧格式的键不会再被读取，过期后自动删除
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if deleted, err := redisCache.MigrateLegacyKeys(ctx); err != nil {
+		log.Warn().Err(err).Int("deleted", deleted).Msg("清理旧格式的 Redis 缓存键失败")
+	} else if deleted > 0 {
+		log.Info().Int("deleted", deleted).Msg("已清理旧格式的 Redis 缓存键")
+	}
+
+	return redisCache, nil
 }
 
 // newMultiLevelCache 创建多级缓存实例
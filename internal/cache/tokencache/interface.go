@@ -1,18 +1,86 @@
 package tokencache
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"gomailapi2/internal/types"
+	"gomailapi2/internal/utils"
+)
+
+// TokenKey access token 的缓存键
+// 同一个 refresh token 可以换取不同 scope（受众）的 access token（如 IMAP 和 Graph），因此键中包含 scope
+type TokenKey struct {
+	ServiceProvider types.ServiceProvider
+	ClientID        string
+	Scope           string // 换取 access token 时使用的 scope，为空表示 refresh token 授权时的 scope
+	RefreshToken    string
+}
+
+// NewTokenKey 根据邮箱信息和 scope 创建缓存键
+func NewTokenKey(mailInfo *types.MailInfo, scope string) TokenKey {
+	return TokenKey{
+		ServiceProvider: mailInfo.ServiceProvider,
+		ClientID:        mailInfo.ClientID,
+		Scope:           scope,
+		RefreshToken:    mailInfo.RefreshToken,
+	}
+}
+
+// String 生成缓存键字符串（refresh token 只以短哈希形式出现）
+func (k TokenKey) String() string {
+	return utils.GenerateCacheKey(string(k.ServiceProvider), k.ClientID, k.Scope, k.RefreshToken)
+}
+
+// 缓存时间
+const (
+	// DefaultExpiryMargin 默认的过期安全余量：缓存时间比 access token 的实际有效期短，避免使用即将过期的令牌
+	DefaultExpiryMargin = 10 * time.Minute
+	// defaultTokenLifetime token endpoint 没有返回 expires_in 时假定的有效期
+	defaultTokenLifetime = time.Hour
+)
+
+// expiryMargin 过期安全余量
+var expiryMargin = DefaultExpiryMargin
+
+// SetExpiryMargin 设置过期安全余量，margin < 0 时使用默认值（应在启动时调用）
+func SetExpiryMargin(margin time.Duration) {
+	if margin < 0 {
+		margin = DefaultExpiryMargin
+	}
+	expiryMargin = margin
+}
+
+// TTLFromExpiresIn 根据 token endpoint 返回的 expires_in（秒）计算缓存时间（有效期减去安全余量）
+// 返回值 <= 0 时表示令牌有效期太短，不应缓存
+func TTLFromExpiresIn(expiresIn int64) time.Duration {
+	lifetime := time.Duration(expiresIn) * time.Second
+	if expiresIn <= 0 {
+		lifetime = defaultTokenLifetime
+	}
+	return lifetime - expiryMargin
+}
 
 // Cache 定义缓存接口
 type Cache interface {
-	// GetAccessToken 使用 refresh token 获取 access token
-	GetAccessToken(refreshToken string) (string, error)
+	// GetAccessToken 获取缓存的 access token
+	GetAccessToken(key TokenKey) (string, error)
+
+	// GetAccessTokenWithTTL 获取缓存的 access token 及其剩余缓存时间
+	GetAccessTokenWithTTL(key TokenKey) (string, time.Duration, error)
 
 	// SetAccessToken 缓存 access token
-	SetAccessToken(refreshToken string, token string, expiration time.Duration) error
+	SetAccessToken(key TokenKey, token string, expiration time.Duration) error
 
 	// // DeleteAccessToken 删除 access token
-	// DeleteAccessToken(refreshToken string) error
+	// DeleteAccessToken(key TokenKey) error
 
 	// Close 关闭缓存连接
 	Close() error
 }
+
+// Locker 分布式锁（Redis 缓存实现），多实例部署时保证同一个 access token 只由一个实例刷新
+type Locker interface {
+	// TryLock 尝试获取 key 对应的刷新锁，获取成功时返回释放锁的函数；锁在 ttl 后自动释放
+	TryLock(ctx context.Context, key TokenKey, ttl time.Duration) (unlock func(), acquired bool, err error)
+}
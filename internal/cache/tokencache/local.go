@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"gomailapi2/internal/utils"
-
 	lru "github.com/hashicorp/golang-lru/v2"
 )
 
@@ -41,27 +39,34 @@ func NewLocalCache(size int) (*LocalCache, error) {
 	}, nil
 }
 
-// GetAccessToken 使用 refresh token 的短哈希获取 access token
-func (l *LocalCache) GetAccessToken(refreshToken string) (string, error) {
-	key := l.generateCacheKey(refreshToken)
+// GetAccessToken 获取缓存的 access token
+func (l *LocalCache) GetAccessToken(tokenKey TokenKey) (string, error) {
+	token, _, err := l.GetAccessTokenWithTTL(tokenKey)
+	return token, err
+}
+
+// GetAccessTokenWithTTL 获取缓存的 access token 及其剩余缓存时间
+func (l *LocalCache) GetAccessTokenWithTTL(tokenKey TokenKey) (string, time.Duration, error) {
+	key := tokenKey.String()
 
 	item, found := l.lru.Get(key)
 	if !found {
-		return "", fmt.Errorf("cache miss")
+		return "", 0, fmt.Errorf("cache miss")
 	}
 
 	// 检查是否过期
-	if time.Now().After(item.ExpiresAt) {
+	ttl := time.Until(item.ExpiresAt)
+	if ttl <= 0 {
 		l.lru.Remove(key)
-		return "", fmt.Errorf("cache expired")
+		return "", 0, fmt.Errorf("cache expired")
 	}
 
-	return item.Value, nil
+	return item.Value, ttl, nil
 }
 
-// SetAccessToken 使用 refresh token 的短哈希作为键缓存 access token
-func (l *LocalCache) SetAccessToken(refreshToken string, token string, expiration time.Duration) error {
-	key := l.generateCacheKey(refreshToken)
+// SetAccessToken 缓存 access token
+func (l *LocalCache) SetAccessToken(tokenKey TokenKey, token string, expiration time.Duration) error {
+	key := tokenKey.String()
 
 	item := &CacheItem{
 		Value:     token,
@@ -73,10 +78,9 @@ func (l *LocalCache) SetAccessToken(refreshToken string, token string, expiratio
 	return nil
 }
 
-// DeleteAccessToken 使用 refresh token 的短哈希删除 access token
-func (l *LocalCache) DeleteAccessToken(refreshToken string) error {
-	key := l.generateCacheKey(refreshToken)
-	l.lru.Remove(key)
+// DeleteAccessToken 删除缓存的 access token
+func (l *LocalCache) DeleteAccessToken(tokenKey TokenKey) error {
+	l.lru.Remove(tokenKey.String())
 	return nil
 }
 
@@ -85,7 +89,3 @@ func (l *LocalCache) Close() error {
 	l.lru.Purge()
 	return nil
 }
-
-func (l *LocalCache) generateCacheKey(refreshToken string) string {
-	return utils.GenerateCacheKey(refreshToken)
-}
@@ -1,14 +1,18 @@
 package tokencache
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
-// 确保 MultiLevelCache 实现了 Cache 接口
-var _ Cache = (*MultiLevelCache)(nil)
+// 确保 MultiLevelCache 实现了 Cache 和 Locker 接口
+var (
+	_ Cache  = (*MultiLevelCache)(nil)
+	_ Locker = (*MultiLevelCache)(nil)
+)
 
 // MultiLevelCache 多级缓存
 type MultiLevelCache struct {
@@ -25,44 +29,49 @@ func NewMultiLevelCache(l1Cache, l2Cache Cache) *MultiLevelCache {
 }
 
 // GetAccessToken 多级缓存获取 access token
+func (m *MultiLevelCache) GetAccessToken(key TokenKey) (string, error) {
+	token, _, err := m.GetAccessTokenWithTTL(key)
+	return token, err
+}
+
+// GetAccessTokenWithTTL 多级缓存获取 access token 及其剩余缓存时间
 // 流程：L1 → L2 → 未命中
-func (m *MultiLevelCache) GetAccessToken(refreshToken string) (string, error) {
+func (m *MultiLevelCache) GetAccessTokenWithTTL(key TokenKey) (string, time.Duration, error) {
 	// 1. 先尝试从 L1（本地缓存）获取
-	if token, err := m.l1Cache.GetAccessToken(refreshToken); err == nil {
-		return token, nil
+	if token, ttl, err := m.l1Cache.GetAccessTokenWithTTL(key); err == nil {
+		return token, ttl, nil
 	}
 
 	// 2. L1 未命中，尝试从 L2（Redis）获取
-	token, err := m.l2Cache.GetAccessToken(refreshToken)
+	token, ttl, err := m.l2Cache.GetAccessTokenWithTTL(key)
 	if err != nil {
-		return "", fmt.Errorf("cache miss in both L1 and L2: %w", err)
+		return "", 0, fmt.Errorf("cache miss in both L1 and L2: %w", err)
 	}
 
-	// 3. L2 命中，回填到 L1 缓存
-	// 使用较短的过期时间，避免 L1 缓存过期时间比 L2 长
-	if err := m.l1Cache.SetAccessToken(refreshToken, token, 50*time.Minute); err != nil {
-		log.Error().Err(err).Msg("Failed to backfill L1 cache")
-		// 不影响返回结果，只记录日志
+	// 3. L2 命中，按 L2 的剩余时间回填到 L1 缓存，避免 L1 比 L2 晚过期
+	if ttl > 0 {
+		if err := m.l1Cache.SetAccessToken(key, token, ttl); err != nil {
+			log.Error().Err(err).Msg("Failed to backfill L1 cache")
+			// 不影响返回结果，只记录日志
+		}
 	}
 
-	return token, nil
+	return token, ttl, nil
 }
 
 // SetAccessToken 多级缓存设置 access token
-// 流程：同时写入 L1 和 L2
-func (m *MultiLevelCache) SetAccessToken(refreshToken string, token string, expiration time.Duration) error {
+// 流程：同时写入 L1 和 L2，两级使用相同的过期时间
+func (m *MultiLevelCache) SetAccessToken(key TokenKey, token string, expiration time.Duration) error {
 	var l1Err, l2Err error
 
 	// 1. 写入 L1 缓存（本地）
 	if m.l1Cache != nil {
-		// L1 使用较短的过期时间或原始时间，取最小值
-		l1Expiration := min(expiration, 50*time.Minute)
-		l1Err = m.l1Cache.SetAccessToken(refreshToken, token, l1Expiration)
+		l1Err = m.l1Cache.SetAccessToken(key, token, expiration)
 	}
 
 	// 2. 写入 L2 缓存（Redis）
 	if m.l2Cache != nil {
-		l2Err = m.l2Cache.SetAccessToken(refreshToken, token, expiration)
+		l2Err = m.l2Cache.SetAccessToken(key, token, expiration)
 	}
 
 	// 3. 处理错误
@@ -83,19 +92,27 @@ func (m *MultiLevelCache) SetAccessToken(refreshToken string, token string, expi
 	return nil
 }
 
+// TryLock 使用 L2（Redis）的分布式锁，L2 不支持加锁时直接视为获取成功
+func (m *MultiLevelCache) TryLock(ctx context.Context, key TokenKey, ttl time.Duration) (func(), bool, error) {
+	if locker, ok := m.l2Cache.(Locker); ok {
+		return locker.TryLock(ctx, key, ttl)
+	}
+	return func() {}, true, nil
+}
+
 // // DeleteAccessToken 多级缓存删除 access token
 // // 流程：同时从 L1 和 L2 删除
-// func (m *MultiLevelCache) DeleteAccessToken(refreshToken string) error {
+// func (m *MultiLevelCache) DeleteAccessToken(key TokenKey) error {
 // 	var l1Err, l2Err error
 
 // 	// 1. 从 L1 缓存删除
 // 	if m.l1Cache != nil {
-// 		l1Err = m.l1Cache.DeleteAccessToken(refreshToken)
+// 		l1Err = m.l1Cache.DeleteAccessToken(key)
 // 	}
 
 // 	// 2. 从 L2 缓存删除
 // 	if m.l2Cache != nil {
-// 		l2Err = m.l2Cache.DeleteAccessToken(refreshToken)
+// 		l2Err = m.l2Cache.DeleteAccessToken(key)
 // 	}
 
 // 	// 3. 处理错误
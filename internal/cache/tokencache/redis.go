@@ -2,6 +2,8 @@ package tokencache
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -11,8 +13,22 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// 确保 RedisClient 实现了 Cache 接口
-var _ Cache = (*RedisClient)(nil)
+// 确保 RedisClient 实现了 Cache 和 Locker 接口
+var (
+	_ Cache  = (*RedisClient)(nil)
+	_ Locker = (*RedisClient)(nil)
+)
+
+// unlockScript 只删除自己持有的锁（锁过期后可能已被其他实例获取）
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// legacyKeyScanCount 迁移旧格式缓存键时每次扫描和删除的数量
+const legacyKeyScanCount = 500
 
 type RedisClient struct {
 	client *redis.Client
@@ -36,33 +52,111 @@ func NewRedisClient(redisConfig config.RedisConfig) (*RedisClient, error) {
 	return &RedisClient{client: rdb}, nil
 }
 
-// GetAccessToken 使用 refresh token 的短哈希获取 access token
-func (r *RedisClient) GetAccessToken(refreshToken string) (string, error) {
-	key := r.generateCacheKey(refreshToken)
+// GetAccessToken 获取缓存的 access token
+func (r *RedisClient) GetAccessToken(key TokenKey) (string, error) {
 	ctx := context.Background()
-	return r.client.Get(ctx, key).Result()
+	return r.client.Get(ctx, key.String()).Result()
 }
 
-// SetAccessToken 使用 refresh token 的短哈希作为键缓存 access token
-func (r *RedisClient) SetAccessToken(refreshToken string, token string, expiration time.Duration) error {
-	key := r.generateCacheKey(refreshToken)
+// GetAccessTokenWithTTL 获取缓存的 access token 及其剩余缓存时间（GET 和 PTTL 在同一个 pipeline 中执行）
+func (r *RedisClient) GetAccessTokenWithTTL(key TokenKey) (string, time.Duration, error) {
 	ctx := context.Background()
-	return r.client.Set(ctx, key, token, expiration).Err()
+
+	var getCmd *redis.StringCmd
+	var ttlCmd *redis.DurationCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(ctx, key.String())
+		ttlCmd = pipe.PTTL(ctx, key.String())
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	// 键没有过期时间时 PTTL 返回负数，按未知处理
+	ttl := ttlCmd.Val()
+	if ttl < 0 {
+		ttl = 0
+	}
+	return getCmd.Val(), ttl, nil
 }
 
-// DeleteAccessToken 使用 refresh token 的短哈希删除 access token
-func (r *RedisClient) DeleteAccessToken(refreshToken string) error {
-	key := r.generateCacheKey(refreshToken)
+// SetAccessToken 缓存 access token
+func (r *RedisClient) SetAccessToken(key TokenKey, token string, expiration time.Duration) error {
 	ctx := context.Background()
-	return r.client.Del(ctx, key).Err()
+	return r.client.Set(ctx, key.String(), token, expiration).Err()
+}
+
+// DeleteAccessToken 删除缓存的 access token
+func (r *RedisClient) DeleteAccessToken(key TokenKey) error {
+	ctx := context.Background()
+	return r.client.Del(ctx, key.String()).Err()
+}
+
+// TryLock 通过 SET NX PX 获取刷新锁，锁的值为随机令牌，释放时校验
+func (r *RedisClient) TryLock(ctx context.Context, key TokenKey, ttl time.Duration) (func(), bool, error) {
+	lockKey := "lock:" + key.String()
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, false, err
+	}
+	lockToken := hex.EncodeToString(buf)
+
+	acquired, err := r.client.SetNX(ctx, lockKey, lockToken, ttl).Result()
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+
+	unlock := func() {
+		// 请求的 ctx 可能已经结束，使用新的 ctx 释放锁
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		unlockScript.Run(unlockCtx, r.client, []string{lockKey}, lockToken)
+	}
+	return unlock, true, nil
+}
+
+// MigrateLegacyKeys 清理旧格式的缓存键，返回删除的数量
+// 旧格式的键只包含 refresh token 的短哈希，无法确定缓存的 access token 属于哪个 scope（受众），
+// 因此不做转换而是直接删除，之后的请求会按新格式重新获取并缓存
+func (r *RedisClient) MigrateLegacyKeys(ctx context.Context) (int, error) {
+	deleted := 0
+	iter := r.client.Scan(ctx, 0, utils.LegacyCacheKeyPattern, legacyKeyScanCount).Iterator()
+
+	batch := make([]string, 0, legacyKeyScanCount)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := r.client.Del(ctx, batch...).Result()
+		if err != nil {
+			return fmt.Errorf("删除旧格式缓存键失败: %w", err)
+		}
+		deleted += int(n)
+		batch = batch[:0]
+		return nil
+	}
+
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == legacyKeyScanCount {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, fmt.Errorf("扫描旧格式缓存键失败: %w", err)
+	}
+	if err := flush(); err != nil {
+		return deleted, err
+	}
+
+	return deleted, nil
 }
 
 // Close 关闭 Redis 连接
 func (r *RedisClient) Close() error {
 	return r.client.Close()
 }
-
-// generateCacheKey 生成基于 refresh token 短哈希的缓存键
-func (r *RedisClient) generateCacheKey(refreshToken string) string {
-	return utils.GenerateCacheKey(refreshToken)
-}
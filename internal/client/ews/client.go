@@ -0,0 +1,205 @@
+package ews
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// ExchangeOnlineURL Exchange Online 的 EWS 地址
+	ExchangeOnlineURL = "https://outlook.office365.com/EWS/Exchange.asmx"
+	// requestTimeout 单个 EWS 请求的超时时间
+	requestTimeout = 60 * time.Second
+)
+
+// Credentials EWS 登录信息（AccessToken 不为空时使用 OAuth，否则使用密码认证）
+type Credentials struct {
+	Email       string // 邮箱地址（OAuth 时作为 X-AnchorMailbox）
+	Username    string // 登录用户名，NTLM 认证可以使用 DOMAIN\user 或 user@domain 格式
+	Password    string // 密码
+	UseNTLM     bool   // 是否使用 NTLM 认证，否则使用 Basic 认证
+	AccessToken string // OAuth 访问令牌（Bearer）
+}
+
+// Client EWS 客户端
+type Client struct {
+	endpoint    string
+	credentials *Credentials
+	httpClient  *http.Client
+}
+
+// NewClient 创建 EWS 客户端，endpoint 为 EWS 地址（如 https://mail.example.com/EWS/Exchange.asmx）
+func NewClient(endpoint string, credentials *Credentials) *Client {
+	// NTLM 认证的是连接而不是请求，握手的几个请求必须使用同一个连接，因此每个客户端使用独立的连接池并限制为一个连接
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxConnsPerHost = 1
+
+	return &Client{
+		endpoint:    endpoint,
+		credentials: credentials,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   requestTimeout,
+		},
+	}
+}
+
+// call 发送 EWS 操作请求并将响应解析到 response
+func (c *Client) call(ctx context.Context, operation, response any) error {
+	payload, err := xml.Marshal(&envelope{
+		SoapNS:     soapNamespace,
+		TypesNS:    typesNamespace,
+		MessagesNS: messagesNamespace,
+		Header: header{
+			RequestServerVersion: serverVersion{Version: requestServerVersion},
+		},
+		Body: body{Operation: operation},
+	})
+	if err != nil {
+		return fmt.Errorf("序列化 EWS 请求失败: %w", err)
+	}
+	payload = append([]byte(xml.Header), payload...)
+
+	resp, err := c.doRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取 EWS 响应失败: %w", err)
+	}
+
+	// SOAP 错误的状态码为 500，先按错误响应解析
+	var fault faultEnvelope
+	if err := xml.Unmarshal(data, &fault); err == nil && fault.Fault != nil {
+		return fault.Fault
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusUnauthorized {
+			return errors.New("EWS 认证失败，请检查用户名、密码或访问令牌")
+		}
+		return fmt.Errorf("EWS 请求失败 (状态码: %d): %s", resp.StatusCode, truncate(string(data), 512))
+	}
+
+	if err := xml.Unmarshal(data, response); err != nil {
+		return fmt.Errorf("解析 EWS 响应失败: %w", err)
+	}
+	return nil
+}
+
+// doRequest 发送带认证信息的请求
+func (c *Client) doRequest(ctx context.Context, payload []byte) (*http.Response, error) {
+	if c.credentials.AccessToken == "" && c.credentials.UseNTLM {
+		return c.doNTLMRequest(ctx, payload)
+	}
+
+	req, err := c.newRequest(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.credentials.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.credentials.AccessToken)
+		// Exchange Online 根据 X-AnchorMailbox 将请求路由到邮箱所在的服务器
+		if c.credentials.Email != "" {
+			req.Header.Set("X-AnchorMailbox", c.credentials.Email)
+		}
+	} else {
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送 EWS 请求失败: %w", err)
+	}
+	return resp, nil
+}
+
+// doNTLMRequest 通过 NTLM 握手发送请求：先发送协商消息获取服务器挑战，再在同一个连接上发送认证消息
+func (c *Client) doNTLMRequest(ctx context.Context, payload []byte) (*http.Response, error) {
+	req, err := c.newRequest(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "NTLM "+base64.StdEncoding.EncodeToString(negotiateMessage()))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送 EWS 请求失败: %w", err)
+	}
+	// 读完响应体才能复用连接
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil, fmt.Errorf("NTLM 协商失败 (状态码: %d)", resp.StatusCode)
+	}
+
+	challenge, err := parseNTLMChallengeHeader(resp.Header.Values("WWW-Authenticate"))
+	if err != nil {
+		return nil, err
+	}
+
+	authenticate, err := authenticateMessage(challenge, c.credentials.Username, c.credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err = c.newRequest(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "NTLM "+base64.StdEncoding.EncodeToString(authenticate))
+
+	resp, err = c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送 EWS 请求失败: %w", err)
+	}
+	return resp, nil
+}
+
+// newRequest 创建 SOAP 请求
+func (c *Client) newRequest(ctx context.Context, payload []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("创建 EWS 请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("Accept", "text/xml")
+	return req, nil
+}
+
+// parseNTLMChallengeHeader 从 WWW-Authenticate 头中解析 NTLM 挑战消息
+func parseNTLMChallengeHeader(values []string) ([]byte, error) {
+	for _, value := range values {
+		encoded, ok := strings.CutPrefix(value, "NTLM ")
+		if !ok {
+			continue
+		}
+		challenge, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("解码 NTLM 挑战消息失败: %w", err)
+		}
+		return challenge, nil
+	}
+	return nil, errors.New("服务器不支持 NTLM 认证")
+}
+
+// truncate 截断过长的字符串（用于错误信息）
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,157 @@
+package ews
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/client/mailparse"
+	"gomailapi2/internal/domain"
+	"strings"
+)
+
+// DefaultMaxPartSize 单个正文分段默认的最大读取字节数（1 MB）
+const DefaultMaxPartSize = 1 << 20
+
+// maxPartSize 单个正文分段的最大读取字节数，超出部分截断并在邮件中标记
+var maxPartSize int64 = DefaultMaxPartSize
+
+// SetMaxPartSize 设置单个正文分段的最大读取字节数，size <= 0 时使用默认值（应在启动时调用）
+func SetMaxPartSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxPartSize
+	}
+	maxPartSize = size
+}
+
+// distinguishedFolderIDs 文件夹角色与 EWS 知名文件夹的对应关系
+// EWS 没有与 archive 对应的知名文件夹（存档文件夹需要使用文件夹 ID）
+var distinguishedFolderIDs = map[string]string{
+	domain.FolderRoleInbox:  "inbox",
+	domain.FolderRoleSent:   "sentitems",
+	domain.FolderRoleDrafts: "drafts",
+	domain.FolderRoleJunk:   "junkemail",
+	domain.FolderRoleTrash:  "deleteditems",
+}
+
+// GetLatestEmail 获取指定文件夹的最新一封邮件，folder 为空时默认收件箱
+// 通过 FindItem 按接收时间倒序查找，再通过 GetItem 获取 MIME 内容并解析
+func (c *Client) GetLatestEmail(ctx context.Context, folder string) (*domain.Email, error) {
+	folderIDs, err := resolveFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var response findItemResponse
+	err = c.call(ctx, &findItemRequest{
+		Traversal: "Shallow",
+		ItemShape: itemShape{BaseShape: "IdOnly"},
+		IndexedPageItemView: indexedPageView{
+			MaxEntriesReturned: 1,
+			Offset:             0,
+			BasePoint:          "Beginning",
+		},
+		SortOrder: []fieldOrder{{
+			Order:    "Descending",
+			FieldURI: fieldURI{FieldURI: "item:DateTimeReceived"},
+		}},
+		ParentFolderIDs: folderIDs,
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("查找邮件失败: %w", err)
+	}
+
+	if len(response.Messages) == 0 {
+		return nil, errors.New("EWS 响应缺少 FindItem 结果")
+	}
+	if err := response.Messages[0].err(); err != nil {
+		return nil, fmt.Errorf("查找邮件失败: %w", err)
+	}
+
+	found := response.Messages[0].RootFolder.Items.Items
+	if len(found) == 0 {
+		return nil, nil // 没有找到邮件时返回 nil，不是错误
+	}
+
+	return c.getItem(ctx, found[0].ItemID.ID)
+}
+
+// GetLatestEmailFromJunk 获取垃圾邮件文件夹的最新一封邮件
+func (c *Client) GetLatestEmailFromJunk(ctx context.Context) (*domain.Email, error) {
+	return c.GetLatestEmail(ctx, domain.FolderRoleJunk)
+}
+
+// GetEmailByID 根据邮件 ID 获取邮件详情（邮件 ID 为 URL 安全编码的 EWS ItemId）
+func (c *Client) GetEmailByID(ctx context.Context, emailID string) (*domain.Email, error) {
+	if emailID == "" {
+		return nil, errors.New("邮件 ID 不能为空")
+	}
+	return c.getItem(ctx, decodeItemID(emailID))
+}
+
+// getItem 通过 GetItem 获取邮件的 MIME 内容并解析
+func (c *Client) getItem(ctx context.Context, id string) (*domain.Email, error) {
+	var response getItemResponse
+	err := c.call(ctx, &getItemRequest{
+		ItemShape: itemShape{BaseShape: "IdOnly", IncludeMimeContent: true},
+		ItemIDs:   []requestItemID{{ID: id}},
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("获取邮件失败: %w", err)
+	}
+
+	if len(response.Messages) == 0 {
+		return nil, errors.New("EWS 响应缺少 GetItem 结果")
+	}
+	message := response.Messages[0]
+	if err := message.err(); err != nil {
+		var respErr *responseError
+		if errors.As(err, &respErr) && respErr.Code == "ErrorItemNotFound" {
+			return nil, fmt.Errorf("未找到 ID 为 %s 的邮件", encodeItemID(id))
+		}
+		return nil, fmt.Errorf("获取邮件失败: %w", err)
+	}
+	if len(message.Items.Items) == 0 {
+		return nil, fmt.Errorf("未找到 ID 为 %s 的邮件", encodeItemID(id))
+	}
+
+	found := message.Items.Items[0]
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(found.MimeContent.Content))
+	if err != nil {
+		return nil, fmt.Errorf("解码邮件内容失败: %w", err)
+	}
+
+	email, err := mailparse.ParseMessage(bytes.NewReader(raw), maxPartSize)
+	if err != nil {
+		return nil, err
+	}
+	email.ID = encodeItemID(found.ItemID.ID)
+	return email, nil
+}
+
+// resolveFolder 将 folder 参数解析为 EWS 文件夹：为空时为收件箱，文件夹角色使用知名文件夹，其他值视为文件夹 ID
+func resolveFolder(folder string) (targetFolderIDs, error) {
+	if folder == "" {
+		folder = domain.FolderRoleInbox
+	}
+
+	if id, ok := distinguishedFolderIDs[strings.ToLower(folder)]; ok {
+		return targetFolderIDs{DistinguishedFolderID: &requestFolderID{ID: id}}, nil
+	}
+	if domain.IsFolderRole(folder) {
+		return targetFolderIDs{}, fmt.Errorf("EWS 不支持文件夹角色 %s，请使用文件夹 ID", folder)
+	}
+
+	return targetFolderIDs{FolderID: &requestFolderID{ID: decodeItemID(folder)}}, nil
+}
+
+// encodeItemID 将 EWS ID（标准 base64）转换为 URL 安全的形式，便于作为 REST 路径参数
+func encodeItemID(id string) string {
+	return strings.NewReplacer("+", "-", "/", "_").Replace(id)
+}
+
+// decodeItemID 将 URL 安全的 ID 还原为 EWS ID（已经是标准形式的 ID 保持不变）
+func decodeItemID(id string) string {
+	return strings.NewReplacer("-", "+", "_", "/").Replace(id)
+}
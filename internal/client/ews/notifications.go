@@ -0,0 +1,156 @@
+package ews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"log"
+	"time"
+)
+
+const (
+	// pullInterval 拉取通知事件的间隔
+	pullInterval = 10 * time.Second
+	// pullSubscriptionTimeout 拉取订阅的超时时间（分钟），超过该时间没有调用 GetEvents 时服务器删除订阅
+	pullSubscriptionTimeout = 10
+)
+
+// Subscription 已创建的 EWS 拉取订阅
+type Subscription struct {
+	client    *Client
+	id        string
+	watermark string // 已处理到的水印
+}
+
+// Subscribe 通过拉取订阅（Subscribe + GetEvents）订阅收件箱的新邮件，订阅创建后返回；
+// 之后调用 Listen 接收新邮件，结束时调用 Close 取消订阅
+// 拉取订阅只需要普通的请求/响应，兼容各版本的 Exchange 和中间的代理服务器
+func (c *Client) Subscribe(ctx context.Context) (*Subscription, error) {
+	subscriptionID, watermark, err := c.subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Subscription{client: c, id: subscriptionID, watermark: watermark}, nil
+}
+
+// Listen 定时拉取通知事件，收到新邮件时发送到 emailChan；阻塞直到 ctx 结束（返回 nil）或拉取出错
+func (s *Subscription) Listen(ctx context.Context, emailChan chan<- *domain.Email) error {
+	ticker := time.NewTicker(pullInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		itemIDs, watermark, err := s.client.getEvents(ctx, s.id, s.watermark)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		s.watermark = watermark
+
+		for _, id := range itemIDs {
+			email, err := s.client.getItem(ctx, id)
+			if err != nil {
+				// 邮件可能已被移动或删除，跳过
+				log.Printf("获取新邮件失败: %v", err)
+				continue
+			}
+			select {
+			case emailChan <- email:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// Close 取消订阅（不依赖调用方的 ctx，调用方的 ctx 可能已经结束）
+func (s *Subscription) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	return s.client.unsubscribe(ctx, s.id)
+}
+
+// subscribe 创建收件箱新邮件事件的拉取订阅，返回订阅 ID 和初始水印
+func (c *Client) subscribe(ctx context.Context) (string, string, error) {
+	var response subscribeResponse
+	err := c.call(ctx, &subscribeRequest{
+		PullSubscriptionRequest: pullSubscriptionRequest{
+			FolderIDs: targetFolderIDs{
+				DistinguishedFolderID: &requestFolderID{ID: distinguishedFolderIDs[domain.FolderRoleInbox]},
+			},
+			EventTypes: []string{"NewMailEvent"},
+			Timeout:    pullSubscriptionTimeout,
+		},
+	}, &response)
+	if err != nil {
+		return "", "", fmt.Errorf("创建 EWS 订阅失败: %w", err)
+	}
+
+	if len(response.Messages) == 0 {
+		return "", "", errors.New("EWS 响应缺少 Subscribe 结果")
+	}
+	message := response.Messages[0]
+	if err := message.err(); err != nil {
+		return "", "", fmt.Errorf("创建 EWS 订阅失败: %w", err)
+	}
+
+	return message.SubscriptionID, message.Watermark, nil
+}
+
+// getEvents 获取水印之后的事件（服务器还有更多事件时继续获取），返回新邮件的 ItemId 和新的水印
+func (c *Client) getEvents(ctx context.Context, subscriptionID, watermark string) ([]string, string, error) {
+	var itemIDs []string
+	for {
+		var response getEventsResponse
+		err := c.call(ctx, &getEventsRequest{
+			SubscriptionID: subscriptionID,
+			Watermark:      watermark,
+		}, &response)
+		if err != nil {
+			return nil, "", fmt.Errorf("获取 EWS 通知失败: %w", err)
+		}
+
+		if len(response.Messages) == 0 {
+			return nil, "", errors.New("EWS 响应缺少 GetEvents 结果")
+		}
+		message := response.Messages[0]
+		if err := message.err(); err != nil {
+			return nil, "", fmt.Errorf("获取 EWS 通知失败: %w", err)
+		}
+
+		// Notification 中除事件外还有 SubscriptionId 等元素，只处理带水印的事件
+		for _, e := range message.Notification.Events {
+			if e.Watermark == "" {
+				continue
+			}
+			watermark = e.Watermark
+			if e.XMLName.Local == "NewMailEvent" && e.ItemID.ID != "" {
+				itemIDs = append(itemIDs, e.ItemID.ID)
+			}
+		}
+
+		if !message.Notification.MoreEvents {
+			return itemIDs, watermark, nil
+		}
+	}
+}
+
+// unsubscribe 取消订阅
+func (c *Client) unsubscribe(ctx context.Context, subscriptionID string) error {
+	var response unsubscribeResponse
+	if err := c.call(ctx, &unsubscribeRequest{SubscriptionID: subscriptionID}, &response); err != nil {
+		return err
+	}
+	if len(response.Messages) > 0 {
+		return response.Messages[0].err()
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package ews
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+// NTLM 认证（MS-NLMP），只实现 HTTP 认证需要的 NTLMv2 部分，不做消息签名和加密
+
+// NTLM 协商标志
+const (
+	ntlmNegotiateUnicode                 = 0x00000001
+	ntlmRequestTarget                    = 0x00000004
+	ntlmNegotiateNTLM                    = 0x00000200
+	ntlmNegotiateAlwaysSign              = 0x00008000
+	ntlmNegotiateExtendedSessionSecurity = 0x00080000
+	ntlmNegotiateTargetInfo              = 0x00800000
+	ntlmNegotiate128                     = 0x20000000
+	ntlmNegotiate56                      = 0x80000000
+
+	ntlmNegotiateFlags = ntlmNegotiateUnicode | ntlmRequestTarget | ntlmNegotiateNTLM | ntlmNegotiateAlwaysSign |
+		ntlmNegotiateExtendedSessionSecurity | ntlmNegotiateTargetInfo | ntlmNegotiate128 | ntlmNegotiate56
+)
+
+// 目标信息（AV_PAIR）的类型
+const (
+	ntlmAvIDEOL       uint16 = 0 // 目标信息结束
+	ntlmAvIDTimestamp uint16 = 7 // MsvAvTimestamp
+)
+
+// ntlmSignature NTLM 消息签名
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// 客户端挑战的随机数来源和当前时间（测试时替换为固定值）
+var (
+	ntlmRandom io.Reader = rand.Reader
+	ntlmNow              = time.Now
+)
+
+// windowsEpochOffset 1601-01-01 到 1970-01-01 的秒数（NTLM 时间戳从 1601 年开始，单位为 100 纳秒）
+const windowsEpochOffset = 11644473600
+
+// negotiateMessage 构建协商消息（类型 1），不携带域名和工作站名
+func negotiateMessage() []byte {
+	message := make([]byte, 32)
+	copy(message, ntlmSignature)
+	binary.LittleEndian.PutUint32(message[8:], 1)
+	binary.LittleEndian.PutUint32(message[12:], ntlmNegotiateFlags)
+	return message
+}
+
+// authenticateMessage 根据服务器的挑战消息（类型 2）构建认证消息（类型 3），使用 NTLMv2 响应
+func authenticateMessage(challenge []byte, username, password string) ([]byte, error) {
+	if len(challenge) < 48 || !bytes.Equal(challenge[:8], ntlmSignature) || binary.LittleEndian.Uint32(challenge[8:]) != 2 {
+		return nil, errors.New("无效的 NTLM 挑战消息")
+	}
+
+	flags := binary.LittleEndian.Uint32(challenge[20:])
+	serverChallenge := challenge[24:32]
+
+	targetInfoLen := int(binary.LittleEndian.Uint16(challenge[40:]))
+	targetInfoOffset := int(binary.LittleEndian.Uint32(challenge[44:]))
+	if targetInfoOffset+targetInfoLen > len(challenge) {
+		return nil, errors.New("无效的 NTLM 挑战消息")
+	}
+	targetInfo := challenge[targetInfoOffset : targetInfoOffset+targetInfoLen]
+
+	// 用户名可以是 DOMAIN\user 或 user@domain 格式（后者域名为空）
+	domain, user := "", username
+	if i := strings.Index(username, `\`); i >= 0 {
+		domain, user = username[:i], username[i+1:]
+	}
+
+	clientChallenge := make([]byte, 8)
+	if _, err := io.ReadFull(ntlmRandom, clientChallenge); err != nil {
+		return nil, err
+	}
+
+	// 优先使用服务器提供的时间戳，此时 LMv2 响应必须为全零
+	timestamp, hasTimestamp := ntlmTimestamp(targetInfo)
+	if !hasTimestamp {
+		now := ntlmNow()
+		timestamp = make([]byte, 8)
+		binary.LittleEndian.PutUint64(timestamp, uint64((now.Unix()+windowsEpochOffset)*1e7+int64(now.Nanosecond()/100)))
+	}
+
+	responseKey := ntlmv2Hash(user, password, domain)
+
+	// NTLMv2 客户端挑战结构
+	var temp bytes.Buffer
+	temp.Write([]byte{1, 1, 0, 0, 0, 0, 0, 0})
+	temp.Write(timestamp)
+	temp.Write(clientChallenge)
+	temp.Write([]byte{0, 0, 0, 0})
+	temp.Write(targetInfo)
+	temp.Write([]byte{0, 0, 0, 0})
+
+	ntProof := hmacMD5(responseKey, serverChallenge, temp.Bytes())
+	ntResponse := append(ntProof, temp.Bytes()...)
+
+	lmResponse := make([]byte, 24)
+	if !hasTimestamp {
+		lmResponse = append(hmacMD5(responseKey, serverChallenge, clientChallenge), clientChallenge...)
+	}
+
+	// 消息头 64 字节，之后依次为各字段的内容
+	fields := [][]byte{lmResponse, ntResponse, encodeUTF16(domain), encodeUTF16(user), nil, nil}
+	message := make([]byte, 64)
+	copy(message, ntlmSignature)
+	binary.LittleEndian.PutUint32(message[8:], 3)
+
+	offset := len(message)
+	for i, field := range fields {
+		position := 12 + i*8
+		binary.LittleEndian.PutUint16(message[position:], uint16(len(field)))
+		binary.LittleEndian.PutUint16(message[position+2:], uint16(len(field)))
+		binary.LittleEndian.PutUint32(message[position+4:], uint32(offset))
+		offset += len(field)
+	}
+	binary.LittleEndian.PutUint32(message[60:], flags&ntlmNegotiateFlags)
+
+	for _, field := range fields {
+		message = append(message, field...)
+	}
+	return message, nil
+}
+
+// ntlmTimestamp 从目标信息中查找 MsvAvTimestamp
+func ntlmTimestamp(targetInfo []byte) ([]byte, bool) {
+	for len(targetInfo) >= 4 {
+		id := binary.LittleEndian.Uint16(targetInfo)
+		length := int(binary.LittleEndian.Uint16(targetInfo[2:]))
+		if id == ntlmAvIDEOL || len(targetInfo) < 4+length {
+			break
+		}
+		if id == ntlmAvIDTimestamp && length == 8 {
+			return targetInfo[4:12], true
+		}
+		targetInfo = targetInfo[4+length:]
+	}
+	return nil, false
+}
+
+// ntlmv2Hash 计算 NTOWFv2：HMAC-MD5(MD4(UTF16LE(password)), UTF16LE(UPPER(user) + domain))
+func ntlmv2Hash(user, password, domain string) []byte {
+	ntHash := md4.New()
+	ntHash.Write(encodeUTF16(password))
+	return hmacMD5(ntHash.Sum(nil), encodeUTF16(strings.ToUpper(user)+domain))
+}
+
+// hmacMD5 计算 HMAC-MD5
+func hmacMD5(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(md5.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// encodeUTF16 编码为 UTF-16LE
+func encodeUTF16(s string) []byte {
+	codes := utf16.Encode([]rune(s))
+	result := make([]byte, len(codes)*2)
+	for i, code := range codes {
+		binary.LittleEndian.PutUint16(result[i*2:], code)
+	}
+	return result
+}
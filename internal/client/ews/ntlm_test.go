@@ -0,0 +1,85 @@
+package ews
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+// MS-NLMP 4.2.4 NTLMv2 认证的测试数据
+const (
+	testNTLMUser     = "User"
+	testNTLMDomain   = "Domain"
+	testNTLMPassword = "Password"
+)
+
+// testNTLMChallenge MS-NLMP 4.2.4.3 的挑战消息（服务器挑战 0123456789abcdef，目标信息不含时间戳）
+const testNTLMChallenge = "4e544c4d53535000020000000c000c003800000033828ae20123456789abcdef" +
+	"00000000000000002400240044000000060070170000000f44006f006d006100" +
+	"69006e0002000c0044006f006d00610069006e0001000c005300650072007600" +
+	"6500720000000000"
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("解码十六进制失败: %v", err)
+	}
+	return b
+}
+
+func TestNTLMv2Hash(t *testing.T) {
+	want := mustDecodeHex(t, "0c868a403bfd7a93a3001ef22ef02e3f")
+	if got := ntlmv2Hash(testNTLMUser, testNTLMPassword, testNTLMDomain); !bytes.Equal(got, want) {
+		t.Errorf("ntlmv2Hash = %x, want %x", got, want)
+	}
+}
+
+func TestAuthenticateMessage(t *testing.T) {
+	// 客户端挑战 aaaaaaaaaaaaaaaa，时间戳为 0（1601-01-01）
+	random, now := ntlmRandom, ntlmNow
+	defer func() { ntlmRandom, ntlmNow = random, now }()
+	ntlmRandom = bytes.NewReader(bytes.Repeat([]byte{0xaa}, 8))
+	ntlmNow = func() time.Time { return time.Unix(-windowsEpochOffset, 0) }
+
+	challenge := mustDecodeHex(t, testNTLMChallenge)
+	message, err := authenticateMessage(challenge, testNTLMDomain+`\`+testNTLMUser, testNTLMPassword)
+	if err != nil {
+		t.Fatalf("authenticateMessage 失败: %v", err)
+	}
+
+	if !bytes.Equal(message[:8], ntlmSignature) || binary.LittleEndian.Uint32(message[8:]) != 3 {
+		t.Fatalf("消息头无效: %x", message[:12])
+	}
+
+	field := func(index int) []byte {
+		position := 12 + index*8
+		length := int(binary.LittleEndian.Uint16(message[position:]))
+		offset := int(binary.LittleEndian.Uint32(message[position+4:]))
+		return message[offset : offset+length]
+	}
+
+	wantLM := mustDecodeHex(t, "86c35097ac9cec102554764a57cccc19aaaaaaaaaaaaaaaa")
+	if got := field(0); !bytes.Equal(got, wantLM) {
+		t.Errorf("LMv2 响应 = %x, want %x", got, wantLM)
+	}
+
+	// NTLMv2 响应：NTProofStr + 客户端挑战结构（含挑战消息中的目标信息）
+	targetInfo := challenge[68:104]
+	wantNT := mustDecodeHex(t, "68cd0ab851e51c96aabc927bebef6a1c"+
+		"0101000000000000"+"0000000000000000"+"aaaaaaaaaaaaaaaa"+"00000000")
+	wantNT = append(wantNT, targetInfo...)
+	wantNT = append(wantNT, 0, 0, 0, 0)
+	if got := field(1); !bytes.Equal(got, wantNT) {
+		t.Errorf("NTLMv2 响应 = %x, want %x", got, wantNT)
+	}
+
+	if got, want := field(2), encodeUTF16(testNTLMDomain); !bytes.Equal(got, want) {
+		t.Errorf("域名 = %x, want %x", got, want)
+	}
+	if got, want := field(3), encodeUTF16(testNTLMUser); !bytes.Equal(got, want) {
+		t.Errorf("用户名 = %x, want %x", got, want)
+	}
+}
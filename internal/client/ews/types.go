@@ -0,0 +1,258 @@
+package ews
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// EWS 使用的 XML 命名空间
+const (
+	soapNamespace     = "http://schemas.xmlsoap.org/soap/envelope/"
+	typesNamespace    = "http://schemas.microsoft.com/exchange/services/2006/types"
+	messagesNamespace = "http://schemas.microsoft.com/exchange/services/2006/messages"
+)
+
+// requestServerVersion 请求的 Exchange 架构版本（Exchange 2010 SP2 及以上的服务器均支持）
+const requestServerVersion = "Exchange2010_SP2"
+
+// 请求结构（元素名带有 soap:、t:、m: 前缀，与 envelope 中声明的命名空间对应）
+
+// envelope SOAP 请求
+type envelope struct {
+	XMLName    xml.Name `xml:"soap:Envelope"`
+	SoapNS     string   `xml:"xmlns:soap,attr"`
+	TypesNS    string   `xml:"xmlns:t,attr"`
+	MessagesNS string   `xml:"xmlns:m,attr"`
+	Header     header   `xml:"soap:Header"`
+	Body       body     `xml:"soap:Body"`
+}
+
+// header SOAP 请求头
+type header struct {
+	RequestServerVersion serverVersion `xml:"t:RequestServerVersion"`
+}
+
+// serverVersion 请求的架构版本
+type serverVersion struct {
+	Version string `xml:"Version,attr"`
+}
+
+// body SOAP 请求体，Operation 为具体的操作（FindItem、GetItem 等）
+type body struct {
+	Operation any
+}
+
+// findItemRequest FindItem 操作
+type findItemRequest struct {
+	XMLName             xml.Name        `xml:"m:FindItem"`
+	Traversal           string          `xml:"Traversal,attr"`
+	ItemShape           itemShape       `xml:"m:ItemShape"`
+	IndexedPageItemView indexedPageView `xml:"m:IndexedPageItemView"`
+	SortOrder           []fieldOrder    `xml:"m:SortOrder>t:FieldOrder"`
+	ParentFolderIDs     targetFolderIDs `xml:"m:ParentFolderIds"`
+}
+
+// getItemRequest GetItem 操作
+type getItemRequest struct {
+	XMLName   xml.Name        `xml:"m:GetItem"`
+	ItemShape itemShape       `xml:"m:ItemShape"`
+	ItemIDs   []requestItemID `xml:"m:ItemIds>t:ItemId"`
+}
+
+// subscribeRequest Subscribe 操作（拉取订阅）
+type subscribeRequest struct {
+	XMLName                 xml.Name                `xml:"m:Subscribe"`
+	PullSubscriptionRequest pullSubscriptionRequest `xml:"m:PullSubscriptionRequest"`
+}
+
+// pullSubscriptionRequest 拉取订阅的参数
+type pullSubscriptionRequest struct {
+	FolderIDs  targetFolderIDs `xml:"t:FolderIds"`
+	EventTypes []string        `xml:"t:EventTypes>t:EventType"`
+	Timeout    int             `xml:"t:Timeout"` // 两次 GetEvents 之间的最长间隔（分钟），超过后订阅失效
+}
+
+// getEventsRequest GetEvents 操作
+type getEventsRequest struct {
+	XMLName        xml.Name `xml:"m:GetEvents"`
+	SubscriptionID string   `xml:"m:SubscriptionId"`
+	Watermark      string   `xml:"m:Watermark"`
+}
+
+// unsubscribeRequest Unsubscribe 操作
+type unsubscribeRequest struct {
+	XMLName        xml.Name `xml:"m:Unsubscribe"`
+	SubscriptionID string   `xml:"m:SubscriptionId"`
+}
+
+// itemShape 返回的邮件属性
+type itemShape struct {
+	BaseShape          string `xml:"t:BaseShape"`
+	IncludeMimeContent bool   `xml:"t:IncludeMimeContent,omitempty"`
+}
+
+// indexedPageView 分页参数
+type indexedPageView struct {
+	MaxEntriesReturned int    `xml:"MaxEntriesReturned,attr"`
+	Offset             int    `xml:"Offset,attr"`
+	BasePoint          string `xml:"BasePoint,attr"`
+}
+
+// fieldOrder 排序条件
+type fieldOrder struct {
+	Order    string   `xml:"Order,attr"`
+	FieldURI fieldURI `xml:"t:FieldURI"`
+}
+
+// fieldURI 属性路径
+type fieldURI struct {
+	FieldURI string `xml:"FieldURI,attr"`
+}
+
+// targetFolderIDs 文件夹（知名文件夹与文件夹 ID 二选一）
+type targetFolderIDs struct {
+	DistinguishedFolderID *requestFolderID `xml:"t:DistinguishedFolderId,omitempty"`
+	FolderID              *requestFolderID `xml:"t:FolderId,omitempty"`
+}
+
+// requestFolderID 文件夹标识
+type requestFolderID struct {
+	ID string `xml:"Id,attr"`
+}
+
+// requestItemID 邮件标识
+type requestItemID struct {
+	ID string `xml:"Id,attr"`
+}
+
+// 响应结构（按元素的本地名称匹配，不区分命名空间前缀）
+
+// faultEnvelope SOAP 错误响应
+type faultEnvelope struct {
+	Fault *soapFault `xml:"Body>Fault"`
+}
+
+// soapFault SOAP 错误
+type soapFault struct {
+	FaultCode    string `xml:"faultcode"`
+	FaultString  string `xml:"faultstring"`
+	ResponseCode string `xml:"detail>ResponseCode"`
+}
+
+func (f *soapFault) Error() string {
+	if f.ResponseCode != "" {
+		return fmt.Sprintf("EWS 请求失败: %s (%s)", f.FaultString, f.ResponseCode)
+	}
+	return fmt.Sprintf("EWS 请求失败: %s", f.FaultString)
+}
+
+// responseMessage 响应消息的公共字段
+type responseMessage struct {
+	ResponseClass string `xml:"ResponseClass,attr"` // Success、Warning 或 Error
+	MessageText   string `xml:"MessageText"`
+	ResponseCode  string `xml:"ResponseCode"`
+}
+
+// err 响应消息为 Error 时返回错误
+func (m *responseMessage) err() error {
+	if m.ResponseClass != "Error" {
+		return nil
+	}
+	return &responseError{Code: m.ResponseCode, Message: m.MessageText}
+}
+
+// responseError 操作级错误
+type responseError struct {
+	Code    string
+	Message string
+}
+
+func (e *responseError) Error() string {
+	return fmt.Sprintf("EWS 操作失败: %s (%s)", e.Message, e.Code)
+}
+
+// findItemResponse FindItem 响应
+type findItemResponse struct {
+	Messages []findItemResponseMessage `xml:"Body>FindItemResponse>ResponseMessages>FindItemResponseMessage"`
+}
+
+// findItemResponseMessage FindItem 响应消息
+type findItemResponseMessage struct {
+	responseMessage
+	RootFolder struct {
+		TotalItemsInView int   `xml:"TotalItemsInView,attr"`
+		Items            items `xml:"Items"`
+	} `xml:"RootFolder"`
+}
+
+// getItemResponse GetItem 响应
+type getItemResponse struct {
+	Messages []getItemResponseMessage `xml:"Body>GetItemResponse>ResponseMessages>GetItemResponseMessage"`
+}
+
+// getItemResponseMessage GetItem 响应消息
+type getItemResponseMessage struct {
+	responseMessage
+	Items items `xml:"Items"`
+}
+
+// items 邮件列表（Message、MeetingRequest 等各种邮件类型）
+type items struct {
+	Items []item `xml:",any"`
+}
+
+// item 邮件
+type item struct {
+	ItemID      itemID      `xml:"ItemId"`
+	MimeContent mimeContent `xml:"MimeContent"`
+}
+
+// itemID 邮件标识
+type itemID struct {
+	ID        string `xml:"Id,attr"`
+	ChangeKey string `xml:"ChangeKey,attr"`
+}
+
+// mimeContent 邮件的 MIME 内容（base64 编码）
+type mimeContent struct {
+	CharacterSet string `xml:"CharacterSet,attr"`
+	Content      string `xml:",chardata"`
+}
+
+// subscribeResponse Subscribe 响应
+type subscribeResponse struct {
+	Messages []subscribeResponseMessage `xml:"Body>SubscribeResponse>ResponseMessages>SubscribeResponseMessage"`
+}
+
+// subscribeResponseMessage Subscribe 响应消息
+type subscribeResponseMessage struct {
+	responseMessage
+	SubscriptionID string `xml:"SubscriptionId"`
+	Watermark      string `xml:"Watermark"`
+}
+
+// getEventsResponse GetEvents 响应
+type getEventsResponse struct {
+	Messages []getEventsResponseMessage `xml:"Body>GetEventsResponse>ResponseMessages>GetEventsResponseMessage"`
+}
+
+// getEventsResponseMessage GetEvents 响应消息
+type getEventsResponseMessage struct {
+	responseMessage
+	Notification struct {
+		MoreEvents bool    `xml:"MoreEvents"`
+		Events     []event `xml:",any"`
+	} `xml:"Notification"`
+}
+
+// event 通知事件（NewMailEvent、StatusEvent 等，元素名即事件类型）
+type event struct {
+	XMLName   xml.Name
+	Watermark string `xml:"Watermark"`
+	ItemID    itemID `xml:"ItemId"`
+}
+
+// unsubscribeResponse Unsubscribe 响应
+type unsubscribeResponse struct {
+	Messages []responseMessage `xml:"Body>UnsubscribeResponse>ResponseMessages>UnsubscribeResponseMessage"`
+}
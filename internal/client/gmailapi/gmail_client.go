@@ -0,0 +1,387 @@
+package gmailapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/client/mailparse"
+	"gomailapi2/internal/domain"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-message"
+	"github.com/emersion/go-message/charset"
+	"github.com/emersion/go-message/mail"
+	"github.com/emersion/go-message/textproto"
+)
+
+const (
+	// API 端点（当前授权用户）
+	gmailBaseURL = "https://gmail.googleapis.com/gmail/v1/users/me"
+	// 邮件端点
+	messagesEndpoint = gmailBaseURL + "/messages"
+	// 存档邮件没有对应的标签，通过搜索排除其他系统标签
+	archiveQuery = "-in:inbox -in:spam -in:trash -in:drafts -in:sent -in:chats"
+	// 分页获取邮件详情时的最大并发请求数
+	maxConcurrentFetches = 5
+)
+
+// wellKnownLabels 文件夹角色与 Gmail 系统标签的对应关系
+var wellKnownLabels = map[string]string{
+	domain.FolderRoleInbox:  "INBOX",
+	domain.FolderRoleSent:   "SENT",
+	domain.FolderRoleDrafts: "DRAFT",
+	domain.FolderRoleJunk:   "SPAM",
+	domain.FolderRoleTrash:  "TRASH",
+}
+
+// GetLatestEmail 获取指定文件夹（标签）的最新一封邮件，folder 为空时默认收件箱
+func GetLatestEmail(ctx context.Context, accessToken string, folder string) (*domain.Email, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+
+	page, err := ListEmails(ctx, accessToken, folder, 1, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Emails) == 0 {
+		return nil, nil // 没有找到邮件时返回 nil，不是错误
+	}
+
+	return page.Emails[0], nil
+}
+
+// GetLatestEmailFromJunk 从垃圾邮件获取最新的一封邮件
+func GetLatestEmailFromJunk(ctx context.Context, accessToken string) (*domain.Email, error) {
+	return GetLatestEmail(ctx, accessToken, domain.FolderRoleJunk)
+}
+
+// GetEmailByID 根据邮件 ID（Gmail 邮件 ID）获取邮件详情
+func GetEmailByID(ctx context.Context, accessToken string, emailID string) (*domain.Email, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return nil, errors.New("邮件 ID 不能为空")
+	}
+
+	requestURL := fmt.Sprintf("%s/%s?format=full", messagesEndpoint, url.PathEscape(emailID))
+
+	body, err := doGetRequest(ctx, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var messageData MessageData
+	if err := json.Unmarshal(body, &messageData); err != nil {
+		return nil, fmt.Errorf("解析邮件响应失败: %w", err)
+	}
+
+	return convertToEmail(&messageData), nil
+}
+
+// GetRawEmail 根据邮件 ID 获取邮件的原始 MIME 内容（RFC 822），调用方负责关闭返回的流
+func GetRawEmail(ctx context.Context, accessToken string, emailID string) (io.ReadCloser, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return nil, errors.New("邮件 ID 不能为空")
+	}
+
+	requestURL := fmt.Sprintf("%s/%s?format=raw", messagesEndpoint, url.PathEscape(emailID))
+
+	body, err := doGetRequest(ctx, accessToken, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("获取邮件原始内容失败: %w", err)
+	}
+
+	var messageData MessageData
+	if err := json.Unmarshal(body, &messageData); err != nil {
+		return nil, fmt.Errorf("解析邮件响应失败: %w", err)
+	}
+
+	raw, err := decodeBase64URL(messageData.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("解码邮件原始内容失败: %w", err)
+	}
+
+	return io.NopCloser(bytes.NewReader(raw)), nil
+}
+
+// ListEmails 按时间倒序分页获取指定文件夹（标签）的邮件，folder 为空时默认收件箱
+// folder 可以是文件夹角色（inbox、sent、drafts、junk、trash、archive）或 Gmail 标签 ID；cursor 为上一页返回的 pageToken
+func ListEmails(ctx context.Context, accessToken string, folder string, limit int, cursor string) (*domain.EmailPage, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+
+	query := folderQuery(folder)
+	query.Set("maxResults", strconv.Itoa(limit))
+	if cursor != "" {
+		query.Set("pageToken", cursor)
+	}
+
+	body, err := doGetRequest(ctx, accessToken, messagesEndpoint+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var response ListMessagesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("解析邮件列表响应失败: %w", err)
+	}
+
+	emails, err := getEmailsByRefs(ctx, accessToken, response.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EmailPage{
+		Emails:     emails,
+		NextCursor: response.NextPageToken,
+	}, nil
+}
+
+// folderQuery 根据文件夹构建 messages.list 的查询参数
+func folderQuery(folder string) url.Values {
+	query := url.Values{}
+
+	role := strings.ToLower(folder)
+	switch {
+	case folder == "":
+		query.Set("labelIds", wellKnownLabels[domain.FolderRoleInbox])
+	case role == domain.FolderRoleArchive:
+		query.Set("q", archiveQuery)
+	case wellKnownLabels[role] != "":
+		query.Set("labelIds", wellKnownLabels[role])
+	default:
+		// 其他值视为标签 ID（系统标签如 "STARRED"，或用户标签如 "Label_1"）
+		query.Set("labelIds", folder)
+	}
+
+	// 垃圾邮件和已删除邮件默认不返回
+	if label := query.Get("labelIds"); label == "SPAM" || label == "TRASH" {
+		query.Set("includeSpamTrash", "true")
+	}
+
+	return query
+}
+
+// getEmailsByRefs 并发获取邮件详情，保持 refs 的顺序
+func getEmailsByRefs(ctx context.Context, accessToken string, refs []MessageRef) ([]*domain.Email, error) {
+	emails := make([]*domain.Email, len(refs))
+	errs := make([]error, len(refs))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentFetches)
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, emailID string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			emails[i], errs[i] = GetEmailByID(ctx, accessToken, emailID)
+		}(i, ref.ID)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+// convertToEmail 将 API 响应中的邮件数据转换为 Email 结构体，邮件 ID 使用 Gmail 邮件 ID
+func convertToEmail(messageData *MessageData) *domain.Email {
+	if messageData.Payload == nil {
+		return &domain.Email{ID: messageData.ID, Text: messageData.Snippet}
+	}
+
+	email := mailparse.ParseHeader(partHeader(messageData.Payload))
+	email.ID = messageData.ID
+
+	// Date 头部缺失或无法解析时使用 Gmail 的接收时间
+	if email.Date == "" {
+		if ms, err := strconv.ParseInt(messageData.InternalDate, 10, 64); err == nil {
+			email.Date = time.UnixMilli(ms).Format(time.RFC3339)
+		}
+	}
+
+	walkParts(messageData.Payload, func(part *MessagePart) {
+		if isAttachmentPart(part) {
+			email.Attachments = append(email.Attachments, partToAttachment(part))
+			return
+		}
+
+		switch strings.ToLower(part.MimeType) {
+		case "text/plain":
+			if email.Text == "" {
+				email.Text = readTextPart(email, part)
+			}
+		case "text/html":
+			if email.HTML == "" {
+				email.HTML = readTextPart(email, part)
+			}
+		}
+	})
+
+	return email
+}
+
+// walkParts 深度优先遍历所有非 multipart 分段
+func walkParts(part *MessagePart, fn func(part *MessagePart)) {
+	if strings.HasPrefix(strings.ToLower(part.MimeType), "multipart/") {
+		for i := range part.Parts {
+			walkParts(&part.Parts[i], fn)
+		}
+		return
+	}
+	fn(part)
+}
+
+// partHeader 将分段头部转换为 mail.Header
+func partHeader(part *MessagePart) mail.Header {
+	var h textproto.Header
+	// textproto.Header.Add 会把字段插入到最前面，倒序添加以保持原始顺序
+	for i := len(part.Headers) - 1; i >= 0; i-- {
+		h.Add(part.Headers[i].Name, part.Headers[i].Value)
+	}
+	return mail.Header{Header: message.Header{Header: h}}
+}
+
+// isAttachmentPart 判断分段是否为附件（包括内联图片和转发的邮件）
+func isAttachmentPart(part *MessagePart) bool {
+	if part.Filename != "" {
+		return true
+	}
+	header := partHeader(part)
+	if disposition, _, _ := header.ContentDisposition(); disposition == "attachment" {
+		return true
+	}
+	if strings.EqualFold(part.MimeType, "message/rfc822") {
+		return true
+	}
+	// 没有文件名但通过 Content-ID 引用的非文本内容（如 HTML 正文中的内联图片）
+	return header.Get("Content-ID") != "" && !strings.HasPrefix(strings.ToLower(part.MimeType), "text/")
+}
+
+// partToAttachment 将分段转换为附件元数据，附件 ID 使用 Gmail 的 attachmentId
+func partToAttachment(part *MessagePart) *domain.Attachment {
+	header := partHeader(part)
+	disposition, _, _ := header.ContentDisposition()
+	contentID := header.Get("Content-ID")
+
+	attachment := &domain.Attachment{
+		Name:        part.Filename,
+		ContentType: strings.ToLower(part.MimeType),
+		ContentID:   strings.Trim(contentID, "<>"),
+		IsInline:    disposition == "inline" || (disposition == "" && contentID != ""),
+	}
+	if part.Body != nil {
+		attachment.ID = part.Body.AttachmentID
+		attachment.Size = part.Body.Size
+	}
+
+	return attachment
+}
+
+// readTextPart 解码正文分段（base64url + 字符集），内容过大（只返回 attachmentId）时标记邮件为截断
+func readTextPart(email *domain.Email, part *MessagePart) string {
+	if part.Body == nil {
+		return ""
+	}
+	if part.Body.Data == "" {
+		if part.Body.AttachmentID != "" {
+			email.Truncated = true
+		}
+		return ""
+	}
+
+	data, err := decodeBase64URL(part.Body.Data)
+	if err != nil {
+		log.Printf("解码正文分段 %s 失败: %v", part.PartID, err)
+		return ""
+	}
+
+	header := partHeader(part)
+	_, params, _ := header.ContentType()
+	if cs := strings.ToLower(params["charset"]); cs != "" && cs != "utf-8" && cs != "us-ascii" {
+		reader, err := charset.Reader(cs, bytes.NewReader(data))
+		if err != nil {
+			log.Printf("不支持的字符集 %s，使用原始内容: %v", cs, err)
+			return string(data)
+		}
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			log.Printf("转换字符集 %s 失败，使用原始内容: %v", cs, err)
+			return string(data)
+		}
+		return string(decoded)
+	}
+
+	return string(data)
+}
+
+// decodeBase64URL 解码 base64url 数据（兼容有无填充）
+func decodeBase64URL(data string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(data, "="))
+}
+
+// doGetRequest 发送带访问令牌的 GET 请求并返回响应体
+func doGetRequest(ctx context.Context, accessToken, requestURL string) ([]byte, error) {
+	return doJSONRequest(ctx, accessToken, http.MethodGet, requestURL, nil)
+}
+
+// doJSONRequest 发送带访问令牌的 JSON 请求（payload 为空时不发送请求体），任意 2xx 状态码视为成功
+func doJSONRequest(ctx context.Context, accessToken, method, requestURL string, payload any) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Gmail API 请求失败 (状态码: %d): %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,96 @@
+package gmailapi
+
+// ListMessagesResponse messages.list 响应
+type ListMessagesResponse struct {
+	Messages      []MessageRef `json:"messages"`
+	NextPageToken string       `json:"nextPageToken"` // 下一页令牌，没有更多数据时为空
+}
+
+// MessageRef messages.list / history.list 中返回的邮件引用（只有 ID）
+type MessageRef struct {
+	ID       string   `json:"id"`
+	ThreadID string   `json:"threadId"`
+	LabelIDs []string `json:"labelIds"`
+}
+
+// MessageData 表示从 Gmail API 返回的单个邮件数据（format=full 时包含 payload，format=raw 时包含 raw）
+type MessageData struct {
+	ID           string       `json:"id"`
+	ThreadID     string       `json:"threadId"`
+	LabelIDs     []string     `json:"labelIds"`
+	Snippet      string       `json:"snippet"`
+	HistoryID    string       `json:"historyId"`
+	InternalDate string       `json:"internalDate"` // 毫秒时间戳（字符串）
+	SizeEstimate int64        `json:"sizeEstimate"`
+	Payload      *MessagePart `json:"payload"`
+	Raw          string       `json:"raw"` // base64url 编码的原始 MIME 内容
+}
+
+// MessagePart 邮件 MIME 分段
+type MessagePart struct {
+	PartID   string        `json:"partId"`
+	MimeType string        `json:"mimeType"`
+	Filename string        `json:"filename"`
+	Headers  []Header      `json:"headers"`
+	Body     *PartBody     `json:"body"`
+	Parts    []MessagePart `json:"parts"`
+}
+
+// Header 邮件头
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PartBody 分段内容（内容较大或为附件时只有 attachmentId，没有 data）
+type PartBody struct {
+	AttachmentID string `json:"attachmentId"`
+	Size         int64  `json:"size"`
+	Data         string `json:"data"` // base64url 编码
+}
+
+// WatchRequest users.watch 请求体
+type WatchRequest struct {
+	TopicName           string   `json:"topicName"`
+	LabelIDs            []string `json:"labelIds,omitempty"`
+	LabelFilterBehavior string   `json:"labelFilterBehavior,omitempty"`
+}
+
+// WatchResponse users.watch 响应
+type WatchResponse struct {
+	HistoryID  string `json:"historyId"`  // 开始监听时邮箱的 historyId
+	Expiration string `json:"expiration"` // 监听过期时间（毫秒时间戳）
+}
+
+// ListHistoryResponse history.list 响应
+type ListHistoryResponse struct {
+	History       []HistoryRecord `json:"history"`
+	NextPageToken string          `json:"nextPageToken"`
+	HistoryID     string          `json:"historyId"`
+}
+
+// HistoryRecord 邮箱变更记录
+type HistoryRecord struct {
+	ID            string         `json:"id"`
+	MessagesAdded []MessageAdded `json:"messagesAdded"`
+}
+
+// MessageAdded 新增邮件记录
+type MessageAdded struct {
+	Message MessageRef `json:"message"`
+}
+
+// PushNotification Pub/Sub 推送请求体
+type PushNotification struct {
+	Message struct {
+		Data      string `json:"data"` // base64 编码的 PushData
+		MessageID string `json:"messageId"`
+	} `json:"message"`
+	Subscription string `json:"subscription"`
+}
+
+// PushData Gmail 推送通知内容
+type PushData struct {
+	EmailAddress string `json:"emailAddress"`
+	HistoryID    uint64 `json:"historyId"`
+}
@@ -0,0 +1,136 @@
+package gmailapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"net/http"
+	"net/url"
+)
+
+// Watch 开始监听收件箱变更，Gmail 通过 Pub/Sub 主题推送通知，返回开始监听时的 historyId
+// 同一账户重复调用会覆盖之前的监听；监听最长 7 天后过期
+func Watch(ctx context.Context, accessToken string, topicName string) (*WatchResponse, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+	if topicName == "" {
+		return nil, errors.New("Pub/Sub 主题不能为空")
+	}
+
+	request := WatchRequest{
+		TopicName:           topicName,
+		LabelIDs:            []string{wellKnownLabels[domain.FolderRoleInbox]},
+		LabelFilterBehavior: "include",
+	}
+
+	body, err := doJSONRequest(ctx, accessToken, http.MethodPost, gmailBaseURL+"/watch", request)
+	if err != nil {
+		return nil, fmt.Errorf("创建 Gmail 监听失败: %w", err)
+	}
+
+	var response WatchResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("解析监听响应失败: %w", err)
+	}
+
+	return &response, nil
+}
+
+// StopWatch 停止账户的所有推送通知
+func StopWatch(ctx context.Context, accessToken string) error {
+	if accessToken == "" {
+		return errors.New("访问令牌不能为空")
+	}
+
+	if _, err := doJSONRequest(ctx, accessToken, http.MethodPost, gmailBaseURL+"/stop", nil); err != nil {
+		return fmt.Errorf("停止 Gmail 监听失败: %w", err)
+	}
+
+	return nil
+}
+
+// ListAddedMessageIDs 获取 startHistoryID 之后收件箱中新增的邮件 ID（按新增顺序）
+func ListAddedMessageIDs(ctx context.Context, accessToken string, startHistoryID string) ([]string, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+	if startHistoryID == "" {
+		return nil, errors.New("historyId 不能为空")
+	}
+
+	query := url.Values{}
+	query.Set("startHistoryId", startHistoryID)
+	query.Set("historyTypes", "messageAdded")
+	query.Set("labelId", wellKnownLabels[domain.FolderRoleInbox])
+
+	var messageIDs []string
+	seen := make(map[string]bool)
+	for {
+		body, err := doGetRequest(ctx, accessToken, gmailBaseURL+"/history?"+query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("获取邮箱变更记录失败: %w", err)
+		}
+
+		var response ListHistoryResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("解析变更记录响应失败: %w", err)
+		}
+
+		for _, record := range response.History {
+			for _, added := range record.MessagesAdded {
+				if !seen[added.Message.ID] {
+					seen[added.Message.ID] = true
+					messageIDs = append(messageIDs, added.Message.ID)
+				}
+			}
+		}
+
+		if response.NextPageToken == "" {
+			return messageIDs, nil
+		}
+		query.Set("pageToken", response.NextPageToken)
+	}
+}
+
+// ParsePushNotification 解析 Pub/Sub 推送请求体中的 Gmail 通知内容
+func ParsePushNotification(body []byte) (*PushData, error) {
+	var notification PushNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, fmt.Errorf("解析推送请求失败: %w", err)
+	}
+
+	// Pub/Sub 推送的 data 为标准 base64 编码
+	data, err := base64.StdEncoding.DecodeString(notification.Message.Data)
+	if err != nil {
+		return nil, fmt.Errorf("解码推送数据失败: %w", err)
+	}
+
+	var pushData PushData
+	if err := json.Unmarshal(data, &pushData); err != nil {
+		return nil, fmt.Errorf("解析推送数据失败: %w", err)
+	}
+	if pushData.EmailAddress == "" {
+		return nil, errors.New("推送数据缺少 emailAddress")
+	}
+
+	return &pushData, nil
+}
+
+// GetLatestAddedEmail 获取 startHistoryID 之后收件箱中最新新增的一封邮件，没有新增邮件时返回 nil
+// 推送通知也会在已读、标签变更等非新增邮件的情况下触发，调用方需要忽略 nil 结果继续等待
+func GetLatestAddedEmail(ctx context.Context, accessToken string, startHistoryID string) (*domain.Email, error) {
+	messageIDs, err := ListAddedMessageIDs(ctx, accessToken, startHistoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	return GetEmailByID(ctx, accessToken, messageIDs[len(messageIDs)-1])
+}
@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// UpdateEmailFlags 修改邮件的已读、星标状态（为空的字段保持不变）
+func UpdateEmailFlags(ctx context.Context, accessToken, emailID string, update *domain.EmailFlagsUpdate) error {
+	if accessToken == "" {
+		return errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return errors.New("邮件 ID 不能为空")
+	}
+	if update == nil || (update.IsRead == nil && update.IsFlagged == nil) {
+		return errors.New("没有需要修改的状态")
+	}
+
+	payload := make(map[string]any)
+	if update.IsRead != nil {
+		payload["isRead"] = *update.IsRead
+	}
+	if update.IsFlagged != nil {
+		flagStatus := "notFlagged"
+		if *update.IsFlagged {
+			flagStatus = "flagged"
+		}
+		payload["flag"] = map[string]string{"flagStatus": flagStatus}
+	}
+
+	requestURL := fmt.Sprintf("%s/me/messages/%s", graphBaseURL, url.PathEscape(emailID))
+	if _, err := doJSONRequest(ctx, accessToken, http.MethodPatch, requestURL, payload); err != nil {
+		return fmt.Errorf("修改邮件状态失败: %w", err)
+	}
+
+	return nil
+}
+
+// MoveEmail 将邮件移动到目标文件夹（支持文件夹角色和 Graph 文件夹 ID）
+// 返回移动后的邮件 ID，Graph 中邮件移动后 ID 会改变
+func MoveEmail(ctx context.Context, accessToken, emailID, targetFolder string) (string, error) {
+	if accessToken == "" {
+		return "", errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return "", errors.New("邮件 ID 不能为空")
+	}
+	if targetFolder == "" {
+		return "", errors.New("目标文件夹不能为空")
+	}
+
+	destinationID := targetFolder
+	if name, ok := wellKnownFolderNames[strings.ToLower(targetFolder)]; ok {
+		destinationID = name
+	}
+
+	requestURL := fmt.Sprintf("%s/me/messages/%s/move", graphBaseURL, url.PathEscape(emailID))
+	body, err := doJSONRequest(ctx, accessToken, http.MethodPost, requestURL, map[string]string{"destinationId": destinationID})
+	if err != nil {
+		return "", fmt.Errorf("移动邮件失败: %w", err)
+	}
+
+	var response FindEmailResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("解析移动邮件响应失败: %w", err)
+	}
+	if response.EmailData == nil {
+		return "", errors.New("移动邮件响应为空")
+	}
+
+	return response.ID, nil
+}
+
+// DeleteEmail 删除邮件：默认移动到已删除邮件文件夹，permanent 为 true 时彻底删除
+func DeleteEmail(ctx context.Context, accessToken, emailID string, permanent bool) error {
+	if accessToken == "" {
+		return errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return errors.New("邮件 ID 不能为空")
+	}
+
+	requestURL := fmt.Sprintf("%s/me/messages/%s", graphBaseURL, url.PathEscape(emailID))
+
+	var err error
+	if permanent {
+		_, err = doJSONRequest(ctx, accessToken, http.MethodPost, requestURL+"/permanentDelete", nil)
+	} else {
+		_, err = doJSONRequest(ctx, accessToken, http.MethodDelete, requestURL, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("删除邮件失败: %w", err)
+	}
+
+	return nil
+}
+
+// doJSONRequest 发送带访问令牌的 JSON 请求（payload 为空时不发送请求体），任意 2xx 状态码视为成功
+func doJSONRequest(ctx context.Context, accessToken, method, requestURL string, payload any) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("请求失败 (状态码: %d): %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"net/url"
+	"strings"
+)
+
+// GetAttachment 根据邮件 ID 和附件 ID 下载附件
+func GetAttachment(ctx context.Context, accessToken, emailID, attachmentID string) (*domain.AttachmentContent, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+	if emailID == "" || attachmentID == "" {
+		return nil, errors.New("邮件 ID 和附件 ID 不能为空")
+	}
+
+	attachmentURL := fmt.Sprintf("%s/me/messages/%s/attachments/%s", graphBaseURL, url.PathEscape(emailID), url.PathEscape(attachmentID))
+
+	// 先获取附件元数据（不包含 contentBytes）
+	body, err := doGetRequest(ctx, accessToken, attachmentURL+"?$select="+attachmentSelectFields)
+	if err != nil {
+		return nil, fmt.Errorf("获取附件信息失败: %w", err)
+	}
+
+	var attachmentData AttachmentData
+	if err := json.Unmarshal(body, &attachmentData); err != nil {
+		return nil, fmt.Errorf("解析附件信息失败: %w", err)
+	}
+
+	// 再以流的形式获取附件原始内容
+	content, err := doStreamRequest(ctx, accessToken, attachmentURL+"/$value")
+	if err != nil {
+		return nil, fmt.Errorf("下载附件失败: %w", err)
+	}
+
+	return &domain.AttachmentContent{
+		Attachment: convertToAttachment(attachmentData),
+		Content:    content,
+	}, nil
+}
+
+// convertToAttachment 将 API 响应中的附件数据转换为 Attachment 结构体
+func convertToAttachment(attachmentData AttachmentData) *domain.Attachment {
+	return &domain.Attachment{
+		ID:          attachmentData.ID,
+		Name:        attachmentData.Name,
+		ContentType: attachmentData.ContentType,
+		Size:        attachmentData.Size,
+		ContentID:   strings.Trim(attachmentData.ContentID, "<>"),
+		IsInline:    attachmentData.IsInline,
+	}
+}
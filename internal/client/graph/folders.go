@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// folderSelectFields 文件夹选择字段
+const folderSelectFields = "id,displayName,parentFolderId,childFolderCount,totalItemCount,unreadItemCount"
+
+// ListFolders 获取所有邮件文件夹（递归包含子文件夹）
+func ListFolders(ctx context.Context, accessToken string) ([]*domain.Folder, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+
+	// 先查出知名文件夹的 ID，用于标记文件夹角色
+	roles := getWellKnownFolderRoles(ctx, accessToken)
+
+	var folders []*domain.Folder
+	if err := collectFolders(ctx, accessToken, mailFoldersEndpoint, "", roles, &folders); err != nil {
+		return nil, err
+	}
+
+	return folders, nil
+}
+
+// collectFolders 获取指定端点下的所有文件夹，并递归获取子文件夹
+func collectFolders(ctx context.Context, accessToken, endpoint, parentPath string, roles map[string]string, folders *[]*domain.Folder) error {
+	requestURL := fmt.Sprintf("%s?$top=100&$select=%s", endpoint, folderSelectFields)
+
+	for requestURL != "" {
+		body, err := doGetRequest(ctx, accessToken, requestURL)
+		if err != nil {
+			return fmt.Errorf("获取文件夹列表失败: %w", err)
+		}
+
+		var response MailFolderResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return fmt.Errorf("解析文件夹列表响应失败: %w", err)
+		}
+
+		for _, folderData := range response.Value {
+			folder := &domain.Folder{
+				ID:          folderData.ID,
+				Name:        folderData.DisplayName,
+				Path:        strings.TrimPrefix(parentPath+"/"+folderData.DisplayName, "/"),
+				Role:        roles[folderData.ID],
+				TotalCount:  folderData.TotalItemCount,
+				UnreadCount: folderData.UnreadItemCount,
+			}
+			// 顶层文件夹的父文件夹是不可见的根文件夹，不返回
+			if parentPath != "" {
+				folder.ParentID = folderData.ParentFolderID
+			}
+			*folders = append(*folders, folder)
+
+			if folderData.ChildFolderCount > 0 {
+				childEndpoint := fmt.Sprintf("%s/%s/childFolders", mailFoldersEndpoint, url.PathEscape(folderData.ID))
+				if err := collectFolders(ctx, accessToken, childEndpoint, folder.Path, roles, folders); err != nil {
+					return err
+				}
+			}
+		}
+
+		// 只跟随指向 Graph API 的下一页链接
+		requestURL = ""
+		if strings.HasPrefix(response.NextLink, graphBaseURL) {
+			requestURL = response.NextLink
+		}
+	}
+
+	return nil
+}
+
+// getWellKnownFolderRoles 获取知名文件夹 ID 与角色的对应关系（获取失败的文件夹忽略，例如未启用存档）
+func getWellKnownFolderRoles(ctx context.Context, accessToken string) map[string]string {
+	roles := make(map[string]string, len(wellKnownFolderNames))
+
+	for role, name := range wellKnownFolderNames {
+		requestURL := fmt.Sprintf("%s/%s?$select=id", mailFoldersEndpoint, name)
+		body, err := doGetRequest(ctx, accessToken, requestURL)
+		if err != nil {
+			log.Debug().Err(err).Str("folder", name).Msg("获取知名文件夹失败")
+			continue
+		}
+
+		var folderData MailFolderData
+		if err := json.Unmarshal(body, &folderData); err != nil {
+			continue
+		}
+		roles[folderData.ID] = role
+	}
+
+	return roles
+}
+
+// folderMessagesEndpoint 获取文件夹的邮件端点
+// folder 支持文件夹角色（inbox、junk 等）和 Graph 文件夹 ID，为空时默认收件箱
+func folderMessagesEndpoint(folder string) string {
+	if folder == "" {
+		folder = domain.FolderRoleInbox
+	}
+
+	if name, ok := wellKnownFolderNames[strings.ToLower(folder)]; ok {
+		folder = name
+	}
+
+	return fmt.Sprintf("%s/%s/messages", mailFoldersEndpoint, url.PathEscape(folder))
+}
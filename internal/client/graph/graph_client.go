@@ -10,6 +10,8 @@ import (
 	"gomailapi2/internal/utils"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -18,16 +20,33 @@ const (
 	graphBaseURL = "https://graph.microsoft.com/v1.0"
 	// // 通用消息端点（所有邮件）
 	// messagesEndpoint = graphBaseURL + "/me/messages"
-	// 收件箱端点
-	inboxEndpoint = graphBaseURL + "/me/mailFolders/Inbox/messages"
+	// 文件夹端点
+	mailFoldersEndpoint = graphBaseURL + "/me/mailFolders"
 	// 订阅端点
 	subscriptionsEndpoint = graphBaseURL + "/subscriptions"
 	// 选择字段
-	selectFields = "subject,from,toRecipients,receivedDateTime,bodyPreview,body"
+	selectFields = "subject,from,sender,toRecipients,ccRecipients,bccRecipients,replyTo,receivedDateTime,bodyPreview,body,isRead"
+	// 附件选择字段（不包含 contentBytes，内容通过下载接口获取；contentId 只有 fileAttachment 类型才有，需要类型转换）
+	attachmentSelectFields = "id,name,contentType,size,isInline,microsoft.graph.fileAttachment/contentId"
+	// 展开字段
+	expandFields = "attachments($select=" + attachmentSelectFields + ")"
 	// 订阅过期时间（分钟）
 	SubscriptionTimeoutMinutes = 5
 )
 
+// cursorParams 分页游标中允许携带的查询参数
+var cursorParams = []string{"$skip", "$skiptoken"}
+
+// wellKnownFolderNames 文件夹角色与 Graph 知名文件夹名称的对应关系
+var wellKnownFolderNames = map[string]string{
+	domain.FolderRoleInbox:   "inbox",
+	domain.FolderRoleSent:    "sentitems",
+	domain.FolderRoleDrafts:  "drafts",
+	domain.FolderRoleJunk:    "junkemail",
+	domain.FolderRoleTrash:   "deleteditems",
+	domain.FolderRoleArchive: "archive",
+}
+
 // CreateSubscription 创建 Graph 订阅
 func CreateSubscription(ctx context.Context, accessToken string, notificationURL string) (*SubscriptionResponse, error) {
 	if accessToken == "" {
@@ -121,14 +140,14 @@ func DeleteSubscription(ctx context.Context, accessToken string, subscriptionID
 	return nil
 }
 
-// GetLatestEmail 获取最新的一封邮件
-func GetLatestEmail(ctx context.Context, accessToken string) (*domain.Email, error) {
+// GetLatestEmail 获取指定文件夹的最新一封邮件，folder 为空时默认收件箱
+func GetLatestEmail(ctx context.Context, accessToken string, folder string) (*domain.Email, error) {
 	if accessToken == "" {
 		return nil, errors.New("访问令牌不能为空")
 	}
 
-	// 使用收件箱端点构建请求 URL，确保只获取收件箱的邮件
-	requestURL := buildEmailRequestURL(inboxEndpoint, 1)
+	// 使用文件夹端点构建请求 URL，确保只获取该文件夹的邮件
+	requestURL := buildEmailRequestURL(folderMessagesEndpoint(folder), 1)
 
 	return getEmailFromURL(ctx, accessToken, requestURL)
 }
@@ -143,11 +162,30 @@ func GetEmailByID(ctx context.Context, accessToken string, emailID string) (*dom
 	}
 
 	// 构建请求 URL
-	requestURL := fmt.Sprintf("%s/me/messages/%s?$select=%s", graphBaseURL, emailID, selectFields)
+	requestURL := fmt.Sprintf("%s/me/messages/%s?$select=%s&$expand=%s", graphBaseURL, emailID, selectFields, expandFields)
 
 	return getEmailFromURL(ctx, accessToken, requestURL)
 }
 
+// GetRawEmail 根据邮件 ID 获取邮件的原始 MIME 内容（RFC 822），调用方负责关闭返回的流
+func GetRawEmail(ctx context.Context, accessToken string, emailID string) (io.ReadCloser, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return nil, errors.New("邮件 ID 不能为空")
+	}
+
+	requestURL := fmt.Sprintf("%s/me/messages/%s/$value", graphBaseURL, url.PathEscape(emailID))
+
+	content, err := doStreamRequest(ctx, accessToken, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("获取邮件原始内容失败: %w", err)
+	}
+
+	return content, nil
+}
+
 // GetLatestEmailFromJunk 从垃圾箱获取最新的一封邮件
 func GetLatestEmailFromJunk(ctx context.Context, accessToken string) (*domain.Email, error) {
 	if accessToken == "" {
@@ -155,8 +193,7 @@ func GetLatestEmailFromJunk(ctx context.Context, accessToken string) (*domain.Em
 	}
 
 	// 使用通用方法构建请求 URL
-	folderEndpoint := fmt.Sprintf("%s/me/mailFolders/%s/messages", graphBaseURL, "junkemail")
-	requestURL := buildEmailRequestURL(folderEndpoint, 1)
+	requestURL := buildEmailRequestURL(folderMessagesEndpoint(domain.FolderRoleJunk), 1)
 
 	return getEmailFromURL(ctx, accessToken, requestURL)
 }
@@ -166,13 +203,215 @@ func GetLatestEmailFromJunk(ctx context.Context, accessToken string) (*domain.Em
 // API 默认会按最新的邮件在前的顺序返回，这正是我们需要的
 func buildEmailRequestURL(endpoint string, count int) string {
 	if count == 1 {
-		return fmt.Sprintf("%s?$top=1&$select=%s", endpoint, selectFields)
+		return fmt.Sprintf("%s?$top=1&$select=%s&$expand=%s", endpoint, selectFields, expandFields)
 	}
-	return fmt.Sprintf("%s?$top=%d&$select=%s", endpoint, count, selectFields)
+	return fmt.Sprintf("%s?$top=%d&$select=%s&$expand=%s", endpoint, count, selectFields, expandFields)
 }
 
-// getEmailFromURL 从指定 URL 获取单封邮件的通用方法
-func getEmailFromURL(ctx context.Context, accessToken, requestURL string) (*domain.Email, error) {
+// ListEmails 按时间倒序分页获取指定文件夹的邮件，folder 为空时默认收件箱
+// cursor 为上一页返回的游标，为空表示从最新的邮件开始
+func ListEmails(ctx context.Context, accessToken string, folder string, limit int, cursor string) (*domain.EmailPage, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+
+	requestURL, err := applyCursor(buildEmailRequestURL(folderMessagesEndpoint(folder), limit), cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return getEmailPageFromURL(ctx, accessToken, requestURL, nil)
+}
+
+// SearchEmails 按条件分页搜索邮件
+// 包含发件人、收件人或正文条件时使用 $search（KQL），否则使用 $filter；两者不能同时使用
+// 发件人与 IMAP 的 FROM 一致，匹配发件人的名称或地址，不要求完全相同（$filter 只支持地址完全匹配，因此使用 $search）
+func SearchEmails(ctx context.Context, accessToken string, criteria *domain.SearchCriteria, limit int, cursor string) (*domain.EmailPage, error) {
+	if accessToken == "" {
+		return nil, errors.New("访问令牌不能为空")
+	}
+
+	query, unreadInResults := buildSearchParams(criteria)
+	var keep func(EmailData) bool
+	if unreadInResults {
+		keep = func(emailData EmailData) bool { return !emailData.IsRead }
+	}
+
+	endpoint := folderMessagesEndpoint(criteria.Folder)
+	requestURL, err := applyCursor(buildEmailRequestURL(endpoint, limit)+query, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := getEmailPageFromURL(ctx, accessToken, requestURL, keep)
+	if err != nil || keep == nil {
+		return page, err
+	}
+
+	// 在结果中过滤时继续获取下一页，直到凑满 limit 封邮件或没有更多结果
+	// 每次只请求还缺少的数量，保留的邮件不会超过 limit，游标不会跳过未返回的邮件
+	for len(page.Emails) < limit && page.NextCursor != "" {
+		requestURL, err := applyCursor(buildEmailRequestURL(endpoint, limit-len(page.Emails))+query, page.NextCursor)
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := getEmailPageFromURL(ctx, accessToken, requestURL, keep)
+		if err != nil {
+			return nil, err
+		}
+		page.Emails = append(page.Emails, next.Emails...)
+		page.NextCursor = next.NextCursor
+	}
+
+	return page, nil
+}
+
+// buildSearchParams 构建搜索的查询参数（$search 或 $filter，已 URL 编码），没有条件时返回空字符串
+// 使用 $search 时不支持 isRead 条件，unreadInResults 为 true 表示需要在结果中过滤未读邮件
+func buildSearchParams(criteria *domain.SearchCriteria) (query string, unreadInResults bool) {
+	if criteria.From != "" || criteria.To != "" || criteria.Body != "" {
+		return "&$search=" + escapeQueryValue(buildSearchQuery(criteria)), criteria.UnreadOnly
+	}
+	if filter := buildFilterQuery(criteria); filter != "" {
+		return "&$filter=" + escapeQueryValue(filter), false
+	}
+	return "", false
+}
+
+// buildSearchQuery 构建 $search 使用的 KQL 查询语句
+func buildSearchQuery(criteria *domain.SearchCriteria) string {
+	var terms []string
+
+	addTerm := func(property, value string) {
+		if value == "" {
+			return
+		}
+		// 去掉双引号和反斜杠，避免破坏查询语句结构
+		value = strings.NewReplacer(`"`, "", `\`, "").Replace(value)
+		terms = append(terms, fmt.Sprintf(`%s:\"%s\"`, property, value))
+	}
+
+	addTerm("from", criteria.From)
+	addTerm("to", criteria.To)
+	addTerm("subject", criteria.Subject)
+	addTerm("body", criteria.Body)
+
+	if !criteria.Since.IsZero() {
+		terms = append(terms, "received>="+criteria.Since.UTC().Format(time.DateOnly))
+	}
+	if !criteria.Before.IsZero() {
+		terms = append(terms, "received<"+criteria.Before.UTC().Format(time.DateOnly))
+	}
+
+	return `"` + strings.Join(terms, " AND ") + `"`
+}
+
+// buildFilterQuery 构建 $filter 查询语句（不包含发件人、收件人和正文条件，这些条件使用 $search）
+func buildFilterQuery(criteria *domain.SearchCriteria) string {
+	var clauses []string
+
+	if criteria.Subject != "" {
+		clauses = append(clauses, fmt.Sprintf("contains(subject, '%s')", escapeODataString(criteria.Subject)))
+	}
+	if !criteria.Since.IsZero() {
+		clauses = append(clauses, "receivedDateTime ge "+criteria.Since.UTC().Format(time.RFC3339))
+	}
+	if !criteria.Before.IsZero() {
+		clauses = append(clauses, "receivedDateTime lt "+criteria.Before.UTC().Format(time.RFC3339))
+	}
+	if criteria.UnreadOnly {
+		clauses = append(clauses, "isRead eq false")
+	}
+
+	return strings.Join(clauses, " and ")
+}
+
+// escapeODataString 转义 OData 字符串字面量中的单引号
+func escapeODataString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// escapeQueryValue 对查询参数值进行 URL 编码（空格编码为 %20）
+func escapeQueryValue(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// applyCursor 将分页游标附加到请求 URL（只接受 $skip 和 $skiptoken 参数）
+func applyCursor(requestURL, cursor string) (string, error) {
+	if cursor == "" {
+		return requestURL, nil
+	}
+
+	values, err := url.ParseQuery(cursor)
+	if err != nil {
+		return "", fmt.Errorf("无效的游标: %w", err)
+	}
+
+	pageValues := url.Values{}
+	for _, key := range cursorParams {
+		if value := values.Get(key); value != "" {
+			pageValues.Set(key, value)
+		}
+	}
+	if len(pageValues) == 0 {
+		return "", fmt.Errorf("无效的游标: %s", cursor)
+	}
+
+	return requestURL + "&" + pageValues.Encode(), nil
+}
+
+// extractCursor 从 @odata.nextLink 中提取分页参数作为游标
+// 不直接返回 nextLink，避免调用方传入任意 URL 导致访问令牌被发送到其他地址
+func extractCursor(nextLink string) string {
+	if nextLink == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(nextLink)
+	if err != nil {
+		return ""
+	}
+
+	query := parsed.Query()
+	pageValues := url.Values{}
+	for _, key := range cursorParams {
+		if value := query.Get(key); value != "" {
+			pageValues.Set(key, value)
+		}
+	}
+
+	return pageValues.Encode()
+}
+
+// getEmailPageFromURL 从指定 URL 获取一页邮件的通用方法（keep 为空时保留全部邮件）
+func getEmailPageFromURL(ctx context.Context, accessToken, requestURL string, keep func(EmailData) bool) (*domain.EmailPage, error) {
+	body, err := doGetRequest(ctx, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var response NewEmailResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("解析邮件列表响应失败: %w", err)
+	}
+
+	page := &domain.EmailPage{
+		Emails:     make([]*domain.Email, 0, len(response.Value)),
+		NextCursor: extractCursor(response.NextLink),
+	}
+	for _, emailData := range response.Value {
+		if keep != nil && !keep(emailData) {
+			continue
+		}
+		page.Emails = append(page.Emails, convertToEmail(emailData))
+	}
+
+	return page, nil
+}
+
+// doGetRequest 发送带访问令牌的 GET 请求并返回响应体
+func doGetRequest(ctx context.Context, accessToken, requestURL string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
@@ -197,6 +436,39 @@ func getEmailFromURL(ctx context.Context, accessToken, requestURL string) (*doma
 		return nil, fmt.Errorf("读取响应失败: %w", err)
 	}
 
+	return body, nil
+}
+
+// doStreamRequest 发送带访问令牌的 GET 请求并返回响应体流，调用方负责关闭
+func doStreamRequest(ctx context.Context, accessToken, requestURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("获取内容失败 (状态码: %d): %s", resp.StatusCode, string(body))
+	}
+
+	return resp.Body, nil
+}
+
+// getEmailFromURL 从指定 URL 获取单封邮件的通用方法
+func getEmailFromURL(ctx context.Context, accessToken, requestURL string) (*domain.Email, error) {
+	body, err := doGetRequest(ctx, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// 判断响应类型：检查是否包含 "value" 字段
 	if bytes.Contains(body, []byte(`"value"`)) {
 		// 这是邮件列表响应 (GetLatestEmail)
@@ -227,18 +499,49 @@ func getEmailFromURL(ctx context.Context, accessToken, requestURL string) (*doma
 
 // convertToEmail 将 API 响应中的邮件数据转换为 Email 结构体
 func convertToEmail(emailData EmailData) *domain.Email {
+	toRecipients := convertToAddresses(emailData.ToRecipients)
+
 	var toRecipient *domain.EmailAddress
-	if len(emailData.ToRecipients) > 0 {
-		toRecipient = &emailData.ToRecipients[0].EmailAddress
+	if len(toRecipients) > 0 {
+		toRecipient = toRecipients[0]
+	}
+
+	var sender *domain.EmailAddress
+	if emailData.Sender != nil {
+		sender = utils.CleanEmailAddress(&emailData.Sender.EmailAddress)
+	}
+
+	email := &domain.Email{
+		ID:            emailData.ID,
+		Subject:       emailData.Subject,
+		From:          utils.CleanEmailAddress(&emailData.From.EmailAddress),
+		To:            toRecipient,
+		Date:          emailData.ReceivedDateTime,
+		Text:          emailData.BodyPreview,
+		HTML:          emailData.Body.Content,
+		Sender:        sender,
+		ToRecipients:  toRecipients,
+		CcRecipients:  convertToAddresses(emailData.CcRecipients),
+		BccRecipients: convertToAddresses(emailData.BccRecipients),
+		ReplyTo:       convertToAddresses(emailData.ReplyTo),
+	}
+
+	for _, attachmentData := range emailData.Attachments {
+		email.Attachments = append(email.Attachments, convertToAttachment(attachmentData))
+	}
+
+	return email
+}
+
+// convertToAddresses 将 API 响应中的收件人列表转换为 EmailAddress 列表
+func convertToAddresses(recipients []Recipient) []*domain.EmailAddress {
+	if len(recipients) == 0 {
+		return nil
 	}
 
-	return &domain.Email{
-		ID:      emailData.ID,
-		Subject: emailData.Subject,
-		From:    utils.CleanEmailAddress(&emailData.From.EmailAddress),
-		To:      utils.CleanEmailAddress(toRecipient),
-		Date:    emailData.ReceivedDateTime,
-		Text:    emailData.BodyPreview,
-		HTML:    emailData.Body.Content,
+	addresses := make([]*domain.EmailAddress, 0, len(recipients))
+	for _, recipient := range recipients {
+		addresses = append(addresses, utils.CleanEmailAddress(&recipient.EmailAddress))
 	}
+	return addresses
 }
@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gomailapi2/internal/domain"
+)
+
+// UTC+8 的 2024-05-02 01:00，对应 UTC 的 2024-05-01 17:00
+var (
+	testSince  = time.Date(2024, 5, 2, 1, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	testBefore = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestBuildSearchQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria domain.SearchCriteria
+		want     string
+	}{
+		{
+			name:     "发件人",
+			criteria: domain.SearchCriteria{From: "alice"},
+			want:     `"from:\"alice\""`,
+		},
+		{
+			name:     "多个条件",
+			criteria: domain.SearchCriteria{From: "alice", To: "bob@example.com", Subject: "报告", Body: "invoice"},
+			want:     `"from:\"alice\" AND to:\"bob@example.com\" AND subject:\"报告\" AND body:\"invoice\""`,
+		},
+		{
+			name:     "去掉双引号和反斜杠",
+			criteria: domain.SearchCriteria{Body: `say "hi"\now`},
+			want:     `"body:\"say hinow\""`,
+		},
+		{
+			name:     "日期按 UTC 取日期部分",
+			criteria: domain.SearchCriteria{To: "bob", Since: testSince, Before: testBefore},
+			want:     `"to:\"bob\" AND received>=2024-05-01 AND received<2024-06-01"`,
+		},
+		{
+			name:     "不包含未读条件",
+			criteria: domain.SearchCriteria{Body: "invoice", UnreadOnly: true},
+			want:     `"body:\"invoice\""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildSearchQuery(&tt.criteria); got != tt.want {
+				t.Errorf("buildSearchQuery() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFilterQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria domain.SearchCriteria
+		want     string
+	}{
+		{
+			name:     "没有条件",
+			criteria: domain.SearchCriteria{},
+			want:     "",
+		},
+		{
+			name:     "主题",
+			criteria: domain.SearchCriteria{Subject: "weekly"},
+			want:     "contains(subject, 'weekly')",
+		},
+		{
+			name:     "转义单引号",
+			criteria: domain.SearchCriteria{Subject: "it's 'done'"},
+			want:     "contains(subject, 'it''s ''done''')",
+		},
+		{
+			name:     "时间范围按 UTC",
+			criteria: domain.SearchCriteria{Since: testSince, Before: testBefore},
+			want:     "receivedDateTime ge 2024-05-01T17:00:00Z and receivedDateTime lt 2024-06-01T00:00:00Z",
+		},
+		{
+			name:     "未读",
+			criteria: domain.SearchCriteria{Subject: "weekly", UnreadOnly: true},
+			want:     "contains(subject, 'weekly') and isRead eq false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildFilterQuery(&tt.criteria); got != tt.want {
+				t.Errorf("buildFilterQuery() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSearchParams(t *testing.T) {
+	tests := []struct {
+		name                string
+		criteria            domain.SearchCriteria
+		wantParam           string // $search、$filter 或空
+		wantUnreadInResults bool
+	}{
+		{"没有条件", domain.SearchCriteria{}, "", false},
+		{"只有文件夹", domain.SearchCriteria{Folder: "junk"}, "", false},
+		{"主题使用 $filter", domain.SearchCriteria{Subject: "weekly"}, "$filter", false},
+		{"时间和未读使用 $filter", domain.SearchCriteria{Since: testSince, UnreadOnly: true}, "$filter", false},
+		{"发件人使用 $search", domain.SearchCriteria{From: "alice"}, "$search", false},
+		{"收件人使用 $search", domain.SearchCriteria{To: "bob"}, "$search", false},
+		{"正文使用 $search", domain.SearchCriteria{Body: "invoice", Subject: "weekly"}, "$search", false},
+		{"$search 在结果中过滤未读", domain.SearchCriteria{From: "alice", UnreadOnly: true}, "$search", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, unreadInResults := buildSearchParams(&tt.criteria)
+			if unreadInResults != tt.wantUnreadInResults {
+				t.Errorf("unreadInResults = %v, want %v", unreadInResults, tt.wantUnreadInResults)
+			}
+			if tt.wantParam == "" {
+				if query != "" {
+					t.Errorf("buildSearchParams() = %q, want empty", query)
+				}
+				return
+			}
+
+			values, err := url.ParseQuery(strings.TrimPrefix(query, "&"))
+			if err != nil {
+				t.Fatalf("解析查询参数 %q 失败: %v", query, err)
+			}
+			if len(values) != 1 {
+				t.Fatalf("buildSearchParams() = %q, want 只有 %s", query, tt.wantParam)
+			}
+
+			want := buildFilterQuery(&tt.criteria)
+			if tt.wantParam == "$search" {
+				want = buildSearchQuery(&tt.criteria)
+			}
+			if got := values.Get(tt.wantParam); got != want {
+				t.Errorf("%s = %s, want %s", tt.wantParam, got, want)
+			}
+		})
+	}
+}
+
+func TestEscapeQueryValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"a b", "a%20b"},
+		{"a+b", "a%2Bb"},
+		{"x&$top=999", "x%26%24top%3D999"},
+		{`"from:\"a\""`, "%22from%3A%5C%22a%5C%22%22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escapeQueryValue(tt.value); got != tt.want {
+				t.Errorf("escapeQueryValue(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// fileAttachmentType 文件附件的 OData 类型
+const fileAttachmentType = "#microsoft.graph.fileAttachment"
+
+// SendEmail 发送邮件（保存到已发送邮件），指定 InReplyTo 时通过 /reply 回复原邮件以保持会话关联
+// 注意：Graph 单个请求体不能超过 4 MB，附件较大时会发送失败
+func SendEmail(ctx context.Context, accessToken string, email *domain.OutgoingEmail) error {
+	if accessToken == "" {
+		return errors.New("访问令牌不能为空")
+	}
+	if err := email.Validate(); err != nil {
+		return err
+	}
+
+	message := convertToOutgoingMessage(email)
+
+	var err error
+	if email.InReplyTo != "" {
+		requestURL := fmt.Sprintf("%s/me/messages/%s/reply", graphBaseURL, url.PathEscape(email.InReplyTo))
+		_, err = doJSONRequest(ctx, accessToken, http.MethodPost, requestURL, &ReplyRequest{Message: message})
+	} else {
+		requestURL := graphBaseURL + "/me/sendMail"
+		_, err = doJSONRequest(ctx, accessToken, http.MethodPost, requestURL, &SendMailRequest{Message: message, SaveToSentItems: true})
+	}
+	if err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+
+	return nil
+}
+
+// replyEndpoints 回复方式对应的 Graph 操作
+var replyEndpoints = map[domain.ReplyMode]string{
+	domain.ReplyModeReply:    "reply",
+	domain.ReplyModeReplyAll: "replyAll",
+	domain.ReplyModeForward:  "forward",
+}
+
+// replyModeNames 回复方式的中文名称（用于错误信息）
+var replyModeNames = map[domain.ReplyMode]string{
+	domain.ReplyModeReply:    "回复",
+	domain.ReplyModeReplyAll: "回复全部",
+	domain.ReplyModeForward:  "转发",
+}
+
+// ReplyEmail 回复、回复全部或转发邮件（Graph 自动设置主题、会话关联和引用的原文，转发时自动带上原邮件的附件）
+func ReplyEmail(ctx context.Context, accessToken, emailID string, reply *domain.ReplyEmail) error {
+	if accessToken == "" {
+		return errors.New("访问令牌不能为空")
+	}
+	if emailID == "" {
+		return errors.New("邮件 ID 不能为空")
+	}
+	if err := reply.Validate(); err != nil {
+		return err
+	}
+
+	// 回复内容通过 comment 传递（Graph 将其放在引用的原文之前，按 HTML 处理）
+	request := &ReplyRequest{Comment: reply.HTML}
+	if request.Comment == "" {
+		request.Comment = strings.ReplaceAll(html.EscapeString(reply.Text), "\n", "<br>")
+	}
+
+	// 只有指定了收件人或附件时才需要修改回复邮件，收件人为空时由 Graph 根据原邮件确定
+	if len(reply.To)+len(reply.Cc)+len(reply.Bcc)+len(reply.Attachments) > 0 {
+		request.Message = &OutgoingMessage{
+			ToRecipients:  convertToRecipients(reply.To),
+			CcRecipients:  convertToRecipients(reply.Cc),
+			BccRecipients: convertToRecipients(reply.Bcc),
+			Attachments:   convertToFileAttachments(reply.Attachments),
+		}
+	}
+
+	requestURL := fmt.Sprintf("%s/me/messages/%s/%s", graphBaseURL, url.PathEscape(emailID), replyEndpoints[reply.Mode])
+	if _, err := doJSONRequest(ctx, accessToken, http.MethodPost, requestURL, request); err != nil {
+		return fmt.Errorf("%s邮件失败: %w", replyModeNames[reply.Mode], err)
+	}
+
+	return nil
+}
+
+// convertToOutgoingMessage 将待发送的邮件转换为 Graph 消息（同时有纯文本和 HTML 正文时只发送 HTML）
+func convertToOutgoingMessage(email *domain.OutgoingEmail) *OutgoingMessage {
+	message := &OutgoingMessage{
+		Subject:       email.Subject,
+		ToRecipients:  convertToRecipients(email.To),
+		CcRecipients:  convertToRecipients(email.Cc),
+		BccRecipients: convertToRecipients(email.Bcc),
+		ReplyTo:       convertToRecipients(email.ReplyTo),
+	}
+
+	switch {
+	case email.HTML != "":
+		message.Body = &ItemBody{ContentType: "HTML", Content: email.HTML}
+	case email.Text != "":
+		message.Body = &ItemBody{ContentType: "Text", Content: email.Text}
+	}
+
+	message.Attachments = convertToFileAttachments(email.Attachments)
+
+	return message
+}
+
+// convertToFileAttachments 将待发送的附件转换为 Graph 文件附件
+func convertToFileAttachments(attachments []*domain.OutgoingAttachment) []FileAttachment {
+	result := make([]FileAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, FileAttachment{
+			ODataType:    fileAttachmentType,
+			Name:         attachment.Name,
+			ContentType:  attachment.ContentType,
+			ContentBytes: attachment.Content,
+			ContentID:    strings.Trim(attachment.ContentID, "<>"),
+			IsInline:     attachment.IsInline,
+		})
+	}
+	return result
+}
+
+// convertToRecipients 将邮件地址列表转换为 Graph 收件人列表
+func convertToRecipients(addresses []*domain.EmailAddress) []Recipient {
+	recipients := make([]Recipient, 0, len(addresses))
+	for _, address := range addresses {
+		recipients = append(recipients, Recipient{EmailAddress: *address})
+	}
+	return recipients
+}
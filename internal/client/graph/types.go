@@ -6,7 +6,8 @@ import (
 )
 
 type NewEmailResponse struct {
-	Value []EmailData `json:"value"`
+	Value    []EmailData `json:"value"`
+	NextLink string      `json:"@odata.nextLink"` // 下一页链接，没有更多数据时为空
 }
 
 type FindEmailResponse struct {
@@ -19,19 +20,73 @@ type EmailData struct {
 	Subject          string `json:"subject"`
 	ReceivedDateTime string `json:"receivedDateTime"`
 	BodyPreview      string `json:"bodyPreview"`
+	IsRead           bool   `json:"isRead"`
 	Body             struct {
 		Content string `json:"content"`
 	} `json:"body"`
-	From struct {
-		EmailAddress domain.EmailAddress `json:"emailAddress"`
-	} `json:"from"`
-	ToRecipients []ToRecipient `json:"toRecipients"`
+	From          Recipient        `json:"from"`
+	Sender        *Recipient       `json:"sender"`
+	ToRecipients  []Recipient      `json:"toRecipients"`
+	CcRecipients  []Recipient      `json:"ccRecipients"`
+	BccRecipients []Recipient      `json:"bccRecipients"`
+	ReplyTo       []Recipient      `json:"replyTo"`
+	Attachments   []AttachmentData `json:"attachments"`
 }
 
-type ToRecipient struct {
+// Recipient 表示 Graph API 中的发件人/收件人
+type Recipient struct {
 	EmailAddress domain.EmailAddress `json:"emailAddress"`
 }
 
+// AttachmentData 表示从 Microsoft Graph API 返回的附件元数据
+type AttachmentData struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	IsInline    bool   `json:"isInline"`
+	ContentID   string `json:"contentId"` // 仅 fileAttachment
+}
+
+// SendMailRequest sendMail 请求体
+type SendMailRequest struct {
+	Message         *OutgoingMessage `json:"message"`
+	SaveToSentItems bool             `json:"saveToSentItems"`
+}
+
+// ReplyRequest reply/replyAll/forward 请求体（comment 与 message.body 只能指定一个）
+type ReplyRequest struct {
+	Message *OutgoingMessage `json:"message,omitempty"`
+	Comment string           `json:"comment,omitempty"`
+}
+
+// OutgoingMessage 待发送的邮件（为空的字段不发送，回复时由 Graph 自动填充）
+type OutgoingMessage struct {
+	Subject       string           `json:"subject,omitempty"`
+	Body          *ItemBody        `json:"body,omitempty"`
+	ToRecipients  []Recipient      `json:"toRecipients,omitempty"`
+	CcRecipients  []Recipient      `json:"ccRecipients,omitempty"`
+	BccRecipients []Recipient      `json:"bccRecipients,omitempty"`
+	ReplyTo       []Recipient      `json:"replyTo,omitempty"`
+	Attachments   []FileAttachment `json:"attachments,omitempty"`
+}
+
+// ItemBody 邮件正文
+type ItemBody struct {
+	ContentType string `json:"contentType"` // "Text" 或 "HTML"
+	Content     string `json:"content"`
+}
+
+// FileAttachment 待发送的文件附件（contentBytes 为 base64 编码）
+type FileAttachment struct {
+	ODataType    string `json:"@odata.type"`
+	Name         string `json:"name"`
+	ContentType  string `json:"contentType,omitempty"`
+	ContentBytes []byte `json:"contentBytes"`
+	ContentID    string `json:"contentId,omitempty"`
+	IsInline     bool   `json:"isInline"`
+}
+
 // Subscription Graph 订阅结构体
 type Subscription struct {
 	Resource           string    `json:"resource"`
@@ -45,3 +100,19 @@ type Subscription struct {
 type SubscriptionResponse struct {
 	ID string `json:"id"`
 }
+
+// MailFolderResponse 文件夹列表响应
+type MailFolderResponse struct {
+	Value    []MailFolderData `json:"value"`
+	NextLink string           `json:"@odata.nextLink"`
+}
+
+// MailFolderData 表示从 Microsoft Graph API 返回的单个文件夹数据
+type MailFolderData struct {
+	ID               string `json:"id"`
+	DisplayName      string `json:"displayName"`
+	ParentFolderID   string `json:"parentFolderId"`
+	ChildFolderCount int    `json:"childFolderCount"`
+	TotalItemCount   int    `json:"totalItemCount"`
+	UnreadItemCount  int    `json:"unreadItemCount"`
+}
@@ -0,0 +1,182 @@
+package imap
+
+import (
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+
+	"github.com/emersion/go-imap"
+)
+
+// errUIDPlusRequired 服务器不支持 UIDPLUS 时无法只彻底删除指定邮件（EXPUNGE 会删除文件夹中所有已标记 \Deleted 的邮件）
+var errUIDPlusRequired = errors.New("服务器不支持 UIDPLUS 扩展，无法只删除指定邮件")
+
+// UpdateFlags 修改邮件的已读、星标状态（为空的字段保持不变），folder 为空时在收件箱中查找【单独建立连接】
+func (c *CommonImapClient) UpdateFlags(emailID, folder string, update *domain.EmailFlagsUpdate) error {
+	if update == nil || (update.IsRead == nil && update.IsFlagged == nil) {
+		return errors.New("没有需要修改的状态")
+	}
+
+	if _, err := c.selectFolder(folder); err != nil {
+		return err
+	}
+
+	uid, err := c.searchUIDByMessageID(emailID)
+	if err != nil {
+		return err
+	}
+
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
+
+	if update.IsRead != nil {
+		if err := c.storeFlag(seqSet, imap.SeenFlag, *update.IsRead); err != nil {
+			return err
+		}
+	}
+	if update.IsFlagged != nil {
+		if err := c.storeFlag(seqSet, imap.FlaggedFlag, *update.IsFlagged); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MoveEmail 将邮件移动到目标文件夹（服务器不支持 MOVE 时使用 COPY + STORE + UID EXPUNGE），folder 为空时在收件箱中查找【单独建立连接】
+// 返回移动后的邮件 ID，IMAP 中 Message-ID 移动后不变
+func (c *CommonImapClient) MoveEmail(emailID, folder, targetFolder string) (string, error) {
+	if targetFolder == "" {
+		return "", errors.New("目标文件夹不能为空")
+	}
+
+	if _, err := c.selectFolder(folder); err != nil {
+		return "", err
+	}
+
+	uid, err := c.searchUIDByMessageID(emailID)
+	if err != nil {
+		return "", err
+	}
+
+	targetName, err := c.resolveFolder(targetFolder)
+	if err != nil {
+		return "", err
+	}
+
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
+
+	if err := c.moveMessage(seqSet, targetName); err != nil {
+		return "", err
+	}
+
+	return emailID, nil
+}
+
+// DeleteEmail 删除邮件，folder 为空时在收件箱中查找【单独建立连接】
+// 默认移动到已删除文件夹；permanent 为 true 或邮件已在已删除文件夹中时彻底删除
+func (c *CommonImapClient) DeleteEmail(emailID, folder string, permanent bool) error {
+	mbox, err := c.selectFolder(folder)
+	if err != nil {
+		return err
+	}
+
+	uid, err := c.searchUIDByMessageID(emailID)
+	if err != nil {
+		return err
+	}
+
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
+
+	if !permanent {
+		trashName, err := c.resolveFolder(domain.FolderRoleTrash)
+		if err != nil {
+			return err
+		}
+		if mbox.Name != trashName {
+			return c.moveMessage(seqSet, trashName)
+		}
+	}
+
+	// 先确认可以只删除这封邮件，避免留下 \Deleted 标记
+	if ok, _ := c.client.Support("UIDPLUS"); !ok {
+		return errUIDPlusRequired
+	}
+
+	if err := c.storeFlag(seqSet, imap.DeletedFlag, true); err != nil {
+		return err
+	}
+
+	return c.expunge(seqSet)
+}
+
+// storeFlag 添加或移除邮件标记
+func (c *CommonImapClient) storeFlag(seqSet *imap.SeqSet, flag string, enabled bool) error {
+	var op imap.FlagsOp = imap.RemoveFlags
+	if enabled {
+		op = imap.AddFlags
+	}
+
+	item := imap.FormatFlagsOp(op, true)
+	if err := c.client.UidStore(seqSet, item, []interface{}{flag}, nil); err != nil {
+		return fmt.Errorf("修改邮件标记 %s 失败: %v", flag, err)
+	}
+
+	return nil
+}
+
+// moveMessage 移动邮件，服务器不支持 MOVE 时使用 UID COPY + STORE + UID EXPUNGE
+// go-imap 自带的回退使用不带 UID 的 EXPUNGE，会删除文件夹中其他已标记 \Deleted 的邮件，因此不使用
+func (c *CommonImapClient) moveMessage(seqSet *imap.SeqSet, targetName string) error {
+	if ok, _ := c.client.Support("MOVE"); ok {
+		if err := c.client.UidMove(seqSet, targetName); err != nil {
+			return fmt.Errorf("移动邮件到 %s 失败: %v", targetName, err)
+		}
+		return nil
+	}
+
+	// 复制之前确认可以只删除原邮件，避免留下重复的邮件
+	if ok, _ := c.client.Support("UIDPLUS"); !ok {
+		return errUIDPlusRequired
+	}
+
+	if err := c.client.UidCopy(seqSet, targetName); err != nil {
+		return fmt.Errorf("复制邮件到 %s 失败: %v", targetName, err)
+	}
+	if err := c.storeFlag(seqSet, imap.DeletedFlag, true); err != nil {
+		return err
+	}
+	return c.expunge(seqSet)
+}
+
+// expunge 通过 UID EXPUNGE 只彻底删除指定的邮件（需要 UIDPLUS）
+// 不使用 EXPUNGE，它会删除当前文件夹中所有已标记 \Deleted 的邮件
+func (c *CommonImapClient) expunge(seqSet *imap.SeqSet) error {
+	if ok, _ := c.client.Support("UIDPLUS"); !ok {
+		return errUIDPlusRequired
+	}
+
+	status, err := c.client.Execute(&uidExpunge{seqSet: seqSet}, nil)
+	if err == nil {
+		err = status.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("彻底删除邮件失败: %v", err)
+	}
+	return nil
+}
+
+// uidExpunge UID EXPUNGE 命令（RFC 4315），go-imap v1 未内置
+type uidExpunge struct {
+	seqSet *imap.SeqSet
+}
+
+// Command 实现 imap.Commander 接口
+func (cmd *uidExpunge) Command() *imap.Command {
+	return &imap.Command{
+		Name:      "UID",
+		Arguments: []interface{}{imap.RawString("EXPUNGE"), cmd.seqSet},
+	}
+}
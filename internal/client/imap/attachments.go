@@ -0,0 +1,186 @@
+package imap
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"io"
+	"mime/quotedprintable"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/emersion/go-imap"
+)
+
+// FetchAttachment 根据邮件 ID（Message-ID）和附件 ID（MIME 分段路径）下载附件，folder 为空时在收件箱中查找【单独建立连接】
+func (c *CommonImapClient) FetchAttachment(emailID, folder, attachmentID string) (*domain.AttachmentContent, error) {
+	path, err := parsePartPath(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.selectFolder(folder); err != nil {
+		return nil, err
+	}
+
+	uid, err := c.searchUIDByMessageID(emailID)
+	if err != nil {
+		return nil, err
+	}
+
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
+
+	section := &imap.BodySectionName{
+		BodyPartName: imap.BodyPartName{Path: path},
+		Peek:         true, // 下载附件不改变邮件的已读状态
+	}
+
+	// 同时获取 BODYSTRUCTURE，用于校验附件并确定传输编码
+	items := []imap.FetchItem{imap.FetchBodyStructure, section.FetchItem()}
+
+	messages := make(chan *imap.Message, 1)
+	if err := c.client.UidFetch(seqSet, items, messages); err != nil {
+		return nil, fmt.Errorf("获取附件失败: %v", err)
+	}
+
+	message := <-messages
+	if message == nil || message.BodyStructure == nil {
+		return nil, errors.New("没有收到邮件内容")
+	}
+
+	part := findPart(message.BodyStructure, path)
+	if part == nil || !isAttachmentPart(part) {
+		return nil, fmt.Errorf("未找到 ID 为 %s 的附件", attachmentID)
+	}
+
+	literal := message.GetBody(section)
+	if literal == nil {
+		return nil, errors.New("附件内容为空")
+	}
+
+	return &domain.AttachmentContent{
+		Attachment: partToAttachment(attachmentID, part),
+		Content:    io.NopCloser(decodeTransferEncoding(literal, part.Encoding)),
+	}, nil
+}
+
+// attachmentsFromBodyStructure 从 BODYSTRUCTURE 中提取附件元数据
+func attachmentsFromBodyStructure(bs *imap.BodyStructure) []*domain.Attachment {
+	var attachments []*domain.Attachment
+
+	bs.Walk(func(path []int, part *imap.BodyStructure) bool {
+		if strings.EqualFold(part.MIMEType, "multipart") {
+			return true
+		}
+		if isAttachmentPart(part) {
+			attachments = append(attachments, partToAttachment(formatPartPath(path), part))
+		}
+		return true
+	})
+
+	return attachments
+}
+
+// findPart 根据分段路径查找 BODYSTRUCTURE 中的分段
+func findPart(bs *imap.BodyStructure, path []int) *imap.BodyStructure {
+	var found *imap.BodyStructure
+
+	bs.Walk(func(partPath []int, part *imap.BodyStructure) bool {
+		if found != nil {
+			return false
+		}
+		if slices.Equal(partPath, path) && !strings.EqualFold(part.MIMEType, "multipart") {
+			found = part
+			return false
+		}
+		return true
+	})
+
+	return found
+}
+
+// isAttachmentPart 判断分段是否为附件（包括内联图片和转发的邮件）
+func isAttachmentPart(part *imap.BodyStructure) bool {
+	if strings.EqualFold(part.Disposition, "attachment") {
+		return true
+	}
+	if filename, _ := part.Filename(); filename != "" {
+		return true
+	}
+	if strings.EqualFold(part.MIMEType, "message") && strings.EqualFold(part.MIMESubType, "rfc822") {
+		return true
+	}
+	// 没有文件名但通过 Content-ID 引用的非文本内容（如 HTML 正文中的内联图片）
+	return part.Id != "" && !strings.EqualFold(part.MIMEType, "text")
+}
+
+// partToAttachment 将 BODYSTRUCTURE 分段转换为附件元数据
+func partToAttachment(id string, part *imap.BodyStructure) *domain.Attachment {
+	name, _ := part.Filename()
+	if name == "" && part.Envelope != nil && part.Envelope.Subject != "" {
+		name = part.Envelope.Subject + ".eml"
+	}
+
+	return &domain.Attachment{
+		ID:          id,
+		Name:        name,
+		ContentType: strings.ToLower(part.MIMEType + "/" + part.MIMESubType),
+		Size:        decodedSize(part),
+		ContentID:   strings.Trim(part.Id, "<>"),
+		IsInline:    strings.EqualFold(part.Disposition, "inline") || (part.Disposition == "" && part.Id != ""),
+	}
+}
+
+// decodedSize 根据传输编码估算分段解码后的大小
+func decodedSize(part *imap.BodyStructure) int64 {
+	size := int64(part.Size)
+	if strings.EqualFold(part.Encoding, "base64") {
+		// base64 每行 76 个字符（加 CRLF 共 78 字节）对应 57 字节原始数据
+		return size * 57 / 78
+	}
+	return size
+}
+
+// decodeTransferEncoding 按 Content-Transfer-Encoding 解码分段内容
+func decodeTransferEncoding(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(encoding) {
+	case "base64":
+		// base64 解码器会自动忽略换行符
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// parsePartPath 解析分段路径，如 "1.2" -> [1, 2]
+func parsePartPath(id string) ([]int, error) {
+	if id == "" {
+		return nil, errors.New("附件 ID 不能为空")
+	}
+
+	fields := strings.Split(id, ".")
+	path := make([]int, 0, len(fields))
+	for _, field := range fields {
+		num, err := strconv.Atoi(field)
+		if err != nil || num < 1 {
+			return nil, fmt.Errorf("无效的附件 ID: %s", id)
+		}
+		path = append(path, num)
+	}
+
+	return path, nil
+}
+
+// formatPartPath 格式化分段路径，如 [1, 2] -> "1.2"
+func formatPartPath(path []int) string {
+	fields := make([]string, 0, len(path))
+	for _, num := range path {
+		fields = append(fields, strconv.Itoa(num))
+	}
+	return strings.Join(fields, ".")
+}
@@ -1,20 +1,22 @@
 package imap
 
 import (
+	"cmp"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"gomailapi2/internal/domain"
-	"gomailapi2/internal/utils"
 	"io"
 	"log"
-	"strings"
+	"net"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/emersion/go-imap"
 	"github.com/emersion/go-imap/client"
-	"github.com/emersion/go-message/mail"
 )
 
 // CommonImapClient 通用 IMAP 客户端
@@ -73,17 +75,18 @@ func (c *CommonImapClient) Connect() error {
 		return fmt.Errorf("连接 IMAP 服务器失败: %v", err)
 	}
 
-	// 获取认证客户端
-	saslClient, err := c.authProvider.GetSASLClient()
-	if err != nil {
-		imapClient.Logout()
-		return fmt.Errorf("获取认证客户端失败: %v", err)
+	if !c.config.UseTLS && c.config.StartTLS {
+		host, _, _ := net.SplitHostPort(c.config.Host)
+		if err := imapClient.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			imapClient.Logout()
+			return fmt.Errorf("STARTTLS 失败: %v", err)
+		}
 	}
 
 	// 进行认证
-	if err := imapClient.Authenticate(saslClient); err != nil {
+	if err := c.authenticate(imapClient); err != nil {
 		imapClient.Logout()
-		return fmt.Errorf("认证失败: %v", err)
+		return err
 	}
 
 	// 选择邮箱（通常是 INBOX）
@@ -98,6 +101,29 @@ func (c *CommonImapClient) Connect() error {
 	return nil
 }
 
+// authenticate 进行认证：认证提供者支持 LOGIN 命令时使用用户名和密码登录，否则使用 SASL 认证
+func (c *CommonImapClient) authenticate(imapClient *client.Client) error {
+	if loginProvider, ok := c.authProvider.(LoginAuthProvider); ok {
+		if username, password, ok := loginProvider.GetLoginCredentials(); ok {
+			if err := imapClient.Login(username, password); err != nil {
+				return fmt.Errorf("认证失败: %v", err)
+			}
+			return nil
+		}
+	}
+
+	// 获取认证客户端
+	saslClient, err := c.authProvider.GetSASLClient()
+	if err != nil {
+		return fmt.Errorf("获取认证客户端失败: %v", err)
+	}
+
+	if err := imapClient.Authenticate(saslClient); err != nil {
+		return fmt.Errorf("认证失败: %v", err)
+	}
+	return nil
+}
+
 // Disconnect 断开连接
 func (c *CommonImapClient) Disconnect() error {
 	c.mu.Lock()
@@ -126,55 +152,67 @@ func (c *CommonImapClient) Disconnect() error {
 	return nil
 }
 
-// FetchLatestEmail 获取最新邮件【单独建立连接】
-func (c *CommonImapClient) FetchLatestEmail() (*domain.Email, error) {
-	return c.fetchLatestEmailFromFolder("inbox")
+// FetchLatestEmail 获取指定文件夹的最新邮件，folder 为空时默认收件箱【单独建立连接】
+func (c *CommonImapClient) FetchLatestEmail(folder string) (*domain.Email, error) {
+	return c.fetchLatestEmailFromFolder(folder)
 }
 
 func (c *CommonImapClient) FetchLatestJunkEmail() (*domain.Email, error) {
-	return c.fetchLatestEmailFromFolder("junk")
+	return c.fetchLatestEmailFromFolder(domain.FolderRoleJunk)
 }
 
-// FetchEmailByID 根据邮件 ID 获取邮件详情【单独建立连接】
-func (c *CommonImapClient) FetchEmailByID(emailID string) (*domain.Email, error) {
-	// 检查是否已连接，如果没有连接则自动连接
-	if !c.isConnected {
-		if err := c.Connect(); err != nil {
-			return nil, fmt.Errorf("建立连接失败: %v", err)
-		}
+// FetchEmailByID 根据邮件 ID 获取邮件详情，folder 为空时在收件箱中查找【单独建立连接】
+func (c *CommonImapClient) FetchEmailByID(emailID string, folder string) (*domain.Email, error) {
+	// 选择要搜索的文件夹
+	if _, err := c.selectFolder(folder); err != nil {
+		return nil, err
 	}
 
-	// 选择收件箱（默认在收件箱中搜索）
-	_, err := c.client.Select("INBOX", false)
+	// 根据 Message-ID 搜索邮件
+	uid, err := c.searchUIDByMessageID(emailID)
 	if err != nil {
-		return nil, fmt.Errorf("选择邮箱失败: %v", err)
+		return nil, err
 	}
 
-	// 根据 Message-ID 搜索邮件
-	criteria := imap.NewSearchCriteria()
-	criteria.Header.Set("Message-ID", emailID)
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
 
-	uids, err := c.client.Search(criteria)
+	emails, err := c.fetchEmails(seqSet, true, false)
 	if err != nil {
-		return nil, fmt.Errorf("搜索邮件失败: %v", err)
+		return nil, err
 	}
 
-	if len(uids) == 0 {
-		return nil, fmt.Errorf("未找到 ID 为 %s 的邮件", emailID)
+	email, ok := emails[uid]
+	if !ok {
+		return nil, errors.New("没有收到邮件内容")
 	}
 
-	// 获取第一个匹配的邮件
-	seqSet := new(imap.SeqSet)
-	seqSet.AddNum(uids[0])
+	return email, nil
+}
 
-	section := &imap.BodySectionName{
-		Partial: []int{0, 50000}, // 获取前 50kb
+// FetchRawEmail 根据邮件 ID 获取邮件的原始 MIME 内容（RFC 822），folder 为空时在收件箱中查找【单独建立连接】
+func (c *CommonImapClient) FetchRawEmail(emailID string, folder string) (io.ReadCloser, error) {
+	// 选择要搜索的文件夹
+	if _, err := c.selectFolder(folder); err != nil {
+		return nil, err
 	}
 
+	// 根据 Message-ID 搜索邮件
+	uid, err := c.searchUIDByMessageID(emailID)
+	if err != nil {
+		return nil, err
+	}
+
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
+
+	// 获取完整邮件，不截断
+	section := &imap.BodySectionName{Peek: true}
+
 	items := []imap.FetchItem{section.FetchItem()}
 
 	messages := make(chan *imap.Message, 1)
-	if err := c.client.Fetch(seqSet, items, messages); err != nil {
+	if err := c.client.UidFetch(seqSet, items, messages); err != nil {
 		return nil, fmt.Errorf("获取邮件失败: %v", err)
 	}
 
@@ -183,7 +221,24 @@ func (c *CommonImapClient) FetchEmailByID(emailID string) (*domain.Email, error)
 		return nil, errors.New("没有收到邮件内容")
 	}
 
-	return parseMail(message, section)
+	literal := message.GetBody(section)
+	if literal == nil {
+		return nil, errors.New("邮件 Literal 为空")
+	}
+
+	return io.NopCloser(literal), nil
+}
+
+// ListEmails 按时间倒序分页获取指定文件夹的邮件，folder 为空时默认收件箱【单独建立连接】
+// cursor 为上一页返回的游标（UID），为空表示从最新的邮件开始
+func (c *CommonImapClient) ListEmails(folder string, limit int, cursor string) (*domain.EmailPage, error) {
+	return c.listEmailsFromFolder(folder, imap.NewSearchCriteria(), limit, cursor)
+}
+
+// SearchEmails 按条件分页搜索邮件【单独建立连接】
+// 注意：IMAP 的 SINCE/BEFORE 只精确到日期
+func (c *CommonImapClient) SearchEmails(criteria *domain.SearchCriteria, limit int, cursor string) (*domain.EmailPage, error) {
+	return c.listEmailsFromFolder(criteria.Folder, buildSearchCriteria(criteria), limit, cursor)
 }
 
 // SubscribeNewEmails 订阅新邮件通知
@@ -390,8 +445,8 @@ func (c *CommonImapClient) listenForEmails(ctx context.Context, updates <-chan c
 	}
 }
 
-// fetchLatestEmailFromFolder 从指定文件夹获取最新邮件
-func (c *CommonImapClient) fetchLatestEmailFromFolder(folderName string) (*domain.Email, error) {
+// selectFolder 确保已连接并选择指定文件夹，folder 为空时默认收件箱
+func (c *CommonImapClient) selectFolder(folder string) (*imap.MailboxStatus, error) {
 	// 检查是否已连接，如果没有连接则自动连接
 	if !c.isConnected {
 		if err := c.Connect(); err != nil {
@@ -399,131 +454,154 @@ func (c *CommonImapClient) fetchLatestEmailFromFolder(folderName string) (*domai
 		}
 	}
 
-	// 选择指定文件夹
+	folderName, err := c.resolveFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+
 	mbox, err := c.client.Select(folderName, false)
 	if err != nil {
 		return nil, fmt.Errorf("选择文件夹 %s 失败: %v", folderName, err)
 	}
 
+	return mbox, nil
+}
+
+// searchUIDByMessageID 在当前选择的文件夹中根据 Message-ID 查找邮件 UID
+func (c *CommonImapClient) searchUIDByMessageID(emailID string) (uint32, error) {
+	criteria := imap.NewSearchCriteria()
+	criteria.Header.Set("Message-ID", emailID)
+
+	uids, err := c.client.UidSearch(criteria)
+	if err != nil {
+		return 0, fmt.Errorf("搜索邮件失败: %v", err)
+	}
+
+	if len(uids) == 0 {
+		return 0, fmt.Errorf("未找到 ID 为 %s 的邮件", emailID)
+	}
+
+	return uids[0], nil
+}
+
+// fetchLatestEmailFromFolder 从指定文件夹获取最新邮件
+func (c *CommonImapClient) fetchLatestEmailFromFolder(folder string) (*domain.Email, error) {
+	// 选择指定文件夹
+	mbox, err := c.selectFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+
 	if mbox.Messages == 0 {
-		log.Printf("文件夹 %s 中没有邮件", folderName)
+		log.Printf("文件夹 %s 中没有邮件", mbox.Name)
 		return nil, nil
 	}
 
 	return c.fetchEmailBySequenceNumber(mbox.Messages)
 }
 
-// fetchEmailBySequenceNumber 通过序列号获取邮件
-func (c *CommonImapClient) fetchEmailBySequenceNumber(sequenceNumber uint32) (*domain.Email, error) {
-	// 获取指定序列号的邮件
-	seqSet := new(imap.SeqSet)
-	seqSet.AddNum(sequenceNumber)
-
-	section := &imap.BodySectionName{
-		Partial: []int{0, 50000}, // 获取前 50kb
+// listEmailsFromFolder 在指定文件夹中按条件分页获取邮件（UID 越大越新，按 UID 倒序返回）
+func (c *CommonImapClient) listEmailsFromFolder(folder string, criteria *imap.SearchCriteria, limit int, cursor string) (*domain.EmailPage, error) {
+	// 选择指定文件夹
+	if _, err := c.selectFolder(folder); err != nil {
+		return nil, err
 	}
 
-	items := []imap.FetchItem{section.FetchItem()}
+	page := &domain.EmailPage{Emails: []*domain.Email{}}
 
-	messages := make(chan *imap.Message, 1)
-	// if err := c.client.UidFetch(seqSet, items, messages); err != nil {
-	if err := c.client.Fetch(seqSet, items, messages); err != nil {
-		return nil, fmt.Errorf("获取邮件失败: %v", err)
+	// 游标即上一页最后一封邮件的 UID，本页只取比它更旧的邮件
+	if cursor != "" {
+		cursorUID, err := strconv.ParseUint(cursor, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("无效的游标: %s", cursor)
+		}
+		if cursorUID <= 1 {
+			return page, nil
+		}
+		uidRange := new(imap.SeqSet)
+		uidRange.AddRange(1, uint32(cursorUID-1))
+		criteria.Uid = uidRange
 	}
 
-	message := <-messages
-	if message == nil {
-		return nil, errors.New("没有收到邮件内容")
+	uids, err := c.client.UidSearch(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("搜索邮件失败: %v", err)
 	}
 
-	return parseMail(message, section)
-}
+	if len(uids) == 0 {
+		return page, nil
+	}
 
-// parseMail 解析邮件内容（通用函数）
-func parseMail(message *imap.Message, section *imap.BodySectionName) (*domain.Email, error) {
-	literal := message.GetBody(section)
+	// 按 UID 倒序排列，最新的邮件在前
+	slices.SortFunc(uids, func(a, b uint32) int { return cmp.Compare(b, a) })
 
-	if literal == nil {
-		return nil, errors.New("邮件 Literal 为空")
+	if len(uids) > limit {
+		uids = uids[:limit]
+		page.NextCursor = strconv.FormatUint(uint64(uids[len(uids)-1]), 10)
 	}
 
-	// 创建邮件阅读器
-	mr, err := mail.CreateReader(literal)
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uids...)
+
+	// 列表查询不改变邮件的已读状态
+	emails, err := c.fetchEmails(seqSet, true, true)
 	if err != nil {
-		return nil, errors.New("创建邮件阅读器失败")
+		return nil, err
 	}
 
-	// 解析邮件基本信息
-	header := mr.Header
+	// 服务器返回顺序不确定，按 UID 重新排序（解析失败的邮件已跳过）
+	for _, uid := range uids {
+		if email, ok := emails[uid]; ok {
+			page.Emails = append(page.Emails, email)
+		}
+	}
 
-	var date string
-	var from, to *domain.EmailAddress
-	var subject string
+	return page, nil
+}
 
-	// 记录 ID
-	messageID := header.Get("Message-ID")
-	// 去掉两端的尖括号
-	messageID = strings.Trim(messageID, "<>")
-	log.Printf("邮件 ID: %s", messageID)
+// buildSearchCriteria 将业务搜索条件转换为 IMAP SEARCH 条件
+func buildSearchCriteria(criteria *domain.SearchCriteria) *imap.SearchCriteria {
+	imapCriteria := imap.NewSearchCriteria()
 
-	if d, err := header.Date(); err != nil {
-		return nil, errors.New("获取邮件日期失败")
-	} else {
-		date = d.Format(time.RFC3339)
+	if criteria.From != "" {
+		imapCriteria.Header.Add("From", criteria.From)
 	}
-
-	if f, err := header.AddressList("From"); err != nil {
-		return nil, errors.New("获取邮件发件人失败")
-	} else {
-		from = utils.CleanEmailAddress(f[0])
+	if criteria.To != "" {
+		imapCriteria.Header.Add("To", criteria.To)
 	}
-
-	if t, err := header.AddressList("To"); err != nil {
-		return nil, errors.New("获取邮件收件人失败")
-	} else {
-		to = utils.CleanEmailAddress(t[0])
+	if criteria.Subject != "" {
+		imapCriteria.Header.Add("Subject", criteria.Subject)
 	}
-
-	if s, err := header.Subject(); err != nil {
-		return nil, errors.New("获取邮件主题失败")
-	} else {
-		subject = s
+	if criteria.Body != "" {
+		imapCriteria.Body = append(imapCriteria.Body, criteria.Body)
 	}
-
-	email := &domain.Email{
-		ID:      messageID,
-		Date:    date,
-		From:    from,
-		To:      to,
-		Subject: subject,
+	if !criteria.Since.IsZero() {
+		imapCriteria.Since = criteria.Since
+	}
+	if !criteria.Before.IsZero() {
+		imapCriteria.Before = criteria.Before
+	}
+	if criteria.UnreadOnly {
+		imapCriteria.WithoutFlags = append(imapCriteria.WithoutFlags, imap.SeenFlag)
 	}
 
-	// 处理邮件正文
-	for {
-		p, err := mr.NextPart()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return email, errors.New("读取邮件正文失败")
-		}
+	return imapCriteria
+}
+
+// fetchEmailBySequenceNumber 通过序列号获取邮件
+func (c *CommonImapClient) fetchEmailBySequenceNumber(sequenceNumber uint32) (*domain.Email, error) {
+	// 获取指定序列号的邮件
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(sequenceNumber)
 
-		switch h := p.Header.(type) {
-		case *mail.InlineHeader:
-			// 这是邮件的正文部分
-			body, _ := io.ReadAll(p.Body)
-			contentType, _, _ := h.ContentType()
+	emails, err := c.fetchEmails(seqSet, false, false)
+	if err != nil {
+		return nil, err
+	}
 
-			if contentType == "text/plain" {
-				email.Text = string(body)
-			} else if contentType == "text/html" {
-				email.HTML = string(body)
-			}
-		case *mail.AttachmentHeader:
-			// 这是附件部分
-			filename, _ := h.Filename()
-			log.Printf("发现附件: %s", filename)
-		}
+	for _, email := range emails {
+		return email, nil
 	}
 
-	return email, nil
+	return nil, errors.New("没有收到邮件内容")
 }
@@ -0,0 +1,119 @@
+package imap
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"gomailapi2/internal/domain"
+
+	"github.com/emersion/go-imap"
+)
+
+func TestBuildSearchCriteria(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		criteria domain.SearchCriteria
+		want     func(c *imap.SearchCriteria)
+	}{
+		{
+			name:     "没有条件",
+			criteria: domain.SearchCriteria{Folder: "junk"},
+			want:     func(*imap.SearchCriteria) {},
+		},
+		{
+			name:     "发件人、收件人和主题使用邮件头",
+			criteria: domain.SearchCriteria{From: "alice", To: "bob@example.com", Subject: "报告"},
+			want: func(c *imap.SearchCriteria) {
+				c.Header.Add("From", "alice")
+				c.Header.Add("To", "bob@example.com")
+				c.Header.Add("Subject", "报告")
+			},
+		},
+		{
+			// 引号和换行由 go-imap 编码为带引号的字符串或字面量，这里原样保留
+			name:     "特殊字符原样保留",
+			criteria: domain.SearchCriteria{Subject: `say "hi"\now`, Body: "line1\r\nline2"},
+			want: func(c *imap.SearchCriteria) {
+				c.Header.Add("Subject", `say "hi"\now`)
+				c.Body = []string{"line1\r\nline2"}
+			},
+		},
+		{
+			name:     "时间范围",
+			criteria: domain.SearchCriteria{Since: since, Before: before},
+			want: func(c *imap.SearchCriteria) {
+				c.Since = since
+				c.Before = before
+			},
+		},
+		{
+			name:     "未读",
+			criteria: domain.SearchCriteria{UnreadOnly: true},
+			want: func(c *imap.SearchCriteria) {
+				c.WithoutFlags = []string{imap.SeenFlag}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := imap.NewSearchCriteria()
+			tt.want(want)
+
+			if got := buildSearchCriteria(&tt.criteria); !reflect.DeepEqual(got, want) {
+				t.Errorf("buildSearchCriteria() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestBuildSearchCriteriaCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria domain.SearchCriteria
+		want     string
+	}{
+		{
+			// SINCE 和 BEFORE 只比较日期（RFC 3501），不包含时间
+			name: "时间范围和未读",
+			criteria: domain.SearchCriteria{
+				Since:      time.Date(2024, 5, 1, 15, 30, 0, 0, time.UTC),
+				Before:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				UnreadOnly: true,
+			},
+			want: "A1 SEARCH SINCE \"1-May-2024\" BEFORE \"1-Jun-2024\" UNSEEN\r\n",
+		},
+		{
+			name:     "引号和反斜杠被转义",
+			criteria: domain.SearchCriteria{Subject: `say "hi"\now`},
+			want:     `A1 SEARCH SUBJECT "say \"hi\"\\now"` + "\r\n",
+		},
+		{
+			// FROM 匹配发件人邮件头的任意部分（名称或地址）
+			name:     "发件人",
+			criteria: domain.SearchCriteria{From: "alice"},
+			want:     "A1 SEARCH FROM \"alice\"\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := imap.NewWriter(&buf)
+			cmd := &imap.Command{Tag: "A1", Name: "SEARCH", Arguments: buildSearchCriteria(&tt.criteria).Format()}
+			if err := cmd.WriteTo(w); err != nil {
+				t.Fatalf("WriteTo 失败: %v", err)
+			}
+			w.Flush()
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("SEARCH 命令 = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
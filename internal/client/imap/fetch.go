@@ -0,0 +1,249 @@
+package imap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/client/mailparse"
+	"gomailapi2/internal/domain"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-message"
+	"github.com/emersion/go-message/charset"
+	"github.com/emersion/go-message/mail"
+	"github.com/emersion/go-message/textproto"
+)
+
+// DefaultMaxPartSize 单个正文分段默认的最大获取字节数（1 MB）
+const DefaultMaxPartSize = 1 << 20
+
+// maxPartSize 单个正文分段的最大获取字节数，超出部分不获取并在邮件中标记为截断
+var maxPartSize int64 = DefaultMaxPartSize
+
+// SetMaxPartSize 设置单个正文分段的最大获取字节数，size <= 0 时使用默认值（应在启动时调用）
+func SetMaxPartSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxPartSize
+	}
+	maxPartSize = size
+}
+
+// headerSection 邮件头分段（头部单独获取，不改变邮件的已读状态）
+var headerSection = &imap.BodySectionName{
+	BodyPartName: imap.BodyPartName{Specifier: imap.HeaderSpecifier},
+	Peek:         true,
+}
+
+// textPart 正文文本分段
+type textPart struct {
+	path      []int
+	structure *imap.BodyStructure
+}
+
+// textFetchGroup 正文分段结构相同、可以合并获取的一组邮件
+type textFetchGroup struct {
+	uids     *imap.SeqSet
+	sections []*imap.BodySectionName
+}
+
+// fetchEmails 分两步获取邮件详情，返回的邮件按 UID 索引：
+//  1. 获取 UID、BODYSTRUCTURE 和邮件头
+//  2. 根据 BODYSTRUCTURE 只获取 text/plain 和 text/html 正文分段（每个分段最多 maxPartSize 字节）
+//
+// byUID 为 false 时 seqSet 为序列号；peek 为 true 时不改变邮件的已读状态
+func (c *CommonImapClient) fetchEmails(seqSet *imap.SeqSet, byUID bool, peek bool) (map[uint32]*domain.Email, error) {
+	items := []imap.FetchItem{imap.FetchUid, imap.FetchBodyStructure, headerSection.FetchItem()}
+
+	messages, err := c.fetchMessages(seqSet, byUID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make(map[uint32]*domain.Email, len(messages))
+	partsByUID := make(map[uint32][]*textPart, len(messages))
+	groups := make(map[string]*textFetchGroup)
+
+	for _, message := range messages {
+		email, err := parseMailHeader(message.GetBody(headerSection))
+		if err != nil {
+			log.Printf("解析邮件头失败（UID: %d）: %v", message.Uid, err)
+			continue
+		}
+		emails[message.Uid] = email
+
+		if message.BodyStructure == nil {
+			continue
+		}
+
+		// 附件元数据（BODYSTRUCTURE 描述完整邮件结构，不需要获取附件内容）
+		email.Attachments = attachmentsFromBodyStructure(message.BodyStructure)
+
+		parts := findTextParts(message.BodyStructure)
+		if len(parts) == 0 {
+			continue
+		}
+		partsByUID[message.Uid] = parts
+
+		// 正文分段路径相同的邮件合并为一次 FETCH
+		key := textPartsKey(parts)
+		group, ok := groups[key]
+		if !ok {
+			group = &textFetchGroup{uids: new(imap.SeqSet)}
+			for _, part := range parts {
+				group.sections = append(group.sections, &imap.BodySectionName{
+					BodyPartName: imap.BodyPartName{Path: part.path},
+					Peek:         peek,
+					Partial:      []int{0, int(maxPartSize)},
+				})
+			}
+			groups[key] = group
+		}
+		group.uids.AddNum(message.Uid)
+	}
+
+	for _, group := range groups {
+		items := make([]imap.FetchItem, 0, len(group.sections)+1)
+		items = append(items, imap.FetchUid)
+		for _, section := range group.sections {
+			items = append(items, section.FetchItem())
+		}
+
+		messages, err := c.fetchMessages(group.uids, true, items)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, message := range messages {
+			email, ok := emails[message.Uid]
+			if !ok {
+				continue
+			}
+			for i, part := range partsByUID[message.Uid] {
+				readTextPart(email, part, message.GetBody(group.sections[i]))
+			}
+		}
+	}
+
+	return emails, nil
+}
+
+// fetchMessages 执行 FETCH（byUID 为 true 时执行 UID FETCH）并收集所有返回的邮件
+func (c *CommonImapClient) fetchMessages(seqSet *imap.SeqSet, byUID bool, items []imap.FetchItem) ([]*imap.Message, error) {
+	// Fetch 会在完成后关闭通道，边接收边读取，避免通道容量不足时阻塞
+	messagesChan := make(chan *imap.Message, 10)
+	done := make(chan error, 1)
+	go func() {
+		if byUID {
+			done <- c.client.UidFetch(seqSet, items, messagesChan)
+		} else {
+			done <- c.client.Fetch(seqSet, items, messagesChan)
+		}
+	}()
+
+	var messages []*imap.Message
+	for message := range messagesChan {
+		messages = append(messages, message)
+	}
+
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("获取邮件失败: %v", err)
+	}
+
+	return messages, nil
+}
+
+// findTextParts 从 BODYSTRUCTURE 中找出第一个 text/plain 和第一个 text/html 正文分段（跳过附件和转发的邮件）
+func findTextParts(bs *imap.BodyStructure) []*textPart {
+	var plain, html *textPart
+
+	bs.Walk(func(path []int, part *imap.BodyStructure) bool {
+		if strings.EqualFold(part.MIMEType, "multipart") {
+			return true
+		}
+		if !strings.EqualFold(part.MIMEType, "text") || isAttachmentPart(part) {
+			return true
+		}
+
+		switch strings.ToLower(part.MIMESubType) {
+		case "plain":
+			if plain == nil {
+				plain = &textPart{path: path, structure: part}
+			}
+		case "html":
+			if html == nil {
+				html = &textPart{path: path, structure: part}
+			}
+		}
+		return true
+	})
+
+	var parts []*textPart
+	for _, part := range []*textPart{plain, html} {
+		if part != nil {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// textPartsKey 生成正文分段的分组键，如 "plain:1.1|html:1.2"
+func textPartsKey(parts []*textPart) string {
+	keys := make([]string, 0, len(parts))
+	for _, part := range parts {
+		keys = append(keys, strings.ToLower(part.structure.MIMESubType)+":"+formatPartPath(part.path))
+	}
+	return strings.Join(keys, "|")
+}
+
+// readTextPart 解码正文分段（传输编码 + 字符集）并写入邮件，分段超过 maxPartSize 时标记为截断
+func readTextPart(email *domain.Email, part *textPart, literal imap.Literal) {
+	if literal == nil {
+		log.Printf("没有收到正文分段 %s", formatPartPath(part.path))
+		return
+	}
+
+	truncated := int64(part.structure.Size) > maxPartSize
+
+	reader := decodeTransferEncoding(literal, part.structure.Encoding)
+	if cs := strings.ToLower(part.structure.Params["charset"]); cs != "" && cs != "utf-8" && cs != "us-ascii" {
+		if decoded, err := charset.Reader(cs, reader); err != nil {
+			log.Printf("不支持的字符集 %s，使用原始内容: %v", cs, err)
+		} else {
+			reader = decoded
+		}
+	}
+
+	body, err := io.ReadAll(reader)
+	// 截断的分段末尾可能是不完整的编码数据，忽略解码错误并保留已解码的内容
+	if err != nil && !truncated {
+		log.Printf("解码正文分段 %s 失败: %v", formatPartPath(part.path), err)
+	}
+
+	switch strings.ToLower(part.structure.MIMESubType) {
+	case "plain":
+		email.Text = string(body)
+	case "html":
+		email.HTML = string(body)
+	}
+
+	if truncated {
+		email.Truncated = true
+	}
+}
+
+// parseMailHeader 解析邮件头（缺失或格式错误的头部不影响解析，对应字段留空）
+func parseMailHeader(literal imap.Literal) (*domain.Email, error) {
+	if literal == nil {
+		return nil, errors.New("邮件头 Literal 为空")
+	}
+
+	h, err := textproto.ReadHeader(bufio.NewReader(literal))
+	if err != nil {
+		return nil, fmt.Errorf("读取邮件头失败: %v", err)
+	}
+
+	return mailparse.ParseHeader(mail.Header{Header: message.Header{Header: h}}), nil
+}
@@ -0,0 +1,143 @@
+package imap
+
+import (
+	"fmt"
+	"gomailapi2/internal/domain"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/emersion/go-imap"
+)
+
+// specialUseRoles SPECIAL-USE 属性（RFC 6154）与文件夹角色的对应关系
+var specialUseRoles = map[string]string{
+	imap.SentAttr:    domain.FolderRoleSent,
+	imap.DraftsAttr:  domain.FolderRoleDrafts,
+	imap.JunkAttr:    domain.FolderRoleJunk,
+	imap.TrashAttr:   domain.FolderRoleTrash,
+	imap.ArchiveAttr: domain.FolderRoleArchive,
+}
+
+// ListFolders 获取所有文件夹（包含子文件夹）【单独建立连接】
+func (c *CommonImapClient) ListFolders() ([]*domain.Folder, error) {
+	// 检查是否已连接，如果没有连接则自动连接
+	if !c.isConnected {
+		if err := c.Connect(); err != nil {
+			return nil, fmt.Errorf("建立连接失败: %v", err)
+		}
+	}
+
+	mailboxes, err := c.listMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make([]*domain.Folder, 0, len(mailboxes))
+	for _, mailbox := range mailboxes {
+		folder := mailboxToFolder(mailbox)
+
+		// 不可选择的文件夹（仅作为层级容器）无法获取邮件数量
+		if !slices.Contains(mailbox.Attributes, imap.NoSelectAttr) {
+			status, err := c.client.Status(mailbox.Name, []imap.StatusItem{imap.StatusMessages, imap.StatusUnseen})
+			if err != nil {
+				log.Printf("获取文件夹 %s 状态失败: %v", mailbox.Name, err)
+			} else {
+				folder.TotalCount = int(status.Messages)
+				folder.UnreadCount = int(status.Unseen)
+			}
+		}
+
+		folders = append(folders, folder)
+	}
+
+	return folders, nil
+}
+
+// listMailboxes 通过 LIST 命令获取所有邮箱
+func (c *CommonImapClient) listMailboxes() ([]*imap.MailboxInfo, error) {
+	mailboxChan := make(chan *imap.MailboxInfo, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.client.List("", "*", mailboxChan)
+	}()
+
+	var mailboxes []*imap.MailboxInfo
+	for mailbox := range mailboxChan {
+		mailboxes = append(mailboxes, mailbox)
+	}
+
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("获取文件夹列表失败: %v", err)
+	}
+
+	return mailboxes, nil
+}
+
+// resolveFolder 将 folder 参数解析为 IMAP 邮箱名称
+// 支持文件夹角色（inbox、junk 等）和邮箱完整名称，为空时默认收件箱
+func (c *CommonImapClient) resolveFolder(folder string) (string, error) {
+	if folder == "" || strings.EqualFold(folder, domain.FolderRoleInbox) {
+		return "INBOX", nil
+	}
+
+	role := strings.ToLower(folder)
+	if !domain.IsFolderRole(role) {
+		// 不是文件夹角色，按邮箱名称处理
+		return folder, nil
+	}
+
+	// 优先使用服务器通过 SPECIAL-USE 属性标识的文件夹
+	mailboxes, err := c.listMailboxes()
+	if err != nil {
+		return "", err
+	}
+	for _, mailbox := range mailboxes {
+		if mailboxRole(mailbox) == role {
+			return mailbox.Name, nil
+		}
+	}
+
+	// 服务器不支持 SPECIAL-USE 时使用提供商的默认名称
+	if name, ok := c.config.FolderNames[role]; ok {
+		return name, nil
+	}
+
+	return folder, nil
+}
+
+// mailboxToFolder 将 IMAP 邮箱信息转换为统一的文件夹模型
+func mailboxToFolder(mailbox *imap.MailboxInfo) *domain.Folder {
+	folder := &domain.Folder{
+		ID:   mailbox.Name,
+		Name: mailbox.Name,
+		Path: mailbox.Name,
+		Role: mailboxRole(mailbox),
+	}
+
+	if mailbox.Delimiter != "" {
+		segments := strings.Split(mailbox.Name, mailbox.Delimiter)
+		folder.Name = segments[len(segments)-1]
+		folder.Path = strings.Join(segments, "/")
+		if len(segments) > 1 {
+			folder.ParentID = strings.Join(segments[:len(segments)-1], mailbox.Delimiter)
+		}
+	}
+
+	return folder
+}
+
+// mailboxRole 根据邮箱名称和 SPECIAL-USE 属性获取文件夹角色
+func mailboxRole(mailbox *imap.MailboxInfo) string {
+	if strings.EqualFold(mailbox.Name, "INBOX") {
+		return domain.FolderRoleInbox
+	}
+
+	for _, attr := range mailbox.Attributes {
+		if role, ok := specialUseRoles[attr]; ok {
+			return role
+		}
+	}
+
+	return ""
+}
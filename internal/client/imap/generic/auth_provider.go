@@ -0,0 +1,36 @@
+package generic
+
+import (
+	"github.com/emersion/go-sasl"
+)
+
+// GenericAuthProvider 实现 AuthProvider 接口（用户名/密码认证）
+// LOGIN 方式下 IMAP 使用 LOGIN 命令、SMTP 使用 AUTH LOGIN、POP3 使用 USER/PASS 命令；PLAIN 方式下都使用 SASL PLAIN
+type GenericAuthProvider struct {
+	username string
+	password string
+	usePlain bool
+}
+
+func NewGenericAuthProvider(credentials *Credentials) *GenericAuthProvider {
+	return &GenericAuthProvider{
+		username: credentials.LoginName(),
+		password: credentials.Password,
+		usePlain: credentials.UsePlain,
+	}
+}
+
+func (a *GenericAuthProvider) GetSASLClient() (sasl.Client, error) {
+	if a.usePlain {
+		return sasl.NewPlainClient("", a.username, a.password), nil
+	}
+	return sasl.NewLoginClient(a.username, a.password), nil
+}
+
+// GetLoginCredentials 实现 imap.LoginAuthProvider 和 pop3.LoginAuthProvider 接口，PLAIN 方式下不使用 LOGIN 命令
+func (a *GenericAuthProvider) GetLoginCredentials() (string, string, bool) {
+	if a.usePlain {
+		return "", "", false
+	}
+	return a.username, a.password, true
+}
@@ -0,0 +1,84 @@
+package generic
+
+import (
+	"gomailapi2/internal/client/imap"
+	"gomailapi2/internal/domain"
+	"net"
+	"strconv"
+)
+
+// 默认端口
+const (
+	defaultImapTLSPort      = 993
+	defaultImapStartTLSPort = 143
+	defaultSmtpTLSPort      = 465
+	defaultSmtpStartTLSPort = 587
+	defaultPop3TLSPort      = 995
+	defaultPop3StartTLSPort = 110
+)
+
+// Credentials 通用邮件服务器的登录信息
+type Credentials struct {
+	Email    string // 邮箱地址（同时作为发件人地址）
+	Username string // 登录用户名，为空时使用 Email
+	Password string // 密码或应用专用密码
+	UsePlain bool   // 是否使用 SASL PLAIN 认证（默认使用 LOGIN）
+}
+
+// LoginName 获取登录用户名
+func (c *Credentials) LoginName() string {
+	if c.Username != "" {
+		return c.Username
+	}
+	return c.Email
+}
+
+// Server 邮件服务器地址
+type Server struct {
+	Host     string
+	Port     int  // 为 0 时按加密方式使用默认端口
+	StartTLS bool // 是否使用 STARTTLS（默认直接使用 TLS 连接）
+}
+
+// address 拼接服务器地址，端口为空时使用默认端口
+func (s *Server) address(tlsPort, startTLSPort int) string {
+	port := s.Port
+	if port == 0 {
+		port = tlsPort
+		if s.StartTLS {
+			port = startTLSPort
+		}
+	}
+	return net.JoinHostPort(s.Host, strconv.Itoa(port))
+}
+
+type GenericImapClient struct {
+	*imap.CommonImapClient
+}
+
+func NewGenericImapClient(credentials *Credentials, server *Server) *GenericImapClient {
+	if server == nil {
+		server = &Server{}
+	}
+
+	// 创建 IMAP 配置（特殊文件夹优先使用 SPECIAL-USE 属性，以下为常见的默认名称）
+	imapConfig := &imap.ImapConfig{
+		Host:     server.address(defaultImapTLSPort, defaultImapStartTLSPort),
+		Username: credentials.LoginName(),
+		UseTLS:   !server.StartTLS,
+		StartTLS: server.StartTLS,
+		FolderNames: map[string]string{
+			domain.FolderRoleSent:    "Sent",
+			domain.FolderRoleDrafts:  "Drafts",
+			domain.FolderRoleJunk:    "Junk",
+			domain.FolderRoleTrash:   "Trash",
+			domain.FolderRoleArchive: "Archive",
+		},
+	}
+
+	authProvider := NewGenericAuthProvider(credentials)
+
+	return &GenericImapClient{
+		CommonImapClient: imap.NewCommonImapClient(imapConfig, authProvider),
+	}
+}
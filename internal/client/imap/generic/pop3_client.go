@@ -0,0 +1,23 @@
+package generic
+
+import (
+	"gomailapi2/internal/client/pop3"
+)
+
+// NewGenericPop3Client 创建通用 POP3 客户端（LOGIN 方式使用 USER/PASS 命令，PLAIN 方式使用 SASL PLAIN）
+func NewGenericPop3Client(credentials *Credentials, server *Server) *pop3.CommonPop3Client {
+	if server == nil {
+		server = &Server{}
+	}
+
+	pop3Config := &pop3.Pop3Config{
+		Host:     server.address(defaultPop3TLSPort, defaultPop3StartTLSPort),
+		Username: credentials.LoginName(),
+		UseTLS:   !server.StartTLS,
+		StartTLS: server.StartTLS,
+	}
+
+	authProvider := NewGenericAuthProvider(credentials)
+
+	return pop3.NewCommonPop3Client(pop3Config, authProvider)
+}
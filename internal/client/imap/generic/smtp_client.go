@@ -0,0 +1,21 @@
+package generic
+
+import (
+	"gomailapi2/internal/client/smtp"
+)
+
+// NewGenericSmtpClient 创建通用 SMTP 客户端，server 为空时发送会返回未配置 SMTP 服务器的错误
+func NewGenericSmtpClient(credentials *Credentials, server *Server) *smtp.CommonSmtpClient {
+	smtpConfig := &smtp.SmtpConfig{
+		Username: credentials.LoginName(),
+		From:     credentials.Email,
+	}
+	if server != nil {
+		smtpConfig.Host = server.address(defaultSmtpTLSPort, defaultSmtpStartTLSPort)
+		smtpConfig.UseTLS = !server.StartTLS
+	}
+
+	authProvider := NewGenericAuthProvider(credentials)
+
+	return smtp.NewCommonSmtpClient(smtpConfig, authProvider)
+}
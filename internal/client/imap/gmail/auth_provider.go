@@ -0,0 +1,24 @@
+package gmail
+
+import (
+	"gomailapi2/internal/utils"
+
+	"github.com/emersion/go-sasl"
+)
+
+// GmailAuthProvider 实现 AuthProvider 接口（Gmail 同样使用 XOAUTH2 认证）
+type GmailAuthProvider struct {
+	email       string
+	accessToken string
+}
+
+func NewGmailAuthProvider(email string, accessToken string) *GmailAuthProvider {
+	return &GmailAuthProvider{
+		email:       email,
+		accessToken: accessToken,
+	}
+}
+
+func (a *GmailAuthProvider) GetSASLClient() (sasl.Client, error) {
+	return utils.NewXOAuth2Client(a.email, a.accessToken), nil
+}
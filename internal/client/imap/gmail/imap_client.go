@@ -0,0 +1,32 @@
+package gmail
+
+import (
+	"gomailapi2/internal/client/imap"
+	"gomailapi2/internal/domain"
+)
+
+type GmailImapClient struct {
+	*imap.CommonImapClient
+}
+
+func NewGmailImapClient(email string, accessToken string) *GmailImapClient {
+	// 创建 IMAP 配置（Gmail 特有，Gmail 支持 SPECIAL-USE，默认名称仅作为兜底）
+	imapConfig := &imap.ImapConfig{
+		Host:     "imap.gmail.com:993",
+		Username: email,
+		UseTLS:   true,
+		FolderNames: map[string]string{
+			domain.FolderRoleSent:    "[Gmail]/Sent Mail",
+			domain.FolderRoleDrafts:  "[Gmail]/Drafts",
+			domain.FolderRoleJunk:    "[Gmail]/Spam",
+			domain.FolderRoleTrash:   "[Gmail]/Trash",
+			domain.FolderRoleArchive: "[Gmail]/All Mail",
+		},
+	}
+
+	authProvider := NewGmailAuthProvider(email, accessToken)
+
+	return &GmailImapClient{
+		CommonImapClient: imap.NewCommonImapClient(imapConfig, authProvider),
+	}
+}
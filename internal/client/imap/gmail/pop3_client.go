@@ -0,0 +1,20 @@
+package gmail
+
+import (
+	"gomailapi2/internal/client/pop3"
+)
+
+// NewGmailPop3Client 创建 Gmail POP3 客户端（995 端口 TLS + XOAUTH2，与 IMAP 使用相同的访问令牌）
+// 需要在 Gmail 设置中开启 POP 下载
+func NewGmailPop3Client(email string, accessToken string) *pop3.CommonPop3Client {
+	// 创建 POP3 配置（Gmail 特有）
+	pop3Config := &pop3.Pop3Config{
+		Host:     "pop.gmail.com:995",
+		Username: email,
+		UseTLS:   true,
+	}
+
+	authProvider := NewGmailAuthProvider(email, accessToken)
+
+	return pop3.NewCommonPop3Client(pop3Config, authProvider)
+}
@@ -0,0 +1,19 @@
+package gmail
+
+import (
+	"gomailapi2/internal/client/smtp"
+)
+
+// NewGmailSmtpClient 创建 Gmail SMTP 客户端（STARTTLS + XOAUTH2，与 IMAP 使用相同的访问令牌）
+func NewGmailSmtpClient(email string, accessToken string) *smtp.CommonSmtpClient {
+	// 创建 SMTP 配置（Gmail 特有）
+	smtpConfig := &smtp.SmtpConfig{
+		Host:     "smtp.gmail.com:587",
+		Username: email,
+		UseTLS:   false,
+	}
+
+	authProvider := NewGmailAuthProvider(email, accessToken)
+
+	return smtp.NewCommonSmtpClient(smtpConfig, authProvider)
+}
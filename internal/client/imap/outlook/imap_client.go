@@ -2,6 +2,7 @@ package outlook
 
 import (
 	"gomailapi2/internal/client/imap"
+	"gomailapi2/internal/domain"
 )
 
 type Credentials struct {
@@ -21,6 +22,13 @@ func NewOutlookImapClient(credentials *Credentials, accessToken string) *Outlook
 		Host:     "outlook.office365.com:993",
 		Username: credentials.Email,
 		UseTLS:   true,
+		FolderNames: map[string]string{
+			domain.FolderRoleSent:    "Sent",
+			domain.FolderRoleDrafts:  "Drafts",
+			domain.FolderRoleJunk:    "Junk",
+			domain.FolderRoleTrash:   "Deleted",
+			domain.FolderRoleArchive: "Archive",
+		},
 	}
 
 	authProvider := NewOutlookAuthProvider(credentials.Email, accessToken)
@@ -0,0 +1,19 @@
+package outlook
+
+import (
+	"gomailapi2/internal/client/pop3"
+)
+
+// NewOutlookPop3Client 创建微软 POP3 客户端（995 端口 TLS + XOAUTH2，与 IMAP 使用相同的访问令牌）
+func NewOutlookPop3Client(credentials *Credentials, accessToken string) *pop3.CommonPop3Client {
+	// 创建 POP3 配置（微软特有）
+	pop3Config := &pop3.Pop3Config{
+		Host:     "outlook.office365.com:995",
+		Username: credentials.Email,
+		UseTLS:   true,
+	}
+
+	authProvider := NewOutlookAuthProvider(credentials.Email, accessToken)
+
+	return pop3.NewCommonPop3Client(pop3Config, authProvider)
+}
@@ -0,0 +1,19 @@
+package outlook
+
+import (
+	"gomailapi2/internal/client/smtp"
+)
+
+// NewOutlookSmtpClient 创建微软 SMTP 客户端（STARTTLS + XOAUTH2，与 IMAP 使用相同的访问令牌）
+func NewOutlookSmtpClient(credentials *Credentials, accessToken string) *smtp.CommonSmtpClient {
+	// 创建 SMTP 配置（微软特有）
+	smtpConfig := &smtp.SmtpConfig{
+		Host:     "smtp.office365.com:587",
+		Username: credentials.Email,
+		UseTLS:   false,
+	}
+
+	authProvider := NewOutlookAuthProvider(credentials.Email, accessToken)
+
+	return smtp.NewCommonSmtpClient(smtpConfig, authProvider)
+}
@@ -9,6 +9,9 @@ type ImapConfig struct {
 	Host     string // IMAP 服务器地址，如 "outlook.office365.com:993"
 	Username string // 用户名/邮箱地址
 	UseTLS   bool   // 是否使用 TLS
+	StartTLS bool   // 明文连接后是否通过 STARTTLS 升级（UseTLS 为 false 时有效）
+	// 特殊文件夹的默认名称（key 为 domain.FolderRole*），服务器不支持 SPECIAL-USE 属性时使用
+	FolderNames map[string]string
 }
 
 // AuthProvider 认证提供者接口
@@ -16,3 +19,9 @@ type AuthProvider interface {
 	// GetSASLClient 获取 SASL 认证客户端
 	GetSASLClient() (sasl.Client, error)
 }
+
+// LoginAuthProvider 使用 IMAP LOGIN 命令认证的提供者（可选接口，实现时优先于 SASL 认证）
+type LoginAuthProvider interface {
+	// GetLoginCredentials 获取 LOGIN 命令使用的用户名和密码，ok 为 false 时使用 SASL 认证
+	GetLoginCredentials() (username, password string, ok bool)
+}
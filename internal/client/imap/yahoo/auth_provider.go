@@ -0,0 +1,24 @@
+package yahoo
+
+import (
+	"gomailapi2/internal/utils"
+
+	"github.com/emersion/go-sasl"
+)
+
+// YahooAuthProvider 实现 AuthProvider 接口（Yahoo 和 AOL 同样使用 XOAUTH2 认证）
+type YahooAuthProvider struct {
+	email       string
+	accessToken string
+}
+
+func NewYahooAuthProvider(email string, accessToken string) *YahooAuthProvider {
+	return &YahooAuthProvider{
+		email:       email,
+		accessToken: accessToken,
+	}
+}
+
+func (a *YahooAuthProvider) GetSASLClient() (sasl.Client, error) {
+	return utils.NewXOAuth2Client(a.email, a.accessToken), nil
+}
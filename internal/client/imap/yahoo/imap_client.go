@@ -0,0 +1,59 @@
+package yahoo
+
+import (
+	"gomailapi2/internal/client/imap"
+	"gomailapi2/internal/domain"
+)
+
+// Server Yahoo 和 AOL 的服务器配置（两者使用同一套邮件系统，只有服务器地址和部分文件夹名称不同）
+type Server struct {
+	ImapHost    string
+	SmtpHost    string
+	FolderNames map[string]string
+}
+
+// YahooServer Yahoo 邮箱服务器
+var YahooServer = &Server{
+	ImapHost: "imap.mail.yahoo.com:993",
+	SmtpHost: "smtp.mail.yahoo.com:465",
+	FolderNames: map[string]string{
+		domain.FolderRoleSent:    "Sent",
+		domain.FolderRoleDrafts:  "Draft",
+		domain.FolderRoleJunk:    "Bulk",
+		domain.FolderRoleTrash:   "Trash",
+		domain.FolderRoleArchive: "Archive",
+	},
+}
+
+// AOLServer AOL 邮箱服务器
+var AOLServer = &Server{
+	ImapHost: "imap.aol.com:993",
+	SmtpHost: "smtp.aol.com:465",
+	FolderNames: map[string]string{
+		domain.FolderRoleSent:    "Sent",
+		domain.FolderRoleDrafts:  "Drafts",
+		domain.FolderRoleJunk:    "Bulk Mail",
+		domain.FolderRoleTrash:   "Trash",
+		domain.FolderRoleArchive: "Archive",
+	},
+}
+
+type YahooImapClient struct {
+	*imap.CommonImapClient
+}
+
+func NewYahooImapClient(server *Server, email string, accessToken string) *YahooImapClient {
+	// 创建 IMAP 配置（Yahoo/AOL 支持 SPECIAL-USE，默认名称仅作为兜底）
+	imapConfig := &imap.ImapConfig{
+		Host:        server.ImapHost,
+		Username:    email,
+		UseTLS:      true,
+		FolderNames: server.FolderNames,
+	}
+
+	authProvider := NewYahooAuthProvider(email, accessToken)
+
+	return &YahooImapClient{
+		CommonImapClient: imap.NewCommonImapClient(imapConfig, authProvider),
+	}
+}
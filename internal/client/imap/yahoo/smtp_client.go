@@ -0,0 +1,18 @@
+package yahoo
+
+import (
+	"gomailapi2/internal/client/smtp"
+)
+
+// NewYahooSmtpClient 创建 Yahoo/AOL SMTP 客户端（465 端口 TLS + XOAUTH2，与 IMAP 使用相同的访问令牌）
+func NewYahooSmtpClient(server *Server, email string, accessToken string) *smtp.CommonSmtpClient {
+	smtpConfig := &smtp.SmtpConfig{
+		Host:     server.SmtpHost,
+		Username: email,
+		UseTLS:   true,
+	}
+
+	authProvider := NewYahooAuthProvider(email, accessToken)
+
+	return smtp.NewCommonSmtpClient(smtpConfig, authProvider)
+}
@@ -0,0 +1,285 @@
+package jmap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// wellKnownPath 只填写服务器域名时使用的会话发现地址（RFC 8620 第 2.2 节）
+const wellKnownPath = "/.well-known/jmap"
+
+// allowedHosts 会话中的 apiUrl、eventSourceUrl 可以使用的其他主机（小写，不含端口）
+var allowedHosts map[string]bool
+
+// SetAllowedHosts 设置会话中的 apiUrl、eventSourceUrl 除会话地址的主机外可以使用的主机（应在启动时调用）
+func SetAllowedHosts(hosts []string) {
+	allowed := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		if host = strings.TrimSpace(host); host != "" {
+			allowed[strings.ToLower(host)] = true
+		}
+	}
+	allowedHosts = allowed
+}
+
+// Credentials JMAP 登录信息
+type Credentials struct {
+	Username  string // 用户名（Basic 认证使用）
+	Password  string // 密码、应用专用密码或 API 令牌
+	UseBearer bool   // 是否将 Password 作为 Bearer 令牌（如 Fastmail API token），否则使用 Basic 认证
+}
+
+// Client JMAP 客户端（会话信息在首次请求时获取并缓存）
+type Client struct {
+	sessionURL  string
+	credentials *Credentials
+
+	mu      sync.Mutex
+	session *Session
+}
+
+// NewClient 创建 JMAP 客户端，sessionURL 为会话地址（如 https://api.fastmail.com/jmap/session），
+// 只填写域名时使用 /.well-known/jmap 发现会话
+func NewClient(sessionURL string, credentials *Credentials) *Client {
+	return &Client{
+		sessionURL:  sessionURL,
+		credentials: credentials,
+	}
+}
+
+// getSession 获取会话信息（缓存）
+func (c *Client) getSession(ctx context.Context) (*Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session != nil {
+		return c.session, nil
+	}
+
+	sessionURL, err := normalizeSessionURL(c.sessionURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, finalURL, err := c.send(ctx, http.MethodGet, sessionURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取 JMAP 会话失败: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(body, &session); err != nil {
+		return nil, fmt.Errorf("解析 JMAP 会话失败: %w", err)
+	}
+	if session.APIURL == "" {
+		return nil, errors.New("JMAP 会话缺少 apiUrl")
+	}
+
+	// apiUrl 和 eventSourceUrl 的请求携带认证信息，只允许与会话地址（跟随重定向后）同源
+	if session.APIURL, err = resolveSessionURL(finalURL, session.APIURL); err != nil {
+		return nil, fmt.Errorf("JMAP 会话的 apiUrl 无效: %w", err)
+	}
+	if session.EventSourceURL != "" {
+		if session.EventSourceURL, err = resolveSessionURL(finalURL, session.EventSourceURL); err != nil {
+			return nil, fmt.Errorf("JMAP 会话的 eventSourceUrl 无效: %w", err)
+		}
+	}
+	if session.PrimaryAccounts[capabilityMail] == "" {
+		return nil, errors.New("JMAP 服务器没有提供邮件账户")
+	}
+
+	c.session = &session
+	return c.session, nil
+}
+
+// accountID 获取邮件账户 ID
+func (c *Client) accountID(ctx context.Context) (string, error) {
+	session, err := c.getSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	return session.PrimaryAccounts[capabilityMail], nil
+}
+
+// call 在一个请求中依次执行多个方法，返回与调用顺序对应的方法响应，任一方法失败时返回错误
+func (c *Client) call(ctx context.Context, calls ...Invocation) ([]Invocation, error) {
+	session, err := c.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodPost, session.APIURL, &Request{
+		Using:       []string{capabilityCore, capabilityMail},
+		MethodCalls: calls,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("解析 JMAP 响应失败: %w", err)
+	}
+
+	results := make([]Invocation, len(calls))
+	for _, invocation := range response.MethodResponses {
+		for i, call := range calls {
+			if invocation.CallID != call.CallID {
+				continue
+			}
+			if invocation.Name == "error" {
+				var methodErr MethodError
+				json.Unmarshal(invocation.Arguments, &methodErr)
+				return nil, &methodError{method: call.Name, MethodError: methodErr}
+			}
+			results[i] = invocation
+		}
+	}
+
+	for i, result := range results {
+		if result.Name == "" {
+			return nil, fmt.Errorf("JMAP 响应缺少方法 %s 的结果", calls[i].Name)
+		}
+	}
+
+	return results, nil
+}
+
+// methodError 方法级错误
+type methodError struct {
+	method string
+	MethodError
+}
+
+func (e *methodError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("JMAP 方法 %s 失败: %s (%s)", e.method, e.Type, e.Description)
+	}
+	return fmt.Sprintf("JMAP 方法 %s 失败: %s", e.method, e.Type)
+}
+
+// isMethodError 判断是否为指定类型的方法级错误
+func isMethodError(err error, errorType string) bool {
+	var methodErr *methodError
+	return errors.As(err, &methodErr) && methodErr.Type == errorType
+}
+
+// newInvocation 构建方法调用
+func newInvocation(name, callID string, arguments any) Invocation {
+	data, _ := json.Marshal(arguments)
+	return Invocation{Name: name, Arguments: data, CallID: callID}
+}
+
+// decodeArguments 解析方法响应参数
+func decodeArguments(invocation Invocation, target any) error {
+	if err := json.Unmarshal(invocation.Arguments, target); err != nil {
+		return fmt.Errorf("解析 %s 响应失败: %w", invocation.Name, err)
+	}
+	return nil
+}
+
+// doRequest 发送带认证信息的请求（payload 为空时不发送请求体），任意 2xx 状态码视为成功
+func (c *Client) doRequest(ctx context.Context, method, requestURL string, payload any) ([]byte, error) {
+	body, _, err := c.send(ctx, method, requestURL, payload)
+	return body, err
+}
+
+// send 发送带认证信息的请求，同时返回跟随重定向后的最终地址
+func (c *Client) send(ctx context.Context, method, requestURL string, payload any) ([]byte, *url.URL, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := c.newRequest(ctx, method, requestURL, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("JMAP 请求失败 (状态码: %d): %s", resp.StatusCode, string(body))
+	}
+
+	return body, resp.Request.URL, nil
+}
+
+// newRequest 创建带认证信息的请求
+func (c *Client) newRequest(ctx context.Context, method, requestURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	if c.credentials.UseBearer {
+		req.Header.Set("Authorization", "Bearer "+c.credentials.Password)
+	} else {
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+	return req, nil
+}
+
+// normalizeSessionURL 规范化会话地址：缺少协议时使用 https，没有路径时使用 /.well-known/jmap
+func normalizeSessionURL(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", errors.New("JMAP 会话地址不能为空")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("无效的 JMAP 会话地址: %s", rawURL)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = wellKnownPath
+	}
+	return u.String(), nil
+}
+
+// resolveSessionURL 相对会话地址解析会话中的地址：协议必须与会话地址相同，
+// 主机必须与会话地址相同或在 SetAllowedHosts 设置的主机中，避免认证信息被发送到其他服务器
+func resolveSessionURL(sessionURL *url.URL, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("无法解析地址 %s: %w", rawURL, err)
+	}
+	if !u.IsAbs() {
+		u = sessionURL.ResolveReference(u)
+		rawURL = u.String()
+	}
+
+	if u.Scheme != sessionURL.Scheme {
+		return "", fmt.Errorf("地址 %s 的协议与会话地址不一致", rawURL)
+	}
+	if !strings.EqualFold(u.Host, sessionURL.Host) && !allowedHosts[strings.ToLower(u.Hostname())] {
+		return "", fmt.Errorf("地址 %s 的主机与会话地址不一致", rawURL)
+	}
+	return rawURL, nil
+}
@@ -0,0 +1,354 @@
+package jmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"strings"
+	"time"
+)
+
+// DefaultMaxPartSize 单个正文分段默认的最大获取字节数（1 MB）
+const DefaultMaxPartSize = 1 << 20
+
+// maxPartSize 单个正文分段的最大获取字节数（maxBodyValueBytes），超出部分由服务器截断并在邮件中标记
+var maxPartSize int64 = DefaultMaxPartSize
+
+// SetMaxPartSize 设置单个正文分段的最大获取字节数，size <= 0 时使用默认值（应在启动时调用）
+func SetMaxPartSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxPartSize
+	}
+	maxPartSize = size
+}
+
+// emailProperties Email/get 获取的属性
+var emailProperties = []string{
+	"id", "blobId", "threadId", "mailboxIds", "keywords", "size", "receivedAt",
+	"messageId", "inReplyTo", "references", "sender", "from", "to", "cc", "bcc", "replyTo",
+	"subject", "sentAt", "textBody", "htmlBody", "attachments", "bodyValues",
+}
+
+// newestFirst 按接收时间倒序排列
+var newestFirst = []Comparator{{Property: "receivedAt", IsAscending: false}}
+
+// GetLatestEmail 获取指定文件夹的最新一封邮件，folder 为空时默认收件箱
+func (c *Client) GetLatestEmail(ctx context.Context, folder string) (*domain.Email, error) {
+	page, err := c.ListEmails(ctx, folder, 1, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Emails) == 0 {
+		return nil, nil // 没有找到邮件时返回 nil，不是错误
+	}
+
+	return page.Emails[0], nil
+}
+
+// GetEmailByID 根据邮件 ID（JMAP 邮件 ID）获取邮件详情
+func (c *Client) GetEmailByID(ctx context.Context, emailID string) (*domain.Email, error) {
+	if emailID == "" {
+		return nil, errors.New("邮件 ID 不能为空")
+	}
+
+	emails, err := c.getEmails(ctx, []string{emailID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("未找到 ID 为 %s 的邮件", emailID)
+	}
+
+	return emails[0], nil
+}
+
+// ListEmails 按时间倒序分页获取指定文件夹的邮件，folder 为空时默认收件箱
+// cursor 为上一页返回的游标（最后一封邮件的 ID），为空表示从最新的邮件开始
+func (c *Client) ListEmails(ctx context.Context, folder string, limit int, cursor string) (*domain.EmailPage, error) {
+	mailboxID, err := c.resolveMailbox(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.queryEmails(ctx, &EmailFilter{InMailbox: mailboxID}, limit, cursor)
+}
+
+// SearchEmails 按条件分页搜索邮件，criteria.Folder 为空时在收件箱中搜索
+func (c *Client) SearchEmails(ctx context.Context, criteria *domain.SearchCriteria, limit int, cursor string) (*domain.EmailPage, error) {
+	mailboxID, err := c.resolveMailbox(ctx, criteria.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &EmailFilter{
+		InMailbox: mailboxID,
+		From:      criteria.From,
+		To:        criteria.To,
+		Subject:   criteria.Subject,
+		Body:      criteria.Body,
+	}
+	if !criteria.Since.IsZero() {
+		filter.After = criteria.Since.UTC().Format(time.RFC3339)
+	}
+	if !criteria.Before.IsZero() {
+		filter.Before = criteria.Before.UTC().Format(time.RFC3339)
+	}
+	if criteria.UnreadOnly {
+		filter.NotKeyword = "$seen"
+	}
+
+	return c.queryEmails(ctx, filter, limit, cursor)
+}
+
+// queryEmails 在一个请求中执行 Email/query 和 Email/get（通过结果引用传递邮件 ID）
+func (c *Client) queryEmails(ctx context.Context, filter *EmailFilter, limit int, cursor string) (*domain.EmailPage, error) {
+	accountID, err := c.accountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &EmailQueryRequest{
+		AccountID:      accountID,
+		Filter:         filter,
+		Sort:           newestFirst,
+		Limit:          limit,
+		CalculateTotal: true,
+	}
+	// 游标即上一页最后一封邮件的 ID，本页从它的下一封开始
+	if cursor != "" {
+		query.Anchor = cursor
+		query.AnchorOffset = 1
+	}
+
+	results, err := c.call(ctx,
+		newInvocation("Email/query", "0", query),
+		newInvocation("Email/get", "1", c.emailGetRequest(accountID, nil, &ResultReference{
+			ResultOf: "0",
+			Name:     "Email/query",
+			Path:     "/ids",
+		})),
+	)
+	if err != nil {
+		if isMethodError(err, "anchorNotFound") {
+			return nil, fmt.Errorf("无效的游标（对应的邮件可能已被删除）: %s", cursor)
+		}
+		return nil, fmt.Errorf("查询邮件失败: %w", err)
+	}
+
+	var queryResponse EmailQueryResponse
+	if err := decodeArguments(results[0], &queryResponse); err != nil {
+		return nil, err
+	}
+	var getResponse EmailGetResponse
+	if err := decodeArguments(results[1], &getResponse); err != nil {
+		return nil, err
+	}
+
+	// Email/get 不保证返回顺序，按查询结果重新排序
+	emailsByID := make(map[string]*Email, len(getResponse.List))
+	for i := range getResponse.List {
+		emailsByID[getResponse.List[i].ID] = &getResponse.List[i]
+	}
+
+	page := &domain.EmailPage{Emails: []*domain.Email{}}
+	for _, id := range queryResponse.IDs {
+		if email, ok := emailsByID[id]; ok {
+			page.Emails = append(page.Emails, convertToEmail(email))
+		}
+	}
+
+	// 服务器不支持计算总数时，返回满一页即认为还有下一页
+	hasMore := len(queryResponse.IDs) == limit
+	if queryResponse.Total != nil {
+		hasMore = queryResponse.Position+len(queryResponse.IDs) < *queryResponse.Total
+	}
+	if hasMore && len(queryResponse.IDs) > 0 {
+		page.NextCursor = queryResponse.IDs[len(queryResponse.IDs)-1]
+	}
+
+	return page, nil
+}
+
+// getEmails 根据邮件 ID 获取邮件详情（按 ids 的顺序返回，不存在的邮件跳过）
+func (c *Client) getEmails(ctx context.Context, ids []string) ([]*domain.Email, error) {
+	list, err := c.fetchEmails(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]*domain.Email, 0, len(list))
+	for _, email := range list {
+		emails = append(emails, convertToEmail(email))
+	}
+	return emails, nil
+}
+
+// fetchEmails 根据邮件 ID 获取 JMAP 邮件对象（按 ids 的顺序返回，不存在的邮件跳过）
+func (c *Client) fetchEmails(ctx context.Context, ids []string) ([]*Email, error) {
+	accountID, err := c.accountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := c.call(ctx, newInvocation("Email/get", "0", c.emailGetRequest(accountID, ids, nil)))
+	if err != nil {
+		return nil, fmt.Errorf("获取邮件失败: %w", err)
+	}
+
+	var response EmailGetResponse
+	if err := decodeArguments(results[0], &response); err != nil {
+		return nil, err
+	}
+
+	emailsByID := make(map[string]*Email, len(response.List))
+	for i := range response.List {
+		emailsByID[response.List[i].ID] = &response.List[i]
+	}
+
+	emails := make([]*Email, 0, len(ids))
+	for _, id := range ids {
+		if email, ok := emailsByID[id]; ok {
+			emails = append(emails, email)
+		}
+	}
+	return emails, nil
+}
+
+// emailGetRequest 构建获取邮件详情的 Email/get 请求（同时获取 text/plain 和 text/html 正文）
+func (c *Client) emailGetRequest(accountID string, ids []string, idsRef *ResultReference) *EmailGetRequest {
+	return &EmailGetRequest{
+		AccountID:           accountID,
+		IDs:                 ids,
+		IDsRef:              idsRef,
+		Properties:          emailProperties,
+		FetchTextBodyValues: true,
+		FetchHTMLBodyValues: true,
+		MaxBodyValueBytes:   maxPartSize,
+	}
+}
+
+// resolveMailbox 将 folder 参数解析为邮箱（文件夹）ID：为空时为收件箱，文件夹角色通过 Mailbox/query 查找，其他值视为邮箱 ID
+// JMAP 的邮箱角色（RFC 8621 第 2 节，即 IANA 的 SPECIAL-USE 名称）与 domain.FolderRole* 的取值相同
+func (c *Client) resolveMailbox(ctx context.Context, folder string) (string, error) {
+	if folder == "" {
+		folder = domain.FolderRoleInbox
+	}
+	if !domain.IsFolderRole(folder) {
+		return folder, nil
+	}
+
+	accountID, err := c.accountID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	results, err := c.call(ctx, newInvocation("Mailbox/query", "0", &MailboxQueryRequest{
+		AccountID: accountID,
+		Filter:    &MailboxFilter{Role: folder},
+	}))
+	if err != nil {
+		return "", fmt.Errorf("查找文件夹失败: %w", err)
+	}
+
+	var response MailboxQueryResponse
+	if err := decodeArguments(results[0], &response); err != nil {
+		return "", err
+	}
+	if len(response.IDs) == 0 {
+		return "", fmt.Errorf("未找到文件夹: %s", folder)
+	}
+
+	return response.IDs[0], nil
+}
+
+// convertToEmail 将 JMAP 邮件对象转换为 domain.Email（邮件 ID 为 JMAP 邮件 ID）
+func convertToEmail(email *Email) *domain.Email {
+	date := email.SentAt
+	if date == "" {
+		date = email.ReceivedAt
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		date = t.Format(time.RFC3339)
+	}
+
+	toRecipients := convertAddresses(email.To)
+
+	result := &domain.Email{
+		ID:            email.ID,
+		Subject:       email.Subject,
+		From:          firstAddress(convertAddresses(email.From)),
+		To:            firstAddress(toRecipients),
+		Date:          date,
+		Sender:        firstAddress(convertAddresses(email.Sender)),
+		ToRecipients:  toRecipients,
+		CcRecipients:  convertAddresses(email.Cc),
+		BccRecipients: convertAddresses(email.Bcc),
+		ReplyTo:       convertAddresses(email.ReplyTo),
+		References:    email.References,
+	}
+	if len(result.References) == 0 {
+		result.References = email.InReplyTo
+	}
+
+	result.Text = readBodyValue(result, email, email.TextBody, "text/plain")
+	result.HTML = readBodyValue(result, email, email.HTMLBody, "text/html")
+
+	for _, part := range email.Attachments {
+		result.Attachments = append(result.Attachments, &domain.Attachment{
+			ID:          part.BlobID,
+			Name:        part.Name,
+			ContentType: strings.ToLower(part.Type),
+			Size:        part.Size,
+			ContentID:   strings.Trim(part.Cid, "<>"),
+			IsInline:    part.Disposition == "inline" || (part.Disposition == "" && part.Cid != ""),
+		})
+	}
+
+	return result
+}
+
+// readBodyValue 读取第一个指定类型的正文分段，服务器截断时标记邮件为截断
+// 没有 HTML 正文时 htmlBody 与 textBody 相同（反之亦然），因此需要按类型筛选
+func readBodyValue(result *domain.Email, email *Email, parts []BodyPart, mediaType string) string {
+	for _, part := range parts {
+		if !strings.EqualFold(part.Type, mediaType) {
+			continue
+		}
+		value, ok := email.BodyValues[part.PartID]
+		if !ok {
+			continue
+		}
+		if value.IsTruncated {
+			result.Truncated = true
+		}
+		return value.Value
+	}
+	return ""
+}
+
+// convertAddresses 转换地址列表
+func convertAddresses(addresses []EmailAddress) []*domain.EmailAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	result := make([]*domain.EmailAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, &domain.EmailAddress{
+			Name:    address.Name,
+			Address: address.Email,
+		})
+	}
+	return result
+}
+
+// firstAddress 获取地址列表中的第一个地址，列表为空时返回 nil
+func firstAddress(addresses []*domain.EmailAddress) *domain.EmailAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	return addresses[0]
+}
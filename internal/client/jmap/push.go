@@ -0,0 +1,222 @@
+package jmap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// pushPingSeconds 要求服务器发送 ping 事件的间隔（秒），用于保持连接
+	pushPingSeconds = "60"
+	// maxChanges 每次 Email/changes 获取的最大变更数量
+	maxChanges = 100
+	// maxEventSize 单个推送事件的最大字节数
+	maxEventSize = 1 << 20
+)
+
+// Subscription 已建立的 JMAP 推送订阅（EventSource 连接已打开）
+type Subscription struct {
+	client    *Client
+	accountID string
+	inboxID   string
+	state     string // 已处理到的邮件状态
+	body      io.ReadCloser
+}
+
+// Subscribe 通过 JMAP 推送（EventSource，RFC 8620 第 7.3 节）订阅收件箱的新邮件：
+// 获取会话和开始时的邮件状态并打开推送连接，连接建立后返回；之后调用 Listen 接收新邮件，结束时调用 Close
+// 推送连接与 ctx 绑定，ctx 结束时连接关闭
+func (c *Client) Subscribe(ctx context.Context) (*Subscription, error) {
+	session, err := c.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if session.EventSourceURL == "" {
+		return nil, errors.New("JMAP 服务器不支持推送（会话缺少 eventSourceUrl）")
+	}
+	accountID := session.PrimaryAccounts[capabilityMail]
+
+	inboxID, err := c.resolveMailbox(ctx, domain.FolderRoleInbox)
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录开始监听时的邮件状态，之后只获取新增的邮件
+	state, err := c.currentEmailState(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, expandEventSourceURL(session.EventSourceURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("连接 JMAP 推送失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("连接 JMAP 推送失败 (状态码: %d)", resp.StatusCode)
+	}
+
+	return &Subscription{
+		client:    c,
+		accountID: accountID,
+		inboxID:   inboxID,
+		state:     state,
+		body:      resp.Body,
+	}, nil
+}
+
+// Listen 读取推送事件，收到新邮件时发送到 emailChan；阻塞直到 ctx 结束（返回 nil）或推送连接出错
+func (s *Subscription) Listen(ctx context.Context, emailChan chan<- *domain.Email) error {
+	scanner := bufio.NewScanner(s.body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// 空行表示一个事件结束，只处理 data 中的 StateChange（忽略 ping 等其他事件）
+		if line != "" {
+			if value, ok := strings.CutPrefix(line, "data:"); ok {
+				data.WriteString(strings.TrimPrefix(value, " "))
+			}
+			continue
+		}
+		if data.Len() == 0 {
+			continue
+		}
+
+		var change StateChange
+		err := json.Unmarshal([]byte(data.String()), &change)
+		data.Reset()
+		if err != nil || change.Type != "StateChange" {
+			continue
+		}
+
+		newState := change.Changed[s.accountID]["Email"]
+		if newState == "" || newState == s.state {
+			continue
+		}
+
+		s.state, err = s.client.sendNewEmails(ctx, s.accountID, s.inboxID, s.state, emailChan)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取 JMAP 推送失败: %w", err)
+	}
+	return errors.New("JMAP 推送连接已断开")
+}
+
+// Close 关闭推送连接
+func (s *Subscription) Close() error {
+	return s.body.Close()
+}
+
+// sendNewEmails 获取 sinceState 之后新增的邮件，将收件箱中的邮件发送到 emailChan，返回新的状态
+func (c *Client) sendNewEmails(ctx context.Context, accountID, inboxID, sinceState string, emailChan chan<- *domain.Email) (string, error) {
+	created, newState, err := c.emailChanges(ctx, accountID, sinceState)
+	if isMethodError(err, "cannotCalculateChanges") {
+		// 服务器无法计算变更（如状态过旧），从当前状态重新开始
+		log.Printf("JMAP 服务器无法计算变更，从当前状态重新开始: %v", err)
+		return c.currentEmailState(ctx, accountID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if len(created) == 0 {
+		return newState, nil
+	}
+
+	emails, err := c.fetchEmails(ctx, created)
+	if err != nil {
+		return "", err
+	}
+
+	for _, email := range emails {
+		if !email.MailboxIDs[inboxID] {
+			continue
+		}
+		select {
+		case emailChan <- convertToEmail(email):
+		case <-ctx.Done():
+			return newState, nil
+		}
+	}
+
+	return newState, nil
+}
+
+// emailChanges 获取 sinceState 之后新增的邮件 ID（自动获取全部变更），返回新增的邮件 ID 和新的状态
+func (c *Client) emailChanges(ctx context.Context, accountID, sinceState string) ([]string, string, error) {
+	var created []string
+	state := sinceState
+	for {
+		results, err := c.call(ctx, newInvocation("Email/changes", "0", &EmailChangesRequest{
+			AccountID:  accountID,
+			SinceState: state,
+			MaxChanges: maxChanges,
+		}))
+		if err != nil {
+			return nil, "", err
+		}
+
+		var response EmailChangesResponse
+		if err := decodeArguments(results[0], &response); err != nil {
+			return nil, "", err
+		}
+
+		created = append(created, response.Created...)
+		state = response.NewState
+		if !response.HasMoreChanges {
+			return created, state, nil
+		}
+	}
+}
+
+// currentEmailState 获取当前的邮件状态（ids 为空数组时 Email/get 只返回状态）
+func (c *Client) currentEmailState(ctx context.Context, accountID string) (string, error) {
+	results, err := c.call(ctx, newInvocation("Email/get", "0", map[string]any{
+		"accountId":  accountID,
+		"ids":        []string{},
+		"properties": []string{"id"},
+	}))
+	if err != nil {
+		return "", fmt.Errorf("获取邮件状态失败: %w", err)
+	}
+
+	var response EmailGetResponse
+	if err := decodeArguments(results[0], &response); err != nil {
+		return "", err
+	}
+	return response.State, nil
+}
+
+// expandEventSourceURL 展开 eventSourceUrl 模板：只推送邮件变更，连接保持打开，定时发送 ping
+func expandEventSourceURL(template string) string {
+	return strings.NewReplacer(
+		"{types}", url.QueryEscape("Email"),
+		"{closeafter}", "no",
+		"{ping}", pushPingSeconds,
+	).Replace(template)
+}
@@ -0,0 +1,215 @@
+package jmap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JMAP 能力标识（RFC 8620 / RFC 8621）
+const (
+	capabilityCore = "urn:ietf:params:jmap:core"
+	capabilityMail = "urn:ietf:params:jmap:mail"
+)
+
+// Session 会话资源（RFC 8620 第 2 节），描述 API 地址、推送地址和账户
+type Session struct {
+	APIURL          string            `json:"apiUrl"`
+	DownloadURL     string            `json:"downloadUrl"`
+	EventSourceURL  string            `json:"eventSourceUrl"`
+	PrimaryAccounts map[string]string `json:"primaryAccounts"` // 能力标识 -> 账户 ID
+	Username        string            `json:"username"`
+	State           string            `json:"state"`
+}
+
+// Request API 请求
+type Request struct {
+	Using       []string     `json:"using"`
+	MethodCalls []Invocation `json:"methodCalls"`
+}
+
+// Response API 响应
+type Response struct {
+	MethodResponses []Invocation `json:"methodResponses"`
+	SessionState    string       `json:"sessionState"`
+}
+
+// Invocation 方法调用/响应，JSON 中为 [name, arguments, callId] 数组
+type Invocation struct {
+	Name      string
+	Arguments json.RawMessage
+	CallID    string
+}
+
+// MarshalJSON 编码为 [name, arguments, callId]
+func (i Invocation) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{i.Name, i.Arguments, i.CallID})
+}
+
+// UnmarshalJSON 从 [name, arguments, callId] 解码
+func (i *Invocation) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return fmt.Errorf("无效的方法响应: %s", string(data))
+	}
+	if err := json.Unmarshal(raw[0], &i.Name); err != nil {
+		return err
+	}
+	i.Arguments = raw[1]
+	return json.Unmarshal(raw[2], &i.CallID)
+}
+
+// MethodError 方法级错误（方法响应名称为 "error"）
+type MethodError struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ResultReference 引用同一请求中前一个方法的结果（参数名以 "#" 开头时使用）
+type ResultReference struct {
+	ResultOf string `json:"resultOf"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+}
+
+// Comparator 排序条件
+type Comparator struct {
+	Property    string `json:"property"`
+	IsAscending bool   `json:"isAscending"`
+}
+
+// EmailFilter Email/query 的过滤条件（各条件之间为 AND 关系）
+type EmailFilter struct {
+	InMailbox  string `json:"inMailbox,omitempty"`
+	From       string `json:"from,omitempty"`
+	To         string `json:"to,omitempty"`
+	Subject    string `json:"subject,omitempty"`
+	Body       string `json:"body,omitempty"`
+	After      string `json:"after,omitempty"`  // UTCDate，receivedAt >= after
+	Before     string `json:"before,omitempty"` // UTCDate，receivedAt < before
+	NotKeyword string `json:"notKeyword,omitempty"`
+}
+
+// EmailQueryRequest Email/query 请求参数
+type EmailQueryRequest struct {
+	AccountID      string       `json:"accountId"`
+	Filter         *EmailFilter `json:"filter,omitempty"`
+	Sort           []Comparator `json:"sort,omitempty"`
+	Anchor         string       `json:"anchor,omitempty"`
+	AnchorOffset   int          `json:"anchorOffset,omitempty"`
+	Limit          int          `json:"limit,omitempty"`
+	CalculateTotal bool         `json:"calculateTotal"`
+}
+
+// EmailQueryResponse Email/query 响应
+type EmailQueryResponse struct {
+	IDs      []string `json:"ids"`
+	Position int      `json:"position"`
+	Total    *int     `json:"total"` // 服务器不支持计算总数时为空
+}
+
+// EmailGetRequest Email/get 请求参数（IDs 与 IDsRef 二选一）
+type EmailGetRequest struct {
+	AccountID           string           `json:"accountId"`
+	IDs                 []string         `json:"ids,omitempty"`
+	IDsRef              *ResultReference `json:"#ids,omitempty"`
+	Properties          []string         `json:"properties,omitempty"`
+	FetchTextBodyValues bool             `json:"fetchTextBodyValues,omitempty"`
+	FetchHTMLBodyValues bool             `json:"fetchHTMLBodyValues,omitempty"`
+	MaxBodyValueBytes   int64            `json:"maxBodyValueBytes,omitempty"`
+}
+
+// EmailGetResponse Email/get 响应
+type EmailGetResponse struct {
+	State    string   `json:"state"`
+	List     []Email  `json:"list"`
+	NotFound []string `json:"notFound"`
+}
+
+// EmailChangesRequest Email/changes 请求参数
+type EmailChangesRequest struct {
+	AccountID  string `json:"accountId"`
+	SinceState string `json:"sinceState"`
+	MaxChanges int    `json:"maxChanges,omitempty"`
+}
+
+// EmailChangesResponse Email/changes 响应
+type EmailChangesResponse struct {
+	OldState       string   `json:"oldState"`
+	NewState       string   `json:"newState"`
+	HasMoreChanges bool     `json:"hasMoreChanges"`
+	Created        []string `json:"created"`
+}
+
+// Email 邮件对象（RFC 8621 第 4 节，只包含用到的属性）
+type Email struct {
+	ID          string               `json:"id"`
+	BlobID      string               `json:"blobId"`
+	ThreadID    string               `json:"threadId"`
+	MailboxIDs  map[string]bool      `json:"mailboxIds"`
+	Keywords    map[string]bool      `json:"keywords"`
+	Size        int64                `json:"size"`
+	ReceivedAt  string               `json:"receivedAt"`
+	MessageID   []string             `json:"messageId"`
+	InReplyTo   []string             `json:"inReplyTo"`
+	References  []string             `json:"references"`
+	Sender      []EmailAddress       `json:"sender"`
+	From        []EmailAddress       `json:"from"`
+	To          []EmailAddress       `json:"to"`
+	Cc          []EmailAddress       `json:"cc"`
+	Bcc         []EmailAddress       `json:"bcc"`
+	ReplyTo     []EmailAddress       `json:"replyTo"`
+	Subject     string               `json:"subject"`
+	SentAt      string               `json:"sentAt"`
+	TextBody    []BodyPart           `json:"textBody"`
+	HTMLBody    []BodyPart           `json:"htmlBody"`
+	Attachments []BodyPart           `json:"attachments"`
+	BodyValues  map[string]BodyValue `json:"bodyValues"`
+}
+
+// EmailAddress 邮件地址
+type EmailAddress struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// BodyPart MIME 分段
+type BodyPart struct {
+	PartID      string `json:"partId"`
+	BlobID      string `json:"blobId"`
+	Size        int64  `json:"size"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Disposition string `json:"disposition"`
+	Cid         string `json:"cid"`
+}
+
+// BodyValue 正文分段的解码内容
+type BodyValue struct {
+	Value       string `json:"value"`
+	IsTruncated bool   `json:"isTruncated"`
+}
+
+// MailboxQueryRequest Mailbox/query 请求参数
+type MailboxQueryRequest struct {
+	AccountID string         `json:"accountId"`
+	Filter    *MailboxFilter `json:"filter,omitempty"`
+}
+
+// MailboxFilter Mailbox/query 的过滤条件
+type MailboxFilter struct {
+	Role string `json:"role,omitempty"`
+}
+
+// MailboxQueryResponse Mailbox/query 响应
+type MailboxQueryResponse struct {
+	IDs []string `json:"ids"`
+}
+
+// StateChange 推送的状态变更（RFC 8620 第 7.1 节）
+type StateChange struct {
+	Type    string                       `json:"@type"`
+	Changed map[string]map[string]string `json:"changed"` // 账户 ID -> 数据类型 -> 新状态
+}
@@ -0,0 +1,93 @@
+package mailparse
+
+import (
+	"gomailapi2/internal/domain"
+	"gomailapi2/internal/utils"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-message/mail"
+)
+
+// ParseHeader 从邮件头解析邮件基本信息（IMAP、POP3、Gmail API 等协议通用），缺失或格式错误的头部不影响解析，对应字段留空
+func ParseHeader(header mail.Header) *domain.Email {
+	var date string
+	var subject string
+
+	// 记录 ID
+	messageID := header.Get("Message-ID")
+	// 去掉两端的尖括号
+	messageID = strings.Trim(messageID, "<>")
+	log.Printf("邮件 ID: %s", messageID)
+
+	// Date 头部缺失时返回零值时间，不是错误
+	if d, err := header.Date(); err != nil {
+		log.Printf("获取邮件日期失败: %v", err)
+	} else if !d.IsZero() {
+		date = d.Format(time.RFC3339)
+	}
+
+	if s, err := header.Subject(); err != nil {
+		log.Printf("解码邮件主题失败，使用原始主题: %v", err)
+		subject = header.Get("Subject")
+	} else {
+		subject = s
+	}
+
+	toRecipients := parseAddressList(header, "To")
+
+	email := &domain.Email{
+		ID:            messageID,
+		Date:          date,
+		From:          firstAddress(parseAddressList(header, "From")),
+		To:            firstAddress(toRecipients),
+		Subject:       subject,
+		Sender:        firstAddress(parseAddressList(header, "Sender")),
+		ToRecipients:  toRecipients,
+		CcRecipients:  parseAddressList(header, "Cc"),
+		BccRecipients: parseAddressList(header, "Bcc"),
+		ReplyTo:       parseAddressList(header, "Reply-To"),
+		References:    parseReferences(header),
+	}
+
+	return email
+}
+
+// parseReferences 解析 References 头部（缺失时使用 In-Reply-To），用于回复时生成会话关联头部
+func parseReferences(header mail.Header) []string {
+	for _, key := range []string{"References", "In-Reply-To"} {
+		ids, err := header.MsgIDList(key)
+		if err != nil {
+			log.Printf("解析邮件头 %s 失败: %v", key, err)
+			continue
+		}
+		if len(ids) > 0 {
+			return ids
+		}
+	}
+	return nil
+}
+
+// parseAddressList 解析地址列表头部，头部缺失或无法解析（如 "undisclosed-recipients:;"）时返回空列表
+func parseAddressList(header mail.Header, key string) []*domain.EmailAddress {
+	addresses, err := header.AddressList(key)
+	if err != nil {
+		log.Printf("解析邮件头 %s 失败: %v", key, err)
+		return nil
+	}
+
+	result := make([]*domain.EmailAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, utils.CleanEmailAddress(address))
+	}
+	return result
+}
+
+// firstAddress 获取地址列表中的第一个地址，列表为空时返回 nil
+func firstAddress(addresses []*domain.EmailAddress) *domain.EmailAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	return addresses[0]
+}
@@ -0,0 +1,147 @@
+package mailparse
+
+import (
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/emersion/go-message"
+	_ "github.com/emersion/go-message/charset" // 注册字符集解码，正文自动转换为 UTF-8
+	"github.com/emersion/go-message/mail"
+)
+
+// ParseMessage 解析完整的 MIME 邮件（POP3 等只能获取完整邮件的协议使用）
+// 只读取第一个 text/plain 和第一个 text/html 正文，每个正文最多 maxPartSize 字节（解码后），超出时标记为截断；
+// 附件只记录元数据，附件 ID 为 MIME 分段路径（与 IMAP 相同，如 "2"、"1.2"）
+func ParseMessage(r io.Reader, maxPartSize int64) (*domain.Email, error) {
+	entity, err := message.Read(r)
+	if err != nil && !isUnknownEncodingError(err) {
+		return nil, fmt.Errorf("解析邮件失败: %v", err)
+	}
+
+	email := ParseHeader(mail.Header{Header: entity.Header})
+	walkEntity(email, entity, nil, maxPartSize)
+
+	return email, nil
+}
+
+// walkEntity 遍历 MIME 分段，读取正文并收集附件元数据
+func walkEntity(email *domain.Email, entity *message.Entity, path []int, maxPartSize int64) {
+	if mr := entity.MultipartReader(); mr != nil {
+		for i := 1; ; i++ {
+			partPath := append(path[:len(path):len(path)], i)
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil && !isUnknownEncodingError(err) {
+				log.Printf("读取分段 %s 失败: %v", formatPartPath(partPath), err)
+				return
+			}
+			walkEntity(email, part, partPath, maxPartSize)
+		}
+	}
+
+	// 单分段邮件的正文路径为 "1"
+	if len(path) == 0 {
+		path = []int{1}
+	}
+
+	mediaType, params, _ := entity.Header.ContentType()
+	if mediaType == "" {
+		mediaType = "text/plain"
+	}
+
+	if isAttachmentEntity(entity, mediaType) {
+		email.Attachments = append(email.Attachments, entityToAttachment(formatPartPath(path), entity, mediaType, params))
+		return
+	}
+
+	switch mediaType {
+	case "text/plain":
+		if email.Text == "" {
+			email.Text = readTextEntity(email, entity, path, maxPartSize)
+		}
+	case "text/html":
+		if email.HTML == "" {
+			email.HTML = readTextEntity(email, entity, path, maxPartSize)
+		}
+	}
+}
+
+// readTextEntity 读取正文分段（传输编码和字符集已由 go-message 解码），超过 maxPartSize 时截断并标记
+func readTextEntity(email *domain.Email, entity *message.Entity, path []int, maxPartSize int64) string {
+	body, err := io.ReadAll(io.LimitReader(entity.Body, maxPartSize+1))
+	if err != nil {
+		log.Printf("解码正文分段 %s 失败: %v", formatPartPath(path), err)
+	}
+
+	if int64(len(body)) > maxPartSize {
+		body = body[:maxPartSize]
+		email.Truncated = true
+	}
+
+	return string(body)
+}
+
+// isAttachmentEntity 判断分段是否为附件（包括内联图片和转发的邮件）
+func isAttachmentEntity(entity *message.Entity, mediaType string) bool {
+	disposition, dispositionParams, _ := entity.Header.ContentDisposition()
+	if disposition == "attachment" {
+		return true
+	}
+	if dispositionParams["filename"] != "" {
+		return true
+	}
+	if _, params, _ := entity.Header.ContentType(); params["name"] != "" {
+		return true
+	}
+	if mediaType == "message/rfc822" {
+		return true
+	}
+	// 没有文件名但通过 Content-ID 引用的非文本内容（如 HTML 正文中的内联图片）
+	return entity.Header.Get("Content-ID") != "" && !strings.HasPrefix(mediaType, "text/")
+}
+
+// entityToAttachment 将分段转换为附件元数据，大小为解码后的实际大小
+func entityToAttachment(id string, entity *message.Entity, mediaType string, params map[string]string) *domain.Attachment {
+	disposition, dispositionParams, _ := entity.Header.ContentDisposition()
+	contentID := entity.Header.Get("Content-ID")
+
+	name := dispositionParams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+
+	size, err := io.Copy(io.Discard, entity.Body)
+	if err != nil {
+		log.Printf("读取附件分段 %s 失败: %v", id, err)
+	}
+
+	return &domain.Attachment{
+		ID:          id,
+		Name:        name,
+		ContentType: mediaType,
+		Size:        size,
+		ContentID:   strings.Trim(contentID, "<>"),
+		IsInline:    disposition == "inline" || (disposition == "" && contentID != ""),
+	}
+}
+
+// isUnknownEncodingError 未知的传输编码或字符集不影响解析，对应内容保持原样
+func isUnknownEncodingError(err error) bool {
+	return message.IsUnknownEncoding(err) || message.IsUnknownCharset(err)
+}
+
+// formatPartPath 格式化 MIME 分段路径，如 [1 2] -> "1.2"
+func formatPartPath(path []int) string {
+	parts := make([]string, len(path))
+	for i, n := range path {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,226 @@
+package pop3
+
+import (
+	"fmt"
+	"gomailapi2/internal/client/mailparse"
+	"gomailapi2/internal/domain"
+	"io"
+	"log"
+	"strings"
+)
+
+// DefaultMaxPartSize 单个正文分段默认的最大字节数（1 MB）
+const DefaultMaxPartSize = 1 << 20
+
+// maxPartSize 单个正文分段的最大字节数，超出部分丢弃并在邮件中标记为截断
+var maxPartSize int64 = DefaultMaxPartSize
+
+// SetMaxPartSize 设置单个正文分段的最大字节数，size <= 0 时使用默认值（应在启动时调用）
+func SetMaxPartSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxPartSize
+	}
+	maxPartSize = size
+}
+
+// DefaultScanLimit 根据 Message-ID 查找邮件时默认最多比对的邮件数
+const DefaultScanLimit = 500
+
+// scanLimit 根据 Message-ID 查找邮件时最多比对的邮件数（从最新的邮件开始）
+var scanLimit = DefaultScanLimit
+
+// SetScanLimit 设置根据 Message-ID 查找邮件时最多比对的邮件数，limit <= 0 时使用默认值（应在启动时调用）
+func SetScanLimit(limit int) {
+	if limit <= 0 {
+		limit = DefaultScanLimit
+	}
+	scanLimit = limit
+}
+
+// CommonPop3Client 通用 POP3 客户端（每次操作单独建立连接，只能访问收件箱）
+//
+// POP3 没有文件夹、已读状态和服务器端搜索：邮件按序号排列（序号越大越新），
+// 根据 Message-ID 查找时需要逐封获取邮件头比对
+type CommonPop3Client struct {
+	config       *Pop3Config
+	authProvider AuthProvider
+}
+
+// NewCommonPop3Client 创建通用 POP3 客户端
+func NewCommonPop3Client(config *Pop3Config, authProvider AuthProvider) *CommonPop3Client {
+	return &CommonPop3Client{
+		config:       config,
+		authProvider: authProvider,
+	}
+}
+
+// FetchLatestEmail 获取收件箱的最新邮件，folder 只能为空或收件箱【单独建立连接】
+func (c *CommonPop3Client) FetchLatestEmail(folder string) (*domain.Email, error) {
+	if err := checkFolder(folder); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.quit()
+
+	count, err := conn.stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		log.Printf("收件箱中没有邮件")
+		return nil, nil
+	}
+
+	return retrEmail(conn, count)
+}
+
+// FetchEmailByID 根据 Message-ID 获取邮件详情，从最新的邮件开始逐封获取邮件头比对，最多比对 scanLimit 封【单独建立连接】
+func (c *CommonPop3Client) FetchEmailByID(emailID string, folder string) (*domain.Email, error) {
+	if err := checkFolder(folder); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.quit()
+
+	count, err := conn.stat()
+	if err != nil {
+		return nil, err
+	}
+
+	emailID = strings.Trim(emailID, "<> ")
+	last := max(count-scanLimit+1, 1)
+	for number := count; number >= last; number-- {
+		header, err := conn.top(number)
+		if err != nil {
+			// 单封邮件获取失败时跳过，不影响其他邮件
+			log.Printf("获取邮件 %d 的邮件头失败，跳过: %v", number, err)
+			continue
+		}
+		if strings.Trim(header.Get("Message-ID"), "<> ") == emailID {
+			return retrEmail(conn, number)
+		}
+	}
+
+	if last > 1 {
+		return nil, fmt.Errorf("在最新的 %d 封邮件中未找到 ID 为 %s 的邮件", scanLimit, emailID)
+	}
+	return nil, fmt.Errorf("未找到 ID 为 %s 的邮件", emailID)
+}
+
+// ListEmails 按时间倒序分页获取收件箱的邮件，folder 只能为空或收件箱【单独建立连接】
+// cursor 为上一页返回的游标（最后一封邮件的 UIDL），为空表示从最新的邮件开始
+func (c *CommonPop3Client) ListEmails(folder string, limit int, cursor string) (*domain.EmailPage, error) {
+	if err := checkFolder(folder); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.quit()
+
+	uids, err := conn.uidl()
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.EmailPage{Emails: []*domain.Email{}}
+
+	// 游标即上一页最后一封邮件的 UIDL，本页从它前一封（更旧的）邮件开始
+	start := len(uids)
+	if cursor != "" {
+		index := -1
+		for i, uid := range uids {
+			if uid == cursor {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("无效的游标（对应的邮件可能已被删除）: %s", cursor)
+		}
+		start = index
+	}
+
+	last := max(start-limit+1, 1)
+	for number := start; number >= last; number-- {
+		email, err := retrEmail(conn, number)
+		if err != nil {
+			// 单封邮件解析失败时跳过，不影响其他邮件
+			log.Printf("获取邮件 %d 失败，跳过: %v", number, err)
+			continue
+		}
+		page.Emails = append(page.Emails, email)
+	}
+
+	if last > 1 {
+		page.NextCursor = uids[last-1]
+	}
+
+	return page, nil
+}
+
+// connect 建立连接并认证：认证提供者支持 USER/PASS 时使用用户名和密码登录，否则使用 SASL 认证
+func (c *CommonPop3Client) connect() (*conn, error) {
+	conn, err := dial(c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.authenticate(conn); err != nil {
+		conn.close()
+		return nil, fmt.Errorf("认证失败: %v", err)
+	}
+
+	return conn, nil
+}
+
+// authenticate 进行认证
+func (c *CommonPop3Client) authenticate(conn *conn) error {
+	if loginProvider, ok := c.authProvider.(LoginAuthProvider); ok {
+		if username, password, ok := loginProvider.GetLoginCredentials(); ok {
+			return conn.login(username, password)
+		}
+	}
+
+	saslClient, err := c.authProvider.GetSASLClient()
+	if err != nil {
+		return fmt.Errorf("获取认证客户端失败: %v", err)
+	}
+
+	return conn.authenticate(saslClient)
+}
+
+// retrEmail 获取并解析完整邮件
+func retrEmail(conn *conn, number int) (*domain.Email, error) {
+	reader, err := conn.retr(number)
+	if err != nil {
+		return nil, err
+	}
+	// 无论解析是否成功都需要读完多行响应，否则后续命令无法继续
+	defer io.Copy(io.Discard, reader)
+
+	email, err := mailparse.ParseMessage(reader, maxPartSize)
+	if err != nil {
+		return nil, fmt.Errorf("解析邮件 %d 失败: %v", number, err)
+	}
+	return email, nil
+}
+
+// checkFolder POP3 只能访问收件箱
+func checkFolder(folder string) error {
+	if folder == "" || strings.EqualFold(folder, domain.FolderRoleInbox) {
+		return nil
+	}
+	return fmt.Errorf("POP3 协议只支持收件箱，不支持文件夹: %s", folder)
+}
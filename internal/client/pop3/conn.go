@@ -0,0 +1,246 @@
+package pop3
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-message"
+	"github.com/emersion/go-message/mail"
+	msgtextproto "github.com/emersion/go-message/textproto"
+	"github.com/emersion/go-sasl"
+)
+
+const (
+	// dialTimeout 连接 POP3 服务器的超时时间
+	dialTimeout = 30 * time.Second
+	// sessionTimeout 单次会话（连接、认证到退出）的最长时间
+	sessionTimeout = 5 * time.Minute
+)
+
+// conn POP3 会话（RFC 1939），每次操作单独建立连接，结束时通过 QUIT 释放邮箱锁
+type conn struct {
+	text *textproto.Conn
+}
+
+// dial 连接 POP3 服务器并读取欢迎信息，需要时通过 STLS 升级为 TLS 连接
+func dial(config *Pop3Config) (*conn, error) {
+	host, _, err := net.SplitHostPort(config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 POP3 服务器地址 %s: %v", config.Host, err)
+	}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var netConn net.Conn
+	if config.UseTLS {
+		netConn, err = tls.DialWithDialer(dialer, "tcp", config.Host, &tls.Config{ServerName: host})
+	} else {
+		netConn, err = dialer.Dial("tcp", config.Host)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("连接 POP3 服务器失败: %v", err)
+	}
+	netConn.SetDeadline(time.Now().Add(sessionTimeout))
+
+	c := &conn{text: textproto.NewConn(netConn)}
+	if _, err := c.readResponse(); err != nil {
+		c.close()
+		return nil, fmt.Errorf("读取 POP3 欢迎信息失败: %v", err)
+	}
+
+	if !config.UseTLS && config.StartTLS {
+		if _, err := c.cmd("STLS"); err != nil {
+			c.close()
+			return nil, fmt.Errorf("STLS 失败: %v", err)
+		}
+		tlsConn := tls.Client(netConn, &tls.Config{ServerName: host})
+		if err := tlsConn.Handshake(); err != nil {
+			c.close()
+			return nil, fmt.Errorf("STLS 失败: %v", err)
+		}
+		c.text = textproto.NewConn(tlsConn)
+	}
+
+	return c, nil
+}
+
+// cmd 发送命令并读取单行响应，返回 +OK 之后的内容
+func (c *conn) cmd(format string, args ...any) (string, error) {
+	if err := c.text.PrintfLine(format, args...); err != nil {
+		return "", err
+	}
+	return c.readResponse()
+}
+
+// readResponse 读取单行响应，-ERR 时返回服务器的错误信息
+func (c *conn) readResponse() (string, error) {
+	line, err := c.text.ReadLine()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.HasPrefix(line, "+OK"):
+		return strings.TrimSpace(strings.TrimPrefix(line, "+OK")), nil
+	case strings.HasPrefix(line, "-ERR"):
+		return "", fmt.Errorf("服务器返回错误: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+	default:
+		return "", fmt.Errorf("无法识别的服务器响应: %s", line)
+	}
+}
+
+// login 使用 USER/PASS 命令认证
+func (c *conn) login(username, password string) error {
+	// 用户名和密码直接拼接在命令中，包含换行时会被服务器当作多条命令
+	if strings.ContainsAny(username, "\r\n") || strings.ContainsAny(password, "\r\n") {
+		return errors.New("用户名和密码不能包含换行符")
+	}
+
+	if _, err := c.cmd("USER %s", username); err != nil {
+		return err
+	}
+	_, err := c.cmd("PASS %s", password)
+	return err
+}
+
+// authenticate 使用 AUTH 命令进行 SASL 认证（RFC 5034）
+func (c *conn) authenticate(client sasl.Client) error {
+	mech, ir, err := client.Start()
+	if err != nil {
+		return err
+	}
+
+	command := "AUTH " + mech
+	if ir != nil {
+		// 空的初始响应用 "=" 表示
+		encoded := base64.StdEncoding.EncodeToString(ir)
+		if encoded == "" {
+			encoded = "="
+		}
+		command += " " + encoded
+	}
+	if err := c.text.PrintfLine("%s", command); err != nil {
+		return err
+	}
+
+	for {
+		line, err := c.text.ReadLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+OK"):
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("服务器返回错误: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case strings.HasPrefix(line, "+"):
+			challenge, err := base64.StdEncoding.DecodeString(strings.TrimSpace(strings.TrimPrefix(line, "+")))
+			if err != nil {
+				return fmt.Errorf("解码服务器挑战失败: %v", err)
+			}
+
+			response, err := client.Next(challenge)
+			if err != nil {
+				// 取消认证，服务器随后返回 -ERR
+				c.text.PrintfLine("*")
+				c.readResponse()
+				return err
+			}
+			if err := c.text.PrintfLine("%s", base64.StdEncoding.EncodeToString(response)); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("无法识别的服务器响应: %s", line)
+		}
+	}
+}
+
+// stat 获取邮箱中的邮件数量
+func (c *conn) stat() (int, error) {
+	response, err := c.cmd("STAT")
+	if err != nil {
+		return 0, fmt.Errorf("获取邮件数量失败: %v", err)
+	}
+
+	fields := strings.Fields(response)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("无法识别的 STAT 响应: %s", response)
+	}
+
+	count, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, fmt.Errorf("无法识别的 STAT 响应: %s", response)
+	}
+	return count, nil
+}
+
+// uidl 获取所有邮件的唯一标识，返回值按邮件序号排列（下标 i 对应序号 i+1）
+func (c *conn) uidl() ([]string, error) {
+	if _, err := c.cmd("UIDL"); err != nil {
+		return nil, fmt.Errorf("获取邮件唯一标识失败: %v", err)
+	}
+
+	lines, err := c.text.ReadDotLines()
+	if err != nil {
+		return nil, fmt.Errorf("获取邮件唯一标识失败: %v", err)
+	}
+
+	uids := make([]string, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("无法识别的 UIDL 响应: %s", line)
+		}
+		number, err := strconv.Atoi(fields[0])
+		if err != nil || number != len(uids)+1 {
+			return nil, fmt.Errorf("无法识别的 UIDL 响应: %s", line)
+		}
+		uids = append(uids, fields[1])
+	}
+	return uids, nil
+}
+
+// top 只获取邮件头（TOP n 0）
+func (c *conn) top(number int) (mail.Header, error) {
+	if _, err := c.cmd("TOP %d 0", number); err != nil {
+		return mail.Header{}, fmt.Errorf("获取邮件 %d 的邮件头失败: %v", number, err)
+	}
+
+	reader := c.text.DotReader()
+	// 无论解析是否成功都需要读完多行响应，否则后续命令无法继续
+	defer io.Copy(io.Discard, reader)
+
+	header, err := msgtextproto.ReadHeader(bufio.NewReader(reader))
+	if err != nil {
+		return mail.Header{}, fmt.Errorf("读取邮件 %d 的邮件头失败: %v", number, err)
+	}
+	return mail.Header{Header: message.Header{Header: header}}, nil
+}
+
+// retr 获取完整邮件，调用方需要将返回的内容读完
+func (c *conn) retr(number int) (io.Reader, error) {
+	if _, err := c.cmd("RETR %d", number); err != nil {
+		return nil, fmt.Errorf("获取邮件 %d 失败: %v", number, err)
+	}
+	return c.text.DotReader(), nil
+}
+
+// quit 结束会话并关闭连接
+func (c *conn) quit() {
+	c.cmd("QUIT")
+	c.close()
+}
+
+// close 关闭连接
+func (c *conn) close() {
+	c.text.Close()
+}
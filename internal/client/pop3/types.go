@@ -0,0 +1,25 @@
+package pop3
+
+import (
+	"github.com/emersion/go-sasl"
+)
+
+// Pop3Config POP3 连接配置
+type Pop3Config struct {
+	Host     string // POP3 服务器地址，如 "outlook.office365.com:995"
+	Username string // 用户名/邮箱地址
+	UseTLS   bool   // 是否直接使用 TLS 连接（如 995 端口）
+	StartTLS bool   // 明文连接后是否通过 STLS 升级（UseTLS 为 false 时有效）
+}
+
+// AuthProvider 认证提供者接口
+type AuthProvider interface {
+	// GetSASLClient 获取 SASL 认证客户端（通过 AUTH 命令认证）
+	GetSASLClient() (sasl.Client, error)
+}
+
+// LoginAuthProvider 使用 USER/PASS 命令认证的提供者（可选接口，实现时优先于 SASL 认证）
+type LoginAuthProvider interface {
+	// GetLoginCredentials 获取 USER/PASS 命令使用的用户名和密码，ok 为 false 时使用 SASL 认证
+	GetLoginCredentials() (username, password string, ok bool)
+}
@@ -0,0 +1,202 @@
+package smtp
+
+import (
+	"gomailapi2/internal/domain"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-message"
+	"github.com/emersion/go-message/mail"
+)
+
+// WriteMessage 将待发送的邮件编码为 MIME 格式写入 w，返回生成的 Message-ID（不含尖括号）
+//
+// 邮件结构：有附件时为 multipart/mixed，同时有纯文本和 HTML 正文时正文部分为 multipart/alternative
+func WriteMessage(w io.Writer, from *domain.EmailAddress, email *domain.OutgoingEmail) (string, error) {
+	var header mail.Header
+	header.SetDate(time.Now())
+	header.SetAddressList("From", toMailAddresses([]*domain.EmailAddress{from}))
+	header.SetAddressList("To", toMailAddresses(email.To))
+	if len(email.Cc) > 0 {
+		header.SetAddressList("Cc", toMailAddresses(email.Cc))
+	}
+	if len(email.ReplyTo) > 0 {
+		header.SetAddressList("Reply-To", toMailAddresses(email.ReplyTo))
+	}
+	header.SetSubject(email.Subject)
+
+	// 回复邮件时设置会话关联头部（References 末尾应为直接回复的邮件）
+	references := make([]string, 0, len(email.References)+1)
+	for _, id := range email.References {
+		if id = strings.Trim(id, "<> "); id != "" {
+			references = append(references, id)
+		}
+	}
+	if inReplyTo := strings.Trim(email.InReplyTo, "<> "); inReplyTo != "" {
+		header.SetMsgIDList("In-Reply-To", []string{inReplyTo})
+		if len(references) == 0 || references[len(references)-1] != inReplyTo {
+			references = append(references, inReplyTo)
+		}
+	}
+	if len(references) > 0 {
+		header.SetMsgIDList("References", references)
+	}
+
+	// 使用发件人地址的域名生成 Message-ID（本机主机名通常不是有效域名，容易被判为垃圾邮件）
+	if err := header.GenerateMessageIDWithHostname(addressDomain(from.Address)); err != nil {
+		return "", err
+	}
+	messageID, err := header.MessageID()
+	if err != nil {
+		return "", err
+	}
+
+	if len(email.Attachments) == 0 {
+		if err := writeBody(&writerTarget{w: w}, header.Header, email); err != nil {
+			return "", err
+		}
+		return messageID, nil
+	}
+
+	header.SetContentType("multipart/mixed", nil)
+	mw, err := message.CreateWriter(w, header.Header)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeBody(&partWriter{parent: mw}, message.Header{}, email); err != nil {
+		return "", err
+	}
+
+	for _, attachment := range email.Attachments {
+		if err := writeAttachment(mw, attachment); err != nil {
+			return "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	return messageID, nil
+}
+
+// bodyWriter 正文的写入目标（完整邮件或 multipart 中的一个分段）
+type bodyWriter interface {
+	create(header message.Header) (*message.Writer, error)
+}
+
+// writerTarget 直接写入邮件（header 为邮件头）
+type writerTarget struct {
+	w io.Writer
+}
+
+func (t *writerTarget) create(header message.Header) (*message.Writer, error) {
+	return message.CreateWriter(t.w, header)
+}
+
+// partWriter 写入 multipart 的一个分段
+type partWriter struct {
+	parent *message.Writer
+}
+
+func (t *partWriter) create(header message.Header) (*message.Writer, error) {
+	return t.parent.CreatePart(header)
+}
+
+// writeBody 写入正文：同时有纯文本和 HTML 时使用 multipart/alternative，否则为单个文本分段
+func writeBody(bw bodyWriter, header message.Header, email *domain.OutgoingEmail) error {
+	if email.Text != "" && email.HTML != "" {
+		header.SetContentType("multipart/alternative", nil)
+		aw, err := bw.create(header)
+		if err != nil {
+			return err
+		}
+		for _, part := range []struct{ subType, content string }{{"plain", email.Text}, {"html", email.HTML}} {
+			if err := writeTextPart(&partWriter{parent: aw}, message.Header{}, part.subType, part.content); err != nil {
+				return err
+			}
+		}
+		return aw.Close()
+	}
+
+	if email.HTML != "" {
+		return writeTextPart(bw, header, "html", email.HTML)
+	}
+	return writeTextPart(bw, header, "plain", email.Text)
+}
+
+// writeTextPart 写入 UTF-8 文本分段（quoted-printable 编码）
+func writeTextPart(bw bodyWriter, header message.Header, subType, content string) error {
+	header.SetContentType("text/"+subType, map[string]string{"charset": "utf-8"})
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	tw, err := bw.create(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(tw, content); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// writeAttachment 写入附件分段（base64 编码）
+func writeAttachment(mw *message.Writer, attachment *domain.OutgoingAttachment) error {
+	var header message.Header
+
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(attachment.Name))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", nil
+	}
+	if params == nil {
+		params = make(map[string]string)
+	}
+	params["name"] = attachment.Name
+	header.SetContentType(mediaType, params)
+
+	disposition := "attachment"
+	if attachment.IsInline {
+		disposition = "inline"
+	}
+	header.SetContentDisposition(disposition, map[string]string{"filename": attachment.Name})
+	header.Set("Content-Transfer-Encoding", "base64")
+	if attachment.ContentID != "" {
+		header.Set("Content-ID", "<"+strings.Trim(attachment.ContentID, "<>")+">")
+	}
+
+	aw, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := aw.Write(attachment.Content); err != nil {
+		return err
+	}
+	return aw.Close()
+}
+
+// addressDomain 返回邮件地址的域名部分，无法解析时返回 "localhost"
+func addressDomain(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 && i < len(address)-1 {
+		return address[i+1:]
+	}
+	return "localhost"
+}
+
+// toMailAddresses 将领域模型的邮件地址转换为 go-message 的邮件地址
+func toMailAddresses(addresses []*domain.EmailAddress) []*mail.Address {
+	result := make([]*mail.Address, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, &mail.Address{Name: address.Name, Address: address.Address})
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package smtp
+
+import (
+	"fmt"
+	"gomailapi2/internal/domain"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// 回复/转发主题前缀
+const (
+	replySubjectPrefix   = "Re: "
+	forwardSubjectPrefix = "Fw: "
+)
+
+// subjectPrefixPattern 已有的回复/转发主题前缀（包括 Outlook 中文客户端使用的前缀）
+var subjectPrefixPattern = regexp.MustCompile(`(?i)^\s*(re|fw|fwd|回复|答复|转发)\s*[:：]`)
+
+// bodyPattern 提取 HTML 文档 <body> 中的内容
+var bodyPattern = regexp.MustCompile(`(?is)<body[^>]*>(.*)</body>`)
+
+// ComposeReply 根据原邮件生成回复/转发邮件：自动确定收件人、添加主题前缀、设置会话关联头部并引用原文
+//
+// self 为当前账户的邮件地址（回复全部时从收件人中排除），转发时原邮件的附件需要调用方自行添加
+func ComposeReply(original *domain.Email, self string, reply *domain.ReplyEmail) *domain.OutgoingEmail {
+	email := &domain.OutgoingEmail{
+		To:          reply.To,
+		Cc:          reply.Cc,
+		Bcc:         reply.Bcc,
+		Attachments: reply.Attachments,
+		References:  original.References,
+	}
+
+	if reply.Mode == domain.ReplyModeForward {
+		email.Subject = addSubjectPrefix(forwardSubjectPrefix, original.Subject)
+		// 转发的邮件只通过 References 关联原会话，不设置 In-Reply-To
+		if original.ID != "" {
+			email.References = append(append([]string(nil), original.References...), original.ID)
+		}
+	} else {
+		email.Subject = addSubjectPrefix(replySubjectPrefix, original.Subject)
+		email.InReplyTo = original.ID
+		if len(email.To) == 0 {
+			email.To, email.Cc = replyRecipients(original, self, reply.Mode == domain.ReplyModeReplyAll)
+			if len(reply.Cc) > 0 {
+				email.Cc = reply.Cc
+			}
+		}
+	}
+
+	email.Text, email.HTML = quoteOriginal(original, reply)
+
+	return email
+}
+
+// replyRecipients 确定回复的收件人：优先使用原邮件的 Reply-To，回复全部时抄送原邮件的其他收件人（排除自己和重复地址）
+func replyRecipients(original *domain.Email, self string, all bool) (to, cc []*domain.EmailAddress) {
+	seen := map[string]bool{strings.ToLower(self): true}
+	add := func(list []*domain.EmailAddress, addresses ...*domain.EmailAddress) []*domain.EmailAddress {
+		for _, address := range addresses {
+			if address == nil || address.Address == "" {
+				continue
+			}
+			key := strings.ToLower(address.Address)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			list = append(list, address)
+		}
+		return list
+	}
+
+	if len(original.ReplyTo) > 0 {
+		to = add(to, original.ReplyTo...)
+	} else {
+		to = add(to, original.From)
+	}
+
+	// 回复自己发出的邮件时，收件人为原邮件的收件人
+	if len(to) == 0 {
+		to = add(to, original.ToRecipients...)
+	}
+
+	if all {
+		to = add(to, original.ToRecipients...)
+		cc = add(cc, original.CcRecipients...)
+	}
+
+	return to, cc
+}
+
+// addSubjectPrefix 添加回复/转发主题前缀（已有前缀时不重复添加）
+func addSubjectPrefix(prefix, subject string) string {
+	if subjectPrefixPattern.MatchString(subject) {
+		return subject
+	}
+	return prefix + subject
+}
+
+// quoteOriginal 生成引用原文的正文：纯文本正文中原文逐行加 "> " 前缀（转发时不加），HTML 正文中原文放在 blockquote 中
+// 原邮件或回复内容有 HTML 时同时生成 HTML 正文
+func quoteOriginal(original *domain.Email, reply *domain.ReplyEmail) (string, string) {
+	forward := reply.Mode == domain.ReplyModeForward
+	headerLines := quoteHeaderLines(original, forward)
+
+	// 纯文本正文
+	var text strings.Builder
+	text.WriteString(reply.Text)
+	text.WriteString("\r\n\r\n")
+	for _, line := range headerLines {
+		text.WriteString(line + "\r\n")
+	}
+	text.WriteString("\r\n")
+	originalText := strings.ReplaceAll(original.Text, "\r\n", "\n")
+	for _, line := range strings.Split(originalText, "\n") {
+		if !forward {
+			text.WriteString("> ")
+		}
+		text.WriteString(line + "\r\n")
+	}
+
+	if reply.HTML == "" && original.HTML == "" {
+		return text.String(), ""
+	}
+
+	// HTML 正文
+	var body strings.Builder
+	if reply.HTML != "" {
+		body.WriteString(htmlBodyContent(reply.HTML))
+	} else {
+		body.WriteString(textToHTML(reply.Text))
+	}
+	body.WriteString("<br><hr>")
+	for _, line := range headerLines {
+		body.WriteString(html.EscapeString(line) + "<br>")
+	}
+	body.WriteString(`<br><blockquote style="margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex">`)
+	if original.HTML != "" {
+		body.WriteString(htmlBodyContent(original.HTML))
+	} else {
+		body.WriteString(textToHTML(original.Text))
+	}
+	body.WriteString("</blockquote>")
+
+	return text.String(), "<html><body>" + body.String() + "</body></html>"
+}
+
+// quoteHeaderLines 生成引用原文前的原邮件信息
+func quoteHeaderLines(original *domain.Email, forward bool) []string {
+	title := "-----Original Message-----"
+	if forward {
+		title = "---------- Forwarded message ----------"
+	}
+
+	lines := []string{
+		title,
+		"From: " + formatAddress(original.From),
+		"Sent: " + original.Date,
+		"To: " + formatAddresses(original.ToRecipients),
+	}
+	if len(original.CcRecipients) > 0 {
+		lines = append(lines, "Cc: "+formatAddresses(original.CcRecipients))
+	}
+	lines = append(lines, "Subject: "+original.Subject)
+
+	return lines
+}
+
+// formatAddress 格式化邮件地址，如 "张三 <zhangsan@example.com>"
+func formatAddress(address *domain.EmailAddress) string {
+	if address == nil {
+		return ""
+	}
+	if address.Name == "" {
+		return address.Address
+	}
+	return fmt.Sprintf("%s <%s>", address.Name, address.Address)
+}
+
+// formatAddresses 格式化邮件地址列表
+func formatAddresses(addresses []*domain.EmailAddress) string {
+	formatted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		formatted = append(formatted, formatAddress(address))
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// htmlBodyContent 提取完整 HTML 文档 <body> 中的内容，不是完整文档时原样返回
+func htmlBodyContent(content string) string {
+	if matches := bodyPattern.FindStringSubmatch(content); matches != nil {
+		return matches[1]
+	}
+	return content
+}
+
+// textToHTML 将纯文本转义为 HTML（保留换行）
+func textToHTML(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
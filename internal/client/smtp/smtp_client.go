@@ -0,0 +1,149 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"gomailapi2/internal/domain"
+	"net"
+	netsmtp "net/smtp"
+	"time"
+
+	"github.com/emersion/go-sasl"
+)
+
+// dialTimeout 连接 SMTP 服务器的超时时间
+const dialTimeout = 30 * time.Second
+
+// CommonSmtpClient 通用 SMTP 客户端（每次发送单独建立连接）
+type CommonSmtpClient struct {
+	config       *SmtpConfig
+	authProvider AuthProvider
+}
+
+// NewCommonSmtpClient 创建通用 SMTP 客户端
+func NewCommonSmtpClient(config *SmtpConfig, authProvider AuthProvider) *CommonSmtpClient {
+	return &CommonSmtpClient{
+		config:       config,
+		authProvider: authProvider,
+	}
+}
+
+// SendMail 发送邮件，返回生成的 Message-ID（不含尖括号）
+func (c *CommonSmtpClient) SendMail(email *domain.OutgoingEmail) (string, error) {
+	if err := email.Validate(); err != nil {
+		return "", err
+	}
+
+	from := &domain.EmailAddress{Address: c.config.From}
+	if from.Address == "" {
+		from.Address = c.config.Username
+	}
+
+	var buf bytes.Buffer
+	messageID, err := WriteMessage(&buf, from, email)
+	if err != nil {
+		return "", fmt.Errorf("生成邮件内容失败: %v", err)
+	}
+
+	var recipients []string
+	for _, list := range [][]*domain.EmailAddress{email.To, email.Cc, email.Bcc} {
+		for _, recipient := range list {
+			recipients = append(recipients, recipient.Address)
+		}
+	}
+
+	if err := c.send(from.Address, recipients, buf.Bytes()); err != nil {
+		return "", err
+	}
+
+	return messageID, nil
+}
+
+// send 连接 SMTP 服务器、完成认证并投递邮件
+func (c *CommonSmtpClient) send(from string, recipients []string, message []byte) error {
+	if c.config.Host == "" {
+		return errors.New("未配置 SMTP 服务器")
+	}
+
+	host, _, err := net.SplitHostPort(c.config.Host)
+	if err != nil {
+		return fmt.Errorf("无效的 SMTP 服务器地址 %s: %v", c.config.Host, err)
+	}
+
+	conn, err := net.DialTimeout("tcp", c.config.Host, dialTimeout)
+	if err != nil {
+		return fmt.Errorf("连接 SMTP 服务器失败: %v", err)
+	}
+	if c.config.UseTLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: host})
+	}
+
+	client, err := netsmtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("连接 SMTP 服务器失败: %v", err)
+	}
+	defer client.Close()
+
+	if !c.config.UseTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP 服务器不支持 STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("STARTTLS 失败: %v", err)
+		}
+	}
+
+	// 获取认证客户端
+	saslClient, err := c.authProvider.GetSASLClient()
+	if err != nil {
+		return fmt.Errorf("获取 SASL 客户端失败: %v", err)
+	}
+
+	if err := client.Auth(&saslAuth{client: saslClient}); err != nil {
+		return fmt.Errorf("SMTP 认证失败: %v", err)
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("设置发件人失败: %v", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("设置收件人 %s 失败: %v", recipient, err)
+		}
+	}
+
+	wc, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("发送邮件内容失败: %v", err)
+	}
+	if _, err := wc.Write(message); err != nil {
+		wc.Close()
+		return fmt.Errorf("发送邮件内容失败: %v", err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("发送邮件内容失败: %v", err)
+	}
+
+	return client.Quit()
+}
+
+// saslAuth 将 sasl.Client 适配为 net/smtp 的 Auth 接口（用于 XOAUTH2 等 net/smtp 未内置的认证机制）
+type saslAuth struct {
+	client sasl.Client
+}
+
+// Start 开始认证过程，返回认证机制名称和初始响应
+func (a *saslAuth) Start(server *netsmtp.ServerInfo) (string, []byte, error) {
+	return a.client.Start()
+}
+
+// Next 处理服务器的挑战（XOAUTH2 认证失败时服务器会返回错误详情，回复空响应后服务器返回最终错误）
+func (a *saslAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	return a.client.Next(fromServer)
+}
@@ -0,0 +1,19 @@
+package smtp
+
+import (
+	"github.com/emersion/go-sasl"
+)
+
+// SmtpConfig SMTP 连接配置
+type SmtpConfig struct {
+	Host     string // SMTP 服务器地址，如 "smtp.office365.com:587"
+	Username string // 用户名/邮箱地址（From 为空时同时作为发件人地址）
+	From     string // 发件人地址，为空时使用 Username（登录用户名不是邮箱地址时需要指定）
+	UseTLS   bool   // 是否直接使用 TLS 连接（如 465 端口），为 false 时通过 STARTTLS 升级
+}
+
+// AuthProvider 认证提供者接口
+type AuthProvider interface {
+	// GetSASLClient 获取 SASL 认证客户端
+	GetSASLClient() (sasl.Client, error)
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -31,9 +32,22 @@ type LocalCacheConfig struct {
 
 // CacheConfig 缓存配置
 type CacheConfig struct {
-	Type  string           `mapstructure:"type"`
-	Local LocalCacheConfig `mapstructure:"local"`
-	Redis RedisConfig      `mapstructure:"redis"`
+	Type         string           `mapstructure:"type"`
+	Local        LocalCacheConfig `mapstructure:"local"`
+	Redis        RedisConfig      `mapstructure:"redis"`
+	ExpiryMargin time.Duration    `mapstructure:"expiry_margin"` // access token 的过期安全余量，缓存时间为实际有效期减去该值
+	Refresh      RefreshConfig    `mapstructure:"refresh"`
+}
+
+// RefreshConfig access token 后台刷新配置
+type RefreshConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`      // 是否在后台提前刷新最近使用的账户的 access token
+	Interval    time.Duration `mapstructure:"interval"`     // 检查间隔
+	Before      time.Duration `mapstructure:"before"`       // 缓存剩余时间低于该值时刷新
+	Jitter      time.Duration `mapstructure:"jitter"`       // 刷新时机的随机偏移上限，避免大量账户同时刷新
+	Concurrency int           `mapstructure:"concurrency"`  // 同时刷新的最大账户数
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"` // 超过该时间未使用的账户不再刷新
+	MaxAccounts int           `mapstructure:"max_accounts"` // 跟踪的最大账户数，超出时淘汰最久未使用的账户
 }
 
 // // GetL1ExpirationDuration 获取 L1 缓存过期时间
@@ -45,6 +59,16 @@ type CacheConfig struct {
 // 	return duration
 // }
 
+// TokenStoreConfig refresh token 存储配置
+type TokenStoreConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`        // 是否保存 refreshToken，启用后可以使用账户 ID 代替邮箱信息
+	Type          string `mapstructure:"type"`           // 存储类型: "file" 或 "redis"（使用 cache.redis 的连接配置）
+	Path          string `mapstructure:"path"`           // 文件存储的路径
+	EncryptionKey string `mapstructure:"encryption_key"` // base64 编码的 32 字节 AES-256 密钥
+	MaxHistory    int    `mapstructure:"max_history"`    // 每个账户保留的 refreshToken 变更记录数
+	AutoRegister  bool   `mapstructure:"auto_register"`  // refreshToken 变更时自动保存未注册的账户
+}
+
 // LogConfig 日志配置
 type LogConfig struct {
 	Level string `mapstructure:"level"`
@@ -52,7 +76,16 @@ type LogConfig struct {
 
 // WebhookConfig webhook 配置
 type WebhookConfig struct {
-	BaseURL string `mapstructure:"base_url"`
+	BaseURL    string `mapstructure:"base_url"`
+	GmailTopic string `mapstructure:"gmail_topic"` // Gmail 推送通知使用的 Pub/Sub 主题（projects/{project}/topics/{topic}），为空时不支持 Gmail API 订阅
+}
+
+// MailConfig 邮件获取配置
+type MailConfig struct {
+	MaxPartSize   int64 `mapstructure:"max_part_size"`   // IMAP/POP3/JMAP/EWS 单个正文分段的最大获取字节数，超出部分截断
+	Pop3ScanLimit int   `mapstructure:"pop3_scan_limit"` // POP3 根据 Message-ID 查找邮件时最多比对的邮件数（从最新的邮件开始）
+
+	JmapAllowedHosts []string `mapstructure:"jmap_allowed_hosts"` // JMAP 会话的 apiUrl、eventSourceUrl 除会话地址的主机外可以使用的主机
 }
 
 // Config 应用程序完整配置
@@ -61,6 +94,9 @@ type Config struct {
 	Cache   CacheConfig   `mapstructure:"cache"`
 	Log     LogConfig     `mapstructure:"log"`
 	Webhook WebhookConfig `mapstructure:"webhook"`
+	Mail    MailConfig    `mapstructure:"mail"`
+
+	TokenStore TokenStoreConfig `mapstructure:"token_store"`
 }
 
 // IsProduction 检查是否为生产环境
@@ -88,8 +124,18 @@ func LoadConfig() *Config {
 	viper.BindEnv("cache.redis.host", "GOMAILAPI_REDIS_HOST")
 	viper.BindEnv("cache.redis.port", "GOMAILAPI_REDIS_PORT")
 	viper.BindEnv("cache.redis.password", "GOMAILAPI_REDIS_PASSWORD")
+	viper.BindEnv("cache.expiry_margin", "GOMAILAPI_CACHE_EXPIRY_MARGIN")
+	viper.BindEnv("cache.refresh.enabled", "GOMAILAPI_CACHE_REFRESH_ENABLED")
+	viper.BindEnv("cache.refresh.concurrency", "GOMAILAPI_CACHE_REFRESH_CONCURRENCY")
 	viper.BindEnv("log.level", "GOMAILAPI_LOG_LEVEL")
 	viper.BindEnv("webhook.base_url", "GOMAILAPI_WEBHOOK_BASE_URL")
+	viper.BindEnv("webhook.gmail_topic", "GOMAILAPI_WEBHOOK_GMAIL_TOPIC")
+	viper.BindEnv("mail.max_part_size", "GOMAILAPI_MAIL_MAX_PART_SIZE")
+	viper.BindEnv("mail.pop3_scan_limit", "GOMAILAPI_MAIL_POP3_SCAN_LIMIT")
+	viper.BindEnv("token_store.enabled", "GOMAILAPI_TOKEN_STORE_ENABLED")
+	viper.BindEnv("token_store.type", "GOMAILAPI_TOKEN_STORE_TYPE")
+	viper.BindEnv("token_store.path", "GOMAILAPI_TOKEN_STORE_PATH")
+	viper.BindEnv("token_store.encryption_key", "GOMAILAPI_TOKEN_STORE_ENCRYPTION_KEY")
 
 	// 根据环境设置默认值
 	isProduction := strings.ToLower(os.Getenv("GOMAILAPI_ENV")) == "production"
@@ -111,8 +157,27 @@ func LoadConfig() *Config {
 	viper.SetDefault("cache.redis.host", "localhost")
 	viper.SetDefault("cache.redis.port", "6379")
 	viper.SetDefault("cache.redis.db", 0)
+	viper.SetDefault("cache.expiry_margin", "10m")
+	viper.SetDefault("cache.refresh.enabled", true)
+	viper.SetDefault("cache.refresh.interval", "1m")
+	viper.SetDefault("cache.refresh.before", "5m")
+	viper.SetDefault("cache.refresh.jitter", "2m")
+	viper.SetDefault("cache.refresh.concurrency", 8)
+	viper.SetDefault("cache.refresh.idle_timeout", "2h")
+	viper.SetDefault("cache.refresh.max_accounts", 1000)
 	viper.SetDefault("log.level", "info")
 
+	// 邮件获取默认值
+	viper.SetDefault("mail.max_part_size", 1048576) // 1 MB
+	viper.SetDefault("mail.pop3_scan_limit", 500)
+
+	// refresh token 存储默认值
+	viper.SetDefault("token_store.enabled", false)
+	viper.SetDefault("token_store.type", "file")
+	viper.SetDefault("token_store.path", "data/token_store.json")
+	viper.SetDefault("token_store.max_history", 100)
+	viper.SetDefault("token_store.auto_register", false)
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Printf("Config file not found, using defaults: %v", err)
 	}
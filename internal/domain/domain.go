@@ -1,6 +1,12 @@
 // internal/domain/mail.go - 新增业务领域模型
 package domain
 
+import (
+	"errors"
+	"io"
+	"time"
+)
+
 // EmailAddress 邮件地址（业务概念）
 type EmailAddress struct {
 	Name    string `json:"name"`
@@ -12,8 +18,179 @@ type Email struct {
 	ID      string        `json:"id"`
 	Subject string        `json:"subject"`
 	From    *EmailAddress `json:"from"`
-	To      *EmailAddress `json:"to"`
+	To      *EmailAddress `json:"to"` // 第一个收件人（完整列表见 ToRecipients）
 	Date    string        `json:"date"`
 	Text    string        `json:"text"`
 	HTML    string        `json:"html"`
+
+	Truncated bool `json:"truncated,omitempty"` // 正文是否因超过大小限制而被截断
+
+	Sender        *EmailAddress   `json:"sender,omitempty"`        // 实际发送者（代发时与 From 不同）
+	ToRecipients  []*EmailAddress `json:"toRecipients,omitempty"`  // 收件人列表
+	CcRecipients  []*EmailAddress `json:"ccRecipients,omitempty"`  // 抄送列表
+	BccRecipients []*EmailAddress `json:"bccRecipients,omitempty"` // 密送列表（通常只有已发送的邮件才有）
+	ReplyTo       []*EmailAddress `json:"replyTo,omitempty"`       // 回复地址列表
+
+	Attachments []*Attachment `json:"attachments,omitempty"` // 附件元数据（不含内容，内容通过下载接口获取）
+
+	References []string `json:"references,omitempty"` // 会话中前序邮件的 Message-ID 列表（References 头部，缺失时使用 In-Reply-To，IMAP 和 Gmail API 提供）
+}
+
+// Attachment 附件元数据
+type Attachment struct {
+	ID          string `json:"id"`                  // 附件标识（IMAP 为 MIME 分段路径，如 "2"、"1.2"；Graph 和 Gmail API 为附件 ID），用于下载
+	Name        string `json:"name"`                // 文件名
+	ContentType string `json:"contentType"`         // MIME 类型，如 "application/pdf"
+	Size        int64  `json:"size"`                // 大小（字节），IMAP 为根据传输编码估算的解码后大小
+	ContentID   string `json:"contentId,omitempty"` // Content-ID，HTML 正文通过 cid: 引用内联图片时使用（Graph 仅文件附件提供）
+	IsInline    bool   `json:"isInline"`            // 是否为内联附件
+}
+
+// AttachmentContent 附件内容，调用方读取完毕后需要关闭 Content
+type AttachmentContent struct {
+	Attachment *Attachment
+	Content    io.ReadCloser
+}
+
+// EmailPage 邮件分页结果（按时间倒序，最新的在前）
+type EmailPage struct {
+	Emails     []*Email `json:"emails"`
+	NextCursor string   `json:"nextCursor,omitempty"` // 下一页游标，为空表示没有更多邮件
+}
+
+// EmailFlagsUpdate 邮件状态修改（为空的字段保持不变）
+type EmailFlagsUpdate struct {
+	IsRead    *bool `json:"isRead,omitempty"`    // 已读/未读
+	IsFlagged *bool `json:"isFlagged,omitempty"` // 星标（旗标）/取消星标
+}
+
+// OutgoingEmail 待发送的邮件（发件人为当前账户）
+type OutgoingEmail struct {
+	To          []*EmailAddress       `json:"to"`                    // 收件人列表
+	Cc          []*EmailAddress       `json:"cc,omitempty"`          // 抄送列表
+	Bcc         []*EmailAddress       `json:"bcc,omitempty"`         // 密送列表
+	ReplyTo     []*EmailAddress       `json:"replyTo,omitempty"`     // 回复地址列表
+	Subject     string                `json:"subject"`               // 主题
+	Text        string                `json:"text,omitempty"`        // 纯文本正文
+	HTML        string                `json:"html,omitempty"`        // HTML 正文（Graph 只支持一种正文，同时指定时使用 HTML）
+	Attachments []*OutgoingAttachment `json:"attachments,omitempty"` // 附件
+	InReplyTo   string                `json:"inReplyTo,omitempty"`   // 回复的邮件 ID（IMAP 为 Message-ID，Graph 为邮件 ID），用于关联到原邮件的会话
+	References  []string              `json:"references,omitempty"`  // 会话中前序邮件的 Message-ID 列表（仅 SMTP 使用，为空时使用 InReplyTo）
+}
+
+// OutgoingAttachment 待发送的附件
+type OutgoingAttachment struct {
+	Name        string `json:"name"`                  // 文件名
+	ContentType string `json:"contentType,omitempty"` // MIME 类型，为空时根据文件名推断
+	Content     []byte `json:"content"`               // 附件内容（JSON 中为 base64 编码）
+	ContentID   string `json:"contentId,omitempty"`   // Content-ID，HTML 正文通过 cid: 引用内联图片时使用
+	IsInline    bool   `json:"isInline,omitempty"`    // 是否为内联附件
+}
+
+// Validate 校验待发送的邮件
+func (e *OutgoingEmail) Validate() error {
+	if len(e.To)+len(e.Cc)+len(e.Bcc) == 0 {
+		return errors.New("至少需要一个收件人")
+	}
+	for _, recipients := range [][]*EmailAddress{e.To, e.Cc, e.Bcc, e.ReplyTo} {
+		for _, recipient := range recipients {
+			if recipient == nil || recipient.Address == "" {
+				return errors.New("收件人地址不能为空")
+			}
+		}
+	}
+	for _, attachment := range e.Attachments {
+		if attachment == nil || attachment.Name == "" {
+			return errors.New("附件文件名不能为空")
+		}
+	}
+	return nil
+}
+
+// ReplyMode 回复方式
+type ReplyMode string
+
+const (
+	ReplyModeReply    ReplyMode = "reply"    // 回复发件人
+	ReplyModeReplyAll ReplyMode = "replyAll" // 回复全部
+	ReplyModeForward  ReplyMode = "forward"  // 转发
+)
+
+// ReplyEmail 回复/转发邮件的内容（主题、会话关联头部和引用的原文自动生成）
+type ReplyEmail struct {
+	Mode        ReplyMode             `json:"mode"`                  // 回复方式
+	To          []*EmailAddress       `json:"to,omitempty"`          // 收件人列表，回复时为空表示根据原邮件自动确定，转发时必填
+	Cc          []*EmailAddress       `json:"cc,omitempty"`          // 抄送列表，回复全部时为空表示根据原邮件自动确定
+	Bcc         []*EmailAddress       `json:"bcc,omitempty"`         // 密送列表
+	Text        string                `json:"text,omitempty"`        // 纯文本回复内容
+	HTML        string                `json:"html,omitempty"`        // HTML 回复内容
+	Attachments []*OutgoingAttachment `json:"attachments,omitempty"` // 附加的附件（转发时原邮件的附件会自动带上）
+}
+
+// Validate 校验回复/转发邮件的内容
+func (r *ReplyEmail) Validate() error {
+	switch r.Mode {
+	case ReplyModeReply, ReplyModeReplyAll:
+	case ReplyModeForward:
+		if len(r.To)+len(r.Cc)+len(r.Bcc) == 0 {
+			return errors.New("转发邮件至少需要一个收件人")
+		}
+	default:
+		return errors.New("不支持的回复方式: " + string(r.Mode))
+	}
+	for _, recipients := range [][]*EmailAddress{r.To, r.Cc, r.Bcc} {
+		for _, recipient := range recipients {
+			if recipient == nil || recipient.Address == "" {
+				return errors.New("收件人地址不能为空")
+			}
+		}
+	}
+	for _, attachment := range r.Attachments {
+		if attachment == nil || attachment.Name == "" {
+			return errors.New("附件文件名不能为空")
+		}
+	}
+	return nil
+}
+
+// SearchCriteria 邮件搜索条件（各条件之间为 AND 关系，空值表示不限制）
+type SearchCriteria struct {
+	From       string    `json:"from,omitempty"`       // 发件人包含（匹配发件人的名称或地址）
+	To         string    `json:"to,omitempty"`         // 收件人包含（匹配收件人的名称或地址）
+	Subject    string    `json:"subject,omitempty"`    // 主题包含
+	Body       string    `json:"body,omitempty"`       // 正文包含
+	Since      time.Time `json:"since,omitempty"`      // 起始时间（包含），RFC3339 格式
+	Before     time.Time `json:"before,omitempty"`     // 截止时间（不包含），RFC3339 格式
+	UnreadOnly bool      `json:"unreadOnly,omitempty"` // 只搜索未读邮件
+	Folder     string    `json:"folder,omitempty"`     // 文件夹，为空表示收件箱
+}
+
+// 文件夹角色（跨协议统一的特殊文件夹标识，可直接作为各邮件接口的 folder 参数）
+const (
+	FolderRoleInbox   = "inbox"   // 收件箱
+	FolderRoleSent    = "sent"    // 已发送
+	FolderRoleDrafts  = "drafts"  // 草稿箱
+	FolderRoleJunk    = "junk"    // 垃圾邮件
+	FolderRoleTrash   = "trash"   // 已删除
+	FolderRoleArchive = "archive" // 存档
+)
+
+// IsFolderRole 判断是否为已知的文件夹角色
+func IsFolderRole(role string) bool {
+	switch role {
+	case FolderRoleInbox, FolderRoleSent, FolderRoleDrafts, FolderRoleJunk, FolderRoleTrash, FolderRoleArchive:
+		return true
+	}
+	return false
+}
+
+// Folder 邮件文件夹
+type Folder struct {
+	ID          string `json:"id"`                 // 文件夹标识（IMAP 为完整名称，Graph 为文件夹 ID），可直接作为 folder 参数
+	Name        string `json:"name"`               // 显示名称
+	Path        string `json:"path"`               // 完整路径（以 / 分隔）
+	ParentID    string `json:"parentId,omitempty"` // 父文件夹标识，顶层文件夹为空
+	Role        string `json:"role,omitempty"`     // 特殊文件夹角色，普通文件夹为空
+	TotalCount  int    `json:"totalCount"`         // 邮件总数
+	UnreadCount int    `json:"unreadCount"`        // 未读邮件数
 }
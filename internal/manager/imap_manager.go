@@ -3,7 +3,7 @@ package manager
 import (
 	"context"
 	"fmt"
-	"gomailapi2/internal/client/imap/outlook"
+	"gomailapi2/internal/client/imap"
 	"gomailapi2/internal/domain"
 	"math/rand"
 	"time"
@@ -13,13 +13,13 @@ import (
 
 // ImapSubscription IMAP 订阅信息
 type ImapSubscription struct {
-	ID         string                     // 订阅 ID
-	Email      string                     // 邮箱地址
-	Client     *outlook.OutlookImapClient // IMAP 客户端
-	EmailChan  chan *domain.Email         // 邮件通道
-	StopCtx    context.Context            // 停止上下文
-	StopCancel context.CancelFunc         // 停止函数
-	CreatedAt  time.Time                  // 创建时间
+	ID         string                 // 订阅 ID
+	Email      string                 // 邮箱地址
+	Client     *imap.CommonImapClient // IMAP 客户端
+	EmailChan  chan *domain.Email     // 邮件通道
+	StopCtx    context.Context        // 停止上下文
+	StopCancel context.CancelFunc     // 停止函数
+	CreatedAt  time.Time              // 创建时间
 }
 
 // ImapSubscriptionManager IMAP 订阅管理器
@@ -35,7 +35,7 @@ func NewImapSubscriptionManager() *ImapSubscriptionManager {
 }
 
 // CreateSubscription 创建新的 IMAP 订阅
-func (m *ImapSubscriptionManager) CreateSubscription(imapClient *outlook.OutlookImapClient, email string) (*ImapSubscription, error) {
+func (m *ImapSubscriptionManager) CreateSubscription(imapClient *imap.CommonImapClient, email string) (*ImapSubscription, error) {
 	// 生成唯一的订阅 ID
 	subscriptionID := generateSubscriptionID()
 
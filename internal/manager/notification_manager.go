@@ -29,6 +29,12 @@ func (nm *NotificationManager) RegisterChannel(subscriptionID string) chan strin
 	return notifyChan
 }
 
+// HasChannel 检查指定的通知通道是否已注册
+func (nm *NotificationManager) HasChannel(subscriptionID string) bool {
+	_, exists := nm.channels[subscriptionID]
+	return exists
+}
+
 // SendNotification 发送邮件通知到指定的订阅通道
 func (nm *NotificationManager) SendNotification(subscriptionID, emailID string) bool {
 	channel, exists := nm.channels[subscriptionID]
@@ -20,29 +20,60 @@ type TokenResponse struct {
 	ExpiresIn    int64  `json:"expires_in"`
 	TokenType    string `json:"token_type"`
 	Scope        string `json:"scope"`
+	IDToken      string `json:"id_token,omitempty"` // 授权码流程申请 openid 权限时返回
 }
 
-// GetAccessToken 获取 accessToken
-func GetAccessToken(mailInfo *types.MailInfo) (string, error) {
+// GetAccessToken 获取 accessToken（ExpiresIn 为 accessToken 的有效期，用于设置缓存时间）
+func GetAccessToken(mailInfo *types.MailInfo) (*TokenResponse, error) {
 	switch mailInfo.ProtocolType {
 	case types.ProtocolTypeIMAP:
 		// IMAP: accessToken 过期 -> GetTokensWithScope(includeScope=false)，取 accessToken
-		tokenResp, err := GetTokensWithScope(mailInfo, false)
-		if err != nil {
-			return "", err
+		return GetTokensWithScope(mailInfo, false)
+
+	case types.ProtocolTypePOP3:
+		if err := checkPop3Provider(mailInfo); err != nil {
+			return nil, err
 		}
-		return tokenResp.AccessToken, nil
+		// POP3: 与 IMAP 使用同一个 accessToken
+		return GetTokensWithScope(mailInfo, false)
 
 	case types.ProtocolTypeGraph:
+		if mailInfo.ServiceProvider != types.ServiceProviderMicrosoft {
+			return nil, fmt.Errorf("%s 账户不支持 Graph 协议", mailInfo.ServiceProvider)
+		}
 		// Graph: accessToken 过期 -> GetTokensWithScope(includeScope=true)，取 accessToken
-		tokenResp, err := GetTokensWithScope(mailInfo, true)
-		if err != nil {
-			return "", err
+		return GetTokensWithScope(mailInfo, true)
+
+	case types.ProtocolTypeGmailAPI:
+		if mailInfo.ServiceProvider != types.ServiceProviderGoogle {
+			return nil, fmt.Errorf("%s 账户不支持 Gmail API 协议", mailInfo.ServiceProvider)
 		}
-		return tokenResp.AccessToken, nil
+		// Gmail API: 与 IMAP 使用同一个 accessToken，权限由 refreshToken 授权时的 scope 决定
+		return GetTokensWithScope(mailInfo, false)
+
+	case types.ProtocolTypeJMAP:
+		// JMAP 只支持密码/API 令牌认证的 GENERIC 账户，不会走到令牌刷新
+		return nil, fmt.Errorf("%s 账户不支持 JMAP 协议", mailInfo.ServiceProvider)
+
+	case types.ProtocolTypeEWS:
+		// EWS: 使用 EWS scope 刷新得到的 accessToken
+		return getEwsTokens(mailInfo)
 
 	default:
-		return "", fmt.Errorf("不支持的协议类型: %s", mailInfo.ProtocolType)
+		return nil, fmt.Errorf("不支持的协议类型: %s", mailInfo.ProtocolType)
+	}
+}
+
+// AccessTokenScope 获取协议换取 access token 时使用的 scope，为空表示使用 refreshToken 授权时的 scope
+// 与 GetAccessToken 中各协议的 scope 保持一致，用于区分缓存中不同受众的 access token
+func AccessTokenScope(protocolType types.ProtocolType) string {
+	switch protocolType {
+	case types.ProtocolTypeGraph:
+		return graphScope
+	case types.ProtocolTypeEWS:
+		return ewsScope
+	default:
+		return ""
 	}
 }
 
@@ -56,74 +87,123 @@ func GetRefreshToken(mailInfo *types.MailInfo) (string, error) {
 	return tokenResp.RefreshToken, nil
 }
 
-// GetBothTokens 同时获取 accessToken 和 refreshToken
-func GetBothTokens(mailInfo *types.MailInfo) (string, string, error) {
+// GetBothTokens 同时获取 accessToken 和 refreshToken（ExpiresIn 为 accessToken 的有效期）
+func GetBothTokens(mailInfo *types.MailInfo) (*TokenResponse, error) {
 	switch mailInfo.ProtocolType {
 	case types.ProtocolTypeIMAP:
 		// IMAP: 要求刷新，同时获取新邮件/监听 -> 同时获取 accessToken 和 refreshToken（GetTokensWithScope(includeScope=false)）
-		tokenResp, err := GetTokensWithScope(mailInfo, false)
-		if err != nil {
-			return "", "", err
+		return GetTokensWithScope(mailInfo, false)
+
+	case types.ProtocolTypePOP3:
+		if err := checkPop3Provider(mailInfo); err != nil {
+			return nil, err
 		}
-		return tokenResp.AccessToken, tokenResp.RefreshToken, nil
+		// POP3: 与 IMAP 相同，一次刷新同时得到 accessToken 和 refreshToken
+		return GetTokensWithScope(mailInfo, false)
 
 	case types.ProtocolTypeGraph:
-		// Graph: 要求刷新，同时获取新邮件/监听 -> 并发获取 accessToken 和 refreshToken
-		return getBothTokensConcurrently(mailInfo)
+		if mailInfo.ServiceProvider != types.ServiceProviderMicrosoft {
+			return nil, fmt.Errorf("%s 账户不支持 Graph 协议", mailInfo.ServiceProvider)
+		}
+		// Graph: 要求刷新，同时获取新邮件/监听 -> 依次获取 accessToken 和 refreshToken
+		return getGraphBothTokens(mailInfo)
+
+	case types.ProtocolTypeGmailAPI:
+		if mailInfo.ServiceProvider != types.ServiceProviderGoogle {
+			return nil, fmt.Errorf("%s 账户不支持 Gmail API 协议", mailInfo.ServiceProvider)
+		}
+		// Gmail API: 一次刷新同时得到 accessToken 和 refreshToken
+		return GetTokensWithScope(mailInfo, false)
+
+	case types.ProtocolTypeJMAP:
+		return nil, fmt.Errorf("%s 账户不支持 JMAP 协议", mailInfo.ServiceProvider)
+
+	case types.ProtocolTypeEWS:
+		// EWS: scope 中包含 offline_access，一次刷新同时得到 accessToken 和 refreshToken
+		return getEwsTokens(mailInfo)
 
 	default:
-		return "", "", fmt.Errorf("不支持的协议类型: %s", mailInfo.ProtocolType)
+		return nil, fmt.Errorf("不支持的协议类型: %s", mailInfo.ProtocolType)
 	}
 }
 
-// getBothTokensConcurrently 并发获取 accessToken 和 refreshToken（仅用于 Graph 协议）
-func getBothTokensConcurrently(mailInfo *types.MailInfo) (string, string, error) {
-	type tokenResult struct {
-		token string
-		err   error
+// checkPop3Provider Yahoo、AOL 的 POP3 服务器不支持 OAuth2 认证
+func checkPop3Provider(mailInfo *types.MailInfo) error {
+	switch mailInfo.ServiceProvider {
+	case types.ServiceProviderYahoo, types.ServiceProviderAOL:
+		return fmt.Errorf("%s 账户不支持 POP3 协议", mailInfo.ServiceProvider)
 	}
+	return nil
+}
 
-	// 创建两个 channel 来接收结果
-	accessTokenCh := make(chan tokenResult, 1)
-	refreshTokenCh := make(chan tokenResult, 1)
-
-	// 并发获取 accessToken（带 scope）
-	go func() {
-		token, err := GetTokensWithScope(mailInfo, true)
-		accessTokenCh <- tokenResult{token: token.AccessToken, err: err}
-	}()
-
-	// 并发获取 refreshToken（不带 scope）
-	go func() {
-		token, err := GetTokensWithScope(mailInfo, false)
-		refreshTokenCh <- tokenResult{token: token.RefreshToken, err: err}
-	}()
+// getEwsTokens 获取 EWS 使用的令牌（仅微软账户支持 OAuth 方式的 EWS，本地部署的 Exchange 使用 GENERIC 账户的密码认证）
+func getEwsTokens(mailInfo *types.MailInfo) (*TokenResponse, error) {
+	if mailInfo.ServiceProvider != types.ServiceProviderMicrosoft {
+		return nil, fmt.Errorf("%s 账户不支持 EWS 协议", mailInfo.ServiceProvider)
+	}
+	return getMicrosoftTokensForScope(mailInfo, ewsScope)
+}
 
-	// 等待两个请求完成
-	accessResult := <-accessTokenCh
-	refreshResult := <-refreshTokenCh
+// getGraphBothTokens 依次获取 accessToken 和 refreshToken（仅用于 Graph 协议）
+// 先获取带 scope 的响应（accessToken 及其有效期），再用其中轮换后的 refreshToken 获取不带 scope 的响应，
+// refreshToken 取自第二次的响应；两次请求不能并发使用同一个 refreshToken，否则轮换后其中一个会失效
+func getGraphBothTokens(mailInfo *types.MailInfo) (*TokenResponse, error) {
+	accessResp, err := GetTokensWithScope(mailInfo, true)
+	if err != nil {
+		return nil, fmt.Errorf("获取 accessToken 失败: %w", err)
+	}
 
-	// 检查错误
-	if accessResult.err != nil {
-		return "", "", fmt.Errorf("获取 accessToken 失败: %w", accessResult.err)
+	rotated := *mailInfo
+	if accessResp.RefreshToken != "" {
+		rotated.RefreshToken = accessResp.RefreshToken
 	}
-	if refreshResult.err != nil {
-		return "", "", fmt.Errorf("获取 refreshToken 失败: %w", refreshResult.err)
+	refreshResp, err := GetTokensWithScope(&rotated, false)
+	if err != nil {
+		return nil, fmt.Errorf("获取 refreshToken 失败: %w", err)
 	}
 
 	// 合并结果
-	return accessResult.token, refreshResult.token, nil
+	result := *accessResp
+	result.RefreshToken = refreshResp.RefreshToken
+	if result.RefreshToken == "" {
+		result.RefreshToken = accessResp.RefreshToken
+	}
+	return &result, nil
 }
 
 // GetTokensWithScope 带 scope 返回 graph api 所需的 accessToken，不带，返回 refreshToken 和 IMAP API 所需的 accessToken（Graph API 无法使用）
+// Google、Yahoo、AOL 账户没有 Graph 协议，忽略 includeScope，始终使用 refreshToken 授权时的 scope
 func GetTokensWithScope(mailInfo *types.MailInfo, includeScope bool) (*TokenResponse, error) {
-	// 目前只处理微软邮箱
-	if mailInfo.ServiceProvider != types.ServiceProviderMicrosoft {
-		return nil, fmt.Errorf("暂时只支持微软邮箱，不支持: %s", mailInfo.ServiceProvider)
+	switch mailInfo.ServiceProvider {
+	case types.ServiceProviderMicrosoft:
+		return getMicrosoftTokens(mailInfo, includeScope)
+	case types.ServiceProviderGoogle:
+		return getGoogleTokens(mailInfo)
+	case types.ServiceProviderYahoo:
+		return getYahooTokens(mailInfo, yahooTokenURL)
+	case types.ServiceProviderAOL:
+		return getYahooTokens(mailInfo, aolTokenURL)
+	case types.ServiceProviderGeneric:
+		return nil, fmt.Errorf("%s 账户使用密码认证，没有可刷新的令牌", mailInfo.ServiceProvider)
+	default:
+		return nil, fmt.Errorf("不支持的服务提供商: %s", mailInfo.ServiceProvider)
+	}
+}
+
+// getMicrosoftTokens 通过微软的 token endpoint 刷新令牌（租户为空时使用 consumers，即个人账户）
+func getMicrosoftTokens(mailInfo *types.MailInfo, includeScope bool) (*TokenResponse, error) {
+	// 根据 includeScope 参数决定是否添加 scope
+	scope := ""
+	if includeScope {
+		scope = graphScope
 	}
+	return getMicrosoftTokensForScope(mailInfo, scope)
+}
 
+// getMicrosoftTokensForScope 通过微软的 token endpoint 刷新令牌，scope 为空时使用 refreshToken 授权时的 scope
+func getMicrosoftTokensForScope(mailInfo *types.MailInfo, scope string) (*TokenResponse, error) {
 	// 微软的 token endpoint
-	tokenURL := "https://login.microsoftonline.com/consumers/oauth2/v2.0/token"
+	tokenURL := microsoftTokenURL(mailInfo.Tenant)
 
 	// 构建请求参数
 	data := url.Values{}
@@ -131,9 +211,13 @@ func GetTokensWithScope(mailInfo *types.MailInfo, includeScope bool) (*TokenResp
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", mailInfo.RefreshToken)
 
-	// 根据 includeScope 参数决定是否添加 scope
-	if includeScope {
-		data.Set("scope", "https://graph.microsoft.com/.default")
+	// 机密客户端：使用客户端密钥或证书断言
+	if err := setMicrosoftClientCredentials(data, mailInfo, tokenURL); err != nil {
+		return nil, err
+	}
+
+	if scope != "" {
+		data.Set("scope", scope)
 	}
 
 	// // 创建请求
@@ -145,6 +229,11 @@ func GetTokensWithScope(mailInfo *types.MailInfo, includeScope bool) (*TokenResp
 
 	// 发送请求
 	// resp, err := client.NewProxyClient().Do(req)
+	return requestTokens(mailInfo, tokenURL, data)
+}
+
+// requestTokens 向 token endpoint 发送刷新请求并解析响应
+func requestTokens(mailInfo *types.MailInfo, tokenURL string, data url.Values) (*TokenResponse, error) {
 	resp, err := http.PostForm(tokenURL, data)
 	if err != nil {
 		return nil, fmt.Errorf("发送请求失败: %w", err)
@@ -153,7 +242,7 @@ func GetTokensWithScope(mailInfo *types.MailInfo, includeScope bool) (*TokenResp
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("获取令牌失败 (状态码: %d): %s", resp.StatusCode, string(body))
+		return nil, parseTokenError(resp.StatusCode, body)
 	}
 
 	// 解析响应
@@ -164,6 +253,7 @@ func GetTokensWithScope(mailInfo *types.MailInfo, includeScope bool) (*TokenResp
 
 	log.Info().
 		Str("email", mailInfo.Email).
+		Str("provider", string(mailInfo.ServiceProvider)).
 		Bool("hasRefreshToken", tokenResp.RefreshToken != "").
 		Int64("expiresIn", tokenResp.ExpiresIn).
 		Msg("成功获取 token")
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"gomailapi2/internal/types"
+)
+
+// googleAuthorizeURL Google 的授权地址
+const googleAuthorizeURL = "https://accounts.google.com/o/oauth2/v2/auth"
+
+// 授权码流程默认申请的权限（同时申请 openid 以从 id_token 中获取邮箱地址）
+const (
+	// defaultMicrosoftScope 微软账户默认申请 IMAP、POP3、SMTP 权限（检测结果为 IMAP 协议），需要 Graph 协议时申请 Graph 权限
+	defaultMicrosoftScope = "openid email profile offline_access https://outlook.office.com/IMAP.AccessAsUser.All https://outlook.office.com/POP.AccessAsUser.All https://outlook.office.com/SMTP.Send"
+	// defaultGoogleScope Google 账户默认申请完整邮箱权限（IMAP、SMTP 和 Gmail API 均可使用）
+	defaultGoogleScope = "openid email https://mail.google.com/"
+)
+
+// AuthorizationRequest 授权码流程的授权请求参数
+type AuthorizationRequest struct {
+	ServiceProvider types.ServiceProvider
+	ClientID        string
+	Tenant          string // 微软租户，为空时使用 consumers
+	RedirectURI     string
+	Scope           string // 为空时使用服务提供商的默认权限
+	State           string
+	CodeChallenge   string // PKCE code_challenge（S256）
+	LoginHint       string // 预先填写的登录邮箱，可为空
+}
+
+// NewPKCE 生成 PKCE 的 code_verifier 和对应的 code_challenge（S256）
+func NewPKCE() (string, string, error) {
+	verifier, err := randomURLString(32)
+	if err != nil {
+		return "", "", fmt.Errorf("生成 code_verifier 失败: %w", err)
+	}
+	digest := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
+
+// NewState 生成授权请求的 state 参数
+func NewState() (string, error) {
+	state, err := randomURLString(24)
+	if err != nil {
+		return "", fmt.Errorf("生成 state 失败: %w", err)
+	}
+	return state, nil
+}
+
+// BuildAuthorizationURL 构建授权地址（仅支持微软和 Google 账户）
+func BuildAuthorizationURL(request *AuthorizationRequest) (string, error) {
+	params := url.Values{}
+	params.Set("client_id", request.ClientID)
+	params.Set("response_type", "code")
+	params.Set("redirect_uri", request.RedirectURI)
+	params.Set("state", request.State)
+	params.Set("code_challenge", request.CodeChallenge)
+	params.Set("code_challenge_method", "S256")
+	if request.LoginHint != "" {
+		params.Set("login_hint", request.LoginHint)
+	}
+
+	switch request.ServiceProvider {
+	case types.ServiceProviderMicrosoft:
+		params.Set("scope", AuthorizationScope(request.ServiceProvider, request.Scope))
+		params.Set("response_mode", "query")
+		return microsoftAuthorizeURL(request.Tenant) + "?" + params.Encode(), nil
+
+	case types.ServiceProviderGoogle:
+		params.Set("scope", AuthorizationScope(request.ServiceProvider, request.Scope))
+		// Google 只有在 access_type=offline 时才返回 refreshToken，prompt=consent 确保重复授权时也返回
+		params.Set("access_type", "offline")
+		params.Set("prompt", "consent")
+		return googleAuthorizeURL + "?" + params.Encode(), nil
+
+	default:
+		return "", fmt.Errorf("%s 账户不支持 OAuth 授权", request.ServiceProvider)
+	}
+}
+
+// AuthorizationScope 获取授权请求的权限：为空时使用服务提供商的默认权限，并补充 openid（微软账户还需要 offline_access）
+func AuthorizationScope(provider types.ServiceProvider, scope string) string {
+	if scope == "" {
+		switch provider {
+		case types.ServiceProviderMicrosoft:
+			return defaultMicrosoftScope
+		case types.ServiceProviderGoogle:
+			return defaultGoogleScope
+		}
+	}
+
+	scopes := strings.Fields(scope)
+	required := []string{"openid", "email"}
+	if provider == types.ServiceProviderMicrosoft {
+		required = append(required, "offline_access")
+	}
+	for _, item := range required {
+		if !slices.Contains(scopes, item) {
+			scopes = append(scopes, item)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// ExchangeAuthorizationCode 使用授权码和 PKCE code_verifier 换取令牌
+// mailInfo 提供服务提供商、clientId、客户端凭据和租户（邮箱地址此时未知，仅用于日志）
+func ExchangeAuthorizationCode(mailInfo *types.MailInfo, code, redirectURI, codeVerifier string) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("client_id", mailInfo.ClientID)
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", redirectURI)
+	data.Set("code_verifier", codeVerifier)
+
+	switch mailInfo.ServiceProvider {
+	case types.ServiceProviderMicrosoft:
+		tokenURL := microsoftTokenURL(mailInfo.Tenant)
+		if err := setMicrosoftClientCredentials(data, mailInfo, tokenURL); err != nil {
+			return nil, err
+		}
+		return requestTokens(mailInfo, tokenURL, data)
+
+	case types.ServiceProviderGoogle:
+		if mailInfo.ClientSecret != "" {
+			data.Set("client_secret", mailInfo.ClientSecret)
+		}
+		return requestTokens(mailInfo, googleTokenURL, data)
+
+	default:
+		return nil, fmt.Errorf("%s 账户不支持 OAuth 授权", mailInfo.ServiceProvider)
+	}
+}
+
+// idTokenClaims id_token 中与邮箱地址有关的声明
+type idTokenClaims struct {
+	Email             string `json:"email"`
+	PreferredUsername string `json:"preferred_username"` // 微软账户的登录名（个人账户为邮箱地址，工作/学校账户通常为 UPN）
+	UPN               string `json:"upn"`
+}
+
+// EmailFromIDToken 从 id_token 中获取邮箱地址
+// id_token 直接从 token endpoint 通过 TLS 获取，按 OpenID Connect 规范可以不校验签名
+func EmailFromIDToken(idToken string) (string, error) {
+	if idToken == "" {
+		return "", errors.New("令牌响应中没有 id_token，请确认申请了 openid 权限")
+	}
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return "", errors.New("无效的 id_token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", fmt.Errorf("解码 id_token 失败: %w", err)
+	}
+
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("解析 id_token 失败: %w", err)
+	}
+
+	for _, email := range []string{claims.Email, claims.PreferredUsername, claims.UPN} {
+		if strings.Contains(email, "@") {
+			return email, nil
+		}
+	}
+	return "", errors.New("id_token 中没有邮箱地址，请确认申请了 email 权限")
+}
+
+// microsoftAuthorizeURL 获取租户的授权地址
+func microsoftAuthorizeURL(tenant string) string {
+	if tenant == "" {
+		tenant = defaultMicrosoftTenant
+	}
+	return fmt.Sprintf("%s/%s/oauth2/v2.0/authorize", microsoftLoginURL, url.PathEscape(tenant))
+}
+
+// randomURLString 生成 n 字节随机数的 URL 安全编码
+func randomURLString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
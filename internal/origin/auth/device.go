@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gomailapi2/internal/types"
+)
+
+// deviceCodeGrantType 设备码流程换取令牌的授权类型（RFC 8628）
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// 轮询间隔（服务器没有返回 interval 时使用默认值，返回 slow_down 时增加）
+const (
+	defaultDevicePollInterval = 5 * time.Second
+	devicePollSlowDown        = 5 * time.Second
+)
+
+// DeviceCodeResponse 设备码响应
+type DeviceCodeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`        // 用户在验证页面输入的代码
+	VerificationURI string `json:"verification_uri"` // 验证页面地址
+	ExpiresIn       int64  `json:"expires_in"`       // 设备码有效期（秒）
+	Interval        int64  `json:"interval"`         // 轮询间隔（秒）
+	Message         string `json:"message"`          // 提示用户的说明文字
+}
+
+// RequestDeviceCode 发起设备码请求（仅支持微软账户，Google 的设备码流程不允许申请邮箱权限）
+// mailInfo 提供服务提供商、clientId 和租户，scope 为空时使用默认权限
+func RequestDeviceCode(mailInfo *types.MailInfo, scope string) (*DeviceCodeResponse, error) {
+	if mailInfo.ServiceProvider != types.ServiceProviderMicrosoft {
+		return nil, fmt.Errorf("%s 账户不支持设备码授权", mailInfo.ServiceProvider)
+	}
+
+	data := url.Values{}
+	data.Set("client_id", mailInfo.ClientID)
+	data.Set("scope", AuthorizationScope(mailInfo.ServiceProvider, scope))
+
+	resp, err := http.PostForm(microsoftDeviceCodeURL(mailInfo.Tenant), data)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, parseTokenError(resp.StatusCode, body)
+	}
+
+	var deviceResp DeviceCodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&deviceResp); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+	return &deviceResp, nil
+}
+
+// PollDeviceToken 按服务器要求的间隔轮询 token endpoint，直到用户完成登录、拒绝授权、设备码过期或 ctx 结束
+func PollDeviceToken(ctx context.Context, mailInfo *types.MailInfo, device *DeviceCodeResponse) (*TokenResponse, error) {
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	data := url.Values{}
+	data.Set("client_id", mailInfo.ClientID)
+	data.Set("grant_type", deviceCodeGrantType)
+	data.Set("device_code", device.DeviceCode)
+	tokenURL := microsoftTokenURL(mailInfo.Tenant)
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		tokenResp, err := requestTokens(mailInfo, tokenURL, data)
+		if err == nil {
+			return tokenResp, nil
+		}
+
+		var tokenErr *TokenError
+		if !errors.As(err, &tokenErr) {
+			return nil, err
+		}
+		switch tokenErr.OAuthError {
+		case "authorization_pending":
+			// 用户还没有完成登录，继续轮询
+		case "slow_down":
+			interval += devicePollSlowDown
+		case "authorization_declined", "access_denied":
+			return nil, errors.New("用户拒绝了授权")
+		case "expired_token", "code_expired":
+			return nil, errors.New("设备码已过期，请重新开始授权")
+		default:
+			return nil, err
+		}
+		timer.Reset(interval)
+	}
+}
+
+// microsoftDeviceCodeURL 获取租户的设备码地址
+func microsoftDeviceCodeURL(tenant string) string {
+	if tenant == "" {
+		tenant = defaultMicrosoftTenant
+	}
+	return fmt.Sprintf("%s/%s/oauth2/v2.0/devicecode", microsoftLoginURL, url.PathEscape(tenant))
+}
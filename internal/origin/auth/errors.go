@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ErrorCode 令牌错误码（对外返回，便于调用方按错误类型处理）
+type ErrorCode string
+
+const (
+	ErrorCodeMFARequired               ErrorCode = "MFA_REQUIRED"                // 需要多重身份验证（refreshToken 需要重新交互式授权）
+	ErrorCodeConditionalAccess         ErrorCode = "CONDITIONAL_ACCESS"          // 被租户的条件访问策略阻止
+	ErrorCodeConsentRequired           ErrorCode = "CONSENT_REQUIRED"            // 用户或管理员没有同意应用所需的权限
+	ErrorCodeRefreshTokenExpired       ErrorCode = "REFRESH_TOKEN_EXPIRED"       // refreshToken 已过期
+	ErrorCodeRefreshTokenRevoked       ErrorCode = "REFRESH_TOKEN_REVOKED"       // refreshToken 已被撤销（如修改密码）
+	ErrorCodeInvalidGrant              ErrorCode = "INVALID_GRANT"               // refreshToken 无效
+	ErrorCodeInvalidClient             ErrorCode = "INVALID_CLIENT"              // clientId、客户端密钥或证书断言无效
+	ErrorCodeClientSecretExpired       ErrorCode = "CLIENT_SECRET_EXPIRED"       // 客户端密钥已过期
+	ErrorCodeClientCredentialsRequired ErrorCode = "CLIENT_CREDENTIALS_REQUIRED" // 机密客户端需要客户端密钥或证书断言
+	ErrorCodeAppNotFound               ErrorCode = "APP_NOT_FOUND"               // 租户中找不到应用
+	ErrorCodeTenantNotFound            ErrorCode = "TENANT_NOT_FOUND"            // 租户不存在
+	ErrorCodeAccountNotInTenant        ErrorCode = "ACCOUNT_NOT_IN_TENANT"       // 账户不属于该租户（如个人账户使用 organizations）
+	ErrorCodeAccountDisabled           ErrorCode = "ACCOUNT_DISABLED"            // 账户已禁用或锁定
+	ErrorCodeUnknown                   ErrorCode = "TOKEN_ERROR"                 // 其他错误
+)
+
+// aadstsErrorCodes AADSTS 错误码与令牌错误码的对应关系
+var aadstsErrorCodes = map[int]ErrorCode{
+	50076:   ErrorCodeMFARequired,
+	50079:   ErrorCodeMFARequired,
+	50158:   ErrorCodeConditionalAccess,
+	53000:   ErrorCodeConditionalAccess,
+	53003:   ErrorCodeConditionalAccess,
+	65001:   ErrorCodeConsentRequired,
+	70008:   ErrorCodeRefreshTokenExpired,
+	700082:  ErrorCodeRefreshTokenExpired,
+	50173:   ErrorCodeRefreshTokenRevoked,
+	54005:   ErrorCodeInvalidGrant,
+	9002313: ErrorCodeInvalidGrant,
+	7000215: ErrorCodeInvalidClient,
+	700027:  ErrorCodeInvalidClient,
+	7000222: ErrorCodeClientSecretExpired,
+	7000218: ErrorCodeClientCredentialsRequired,
+	700016:  ErrorCodeAppNotFound,
+	90002:   ErrorCodeTenantNotFound,
+	50020:   ErrorCodeAccountNotInTenant,
+	50034:   ErrorCodeAccountNotInTenant,
+	9002331: ErrorCodeAccountNotInTenant,
+	50053:   ErrorCodeAccountDisabled,
+	50057:   ErrorCodeAccountDisabled,
+}
+
+// oauthErrorCodes OAuth 标准错误与令牌错误码的对应关系（没有 AADSTS 错误码时使用，如 Google）
+var oauthErrorCodes = map[string]ErrorCode{
+	"invalid_grant":        ErrorCodeInvalidGrant,
+	"invalid_client":       ErrorCodeInvalidClient,
+	"unauthorized_client":  ErrorCodeInvalidClient,
+	"consent_required":     ErrorCodeConsentRequired,
+	"interaction_required": ErrorCodeMFARequired,
+}
+
+// errorCodeMessages 令牌错误码的说明
+var errorCodeMessages = map[ErrorCode]string{
+	ErrorCodeMFARequired:               "需要多重身份验证，请重新授权获取 refreshToken",
+	ErrorCodeConditionalAccess:         "被租户的条件访问策略阻止",
+	ErrorCodeConsentRequired:           "应用权限未获同意，请用户或管理员授权",
+	ErrorCodeRefreshTokenExpired:       "refreshToken 已过期，请重新授权",
+	ErrorCodeRefreshTokenRevoked:       "refreshToken 已被撤销，请重新授权",
+	ErrorCodeInvalidGrant:              "refreshToken 无效",
+	ErrorCodeInvalidClient:             "客户端凭据无效",
+	ErrorCodeClientSecretExpired:       "客户端密钥已过期",
+	ErrorCodeClientCredentialsRequired: "机密客户端需要提供客户端密钥或证书",
+	ErrorCodeAppNotFound:               "租户中找不到该应用",
+	ErrorCodeTenantNotFound:            "租户不存在",
+	ErrorCodeAccountNotInTenant:        "账户不属于该租户",
+	ErrorCodeAccountDisabled:           "账户已禁用或锁定",
+	ErrorCodeUnknown:                   "获取令牌失败",
+}
+
+// aadstsPattern 从错误描述中提取 AADSTS 错误码（error_codes 字段缺失时使用）
+var aadstsPattern = regexp.MustCompile(`AADSTS(\d+)`)
+
+// TokenError 令牌刷新失败的错误详情
+type TokenError struct {
+	StatusCode  int       // HTTP 状态码
+	Code        ErrorCode // 令牌错误码
+	OAuthError  string    // OAuth 错误（error 字段），如 "invalid_grant"
+	Description string    // 错误描述（error_description 字段）
+	AADSTSCode  int       // AADSTS 错误码（仅微软），如 50076
+}
+
+// Error 实现 error 接口
+func (e *TokenError) Error() string {
+	message := fmt.Sprintf("[%s] %s", e.Code, errorCodeMessages[e.Code])
+	if e.AADSTSCode != 0 {
+		message += fmt.Sprintf(" (AADSTS%d)", e.AADSTSCode)
+	}
+	return fmt.Sprintf("%s (状态码: %d): %s", message, e.StatusCode, e.Description)
+}
+
+// tokenErrorResponse token endpoint 的错误响应
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	ErrorCodes       []int  `json:"error_codes"`
+}
+
+// parseTokenError 解析 token endpoint 的错误响应
+func parseTokenError(statusCode int, body []byte) *TokenError {
+	tokenErr := &TokenError{
+		StatusCode:  statusCode,
+		Code:        ErrorCodeUnknown,
+		Description: string(body),
+	}
+
+	var response tokenErrorResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return tokenErr
+	}
+
+	tokenErr.OAuthError = response.Error
+	if response.ErrorDescription != "" {
+		tokenErr.Description = response.ErrorDescription
+	}
+
+	if len(response.ErrorCodes) > 0 {
+		tokenErr.AADSTSCode = response.ErrorCodes[0]
+	} else if match := aadstsPattern.FindStringSubmatch(response.ErrorDescription); match != nil {
+		tokenErr.AADSTSCode, _ = strconv.Atoi(match[1])
+	}
+
+	if code, ok := aadstsErrorCodes[tokenErr.AADSTSCode]; ok {
+		tokenErr.Code = code
+	} else if code, ok := oauthErrorCodes[response.Error]; ok {
+		tokenErr.Code = code
+	}
+
+	return tokenErr
+}
+
+// GetErrorCode 获取错误链中的令牌错误码，不是令牌错误时返回空字符串
+func GetErrorCode(err error) ErrorCode {
+	var tokenErr *TokenError
+	if errors.As(err, &tokenErr) {
+		return tokenErr.Code
+	}
+	return ""
+}
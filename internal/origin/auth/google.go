@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"net/url"
+
+	"gomailapi2/internal/types"
+)
+
+// googleTokenURL Google 的 token endpoint
+const googleTokenURL = "https://oauth2.googleapis.com/token"
+
+// getGoogleTokens 通过 Google 的 token endpoint 刷新令牌
+// Google 的 refreshToken 不会轮换，响应中通常不包含 refresh_token，此时返回原来的 refreshToken
+func getGoogleTokens(mailInfo *types.MailInfo) (*TokenResponse, error) {
+	// 构建请求参数（Google 的 Web/桌面应用都需要 client_secret）
+	data := url.Values{}
+	data.Set("client_id", mailInfo.ClientID)
+	if mailInfo.ClientSecret != "" {
+		data.Set("client_secret", mailInfo.ClientSecret)
+	}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", mailInfo.RefreshToken)
+
+	tokenResp, err := requestTokens(mailInfo, googleTokenURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenResp.RefreshToken == "" {
+		tokenResp.RefreshToken = mailInfo.RefreshToken
+	}
+
+	return tokenResp, nil
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"gomailapi2/internal/types"
+)
+
+const (
+	// microsoftLoginURL 微软身份平台地址
+	microsoftLoginURL = "https://login.microsoftonline.com"
+	// defaultMicrosoftTenant 未指定租户时使用的租户（个人账户）
+	defaultMicrosoftTenant = "consumers"
+	// clientAssertionType 证书断言类型
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	// clientAssertionLifetime 证书断言有效期
+	clientAssertionLifetime = 10 * time.Minute
+	// graphScope Graph API 的权限（应用注册时配置的所有 Graph 权限）
+	graphScope = "https://graph.microsoft.com/.default"
+	// ewsScope Exchange Online EWS 的权限（同时申请 offline_access 以获取新的 refreshToken）
+	ewsScope = "https://outlook.office365.com/EWS.AccessAsUser.All offline_access"
+)
+
+// microsoftTokenURL 获取租户的 token endpoint
+// tenant 可以是租户 ID、租户域名，或 common（所有账户）、organizations（工作/学校账户）、consumers（个人账户）
+func microsoftTokenURL(tenant string) string {
+	if tenant == "" {
+		tenant = defaultMicrosoftTenant
+	}
+	return fmt.Sprintf("%s/%s/oauth2/v2.0/token", microsoftLoginURL, url.PathEscape(tenant))
+}
+
+// setMicrosoftClientCredentials 为机密客户端设置客户端凭据：优先使用客户端密钥，其次使用证书断言，都没有时按公共客户端处理
+func setMicrosoftClientCredentials(data url.Values, mailInfo *types.MailInfo, tokenURL string) error {
+	if mailInfo.ClientSecret != "" {
+		data.Set("client_secret", mailInfo.ClientSecret)
+		return nil
+	}
+
+	if mailInfo.ClientCertificate == "" && mailInfo.ClientPrivateKey == "" {
+		return nil
+	}
+
+	// 私钥为空时从证书内容中查找（证书和私钥放在同一个 PEM 中）
+	privateKeyPEM := mailInfo.ClientPrivateKey
+	if privateKeyPEM == "" {
+		privateKeyPEM = mailInfo.ClientCertificate
+	}
+
+	assertion, err := buildClientAssertion(mailInfo.ClientID, tokenURL, mailInfo.ClientCertificate, privateKeyPEM)
+	if err != nil {
+		return fmt.Errorf("生成证书断言失败: %w", err)
+	}
+
+	data.Set("client_assertion_type", clientAssertionType)
+	data.Set("client_assertion", assertion)
+	return nil
+}
+
+// buildClientAssertion 使用证书私钥签名生成客户端断言（RS256 JWT），x5t 和 x5t#S256 分别为证书的 SHA-1 和 SHA-256 指纹
+func buildClientAssertion(clientID, tokenURL, certificatePEM, privateKeyPEM string) (string, error) {
+	certificate, err := parseCertificate(certificatePEM)
+	if err != nil {
+		return "", err
+	}
+
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	sha1Thumbprint := sha1.Sum(certificate.Raw)
+	sha256Thumbprint := sha256.Sum256(certificate.Raw)
+	header := map[string]string{
+		"alg":      "RS256",
+		"typ":      "JWT",
+		"x5t":      base64.RawURLEncoding.EncodeToString(sha1Thumbprint[:]),
+		"x5t#S256": base64.RawURLEncoding.EncodeToString(sha256Thumbprint[:]),
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("生成断言 ID 失败: %w", err)
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"aud": tokenURL,
+		"iss": clientID,
+		"sub": clientID,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("签名失败: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseCertificate 解析 PEM 格式的证书（内容中可以同时包含私钥）
+func parseCertificate(certificatePEM string) (*x509.Certificate, error) {
+	rest := []byte(certificatePEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("未找到 PEM 格式的证书")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析证书失败: %w", err)
+		}
+		return certificate, nil
+	}
+}
+
+// parseRSAPrivateKey 解析 PEM 格式的 RSA 私钥（支持 PKCS#1 和 PKCS#8，内容中可以同时包含证书）
+func parseRSAPrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	rest := []byte(privateKeyPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("未找到 PEM 格式的私钥")
+		}
+
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("解析私钥失败: %w", err)
+			}
+			return privateKey, nil
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("解析私钥失败: %w", err)
+			}
+			privateKey, ok := key.(*rsa.PrivateKey)
+			if !ok {
+				return nil, errors.New("证书断言只支持 RSA 私钥")
+			}
+			return privateKey, nil
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/url"
+
+	"gomailapi2/internal/types"
+)
+
+// Yahoo 和 AOL 的 token endpoint（AOL 与 Yahoo 使用同一套身份平台）
+const (
+	yahooTokenURL = "https://api.login.yahoo.com/oauth2/get_token"
+	aolTokenURL   = "https://api.login.aol.com/oauth2/get_token"
+)
+
+// getYahooTokens 通过 Yahoo/AOL 的 token endpoint 刷新令牌
+// 刷新时需要 client_secret；响应中没有 refresh_token 时返回原来的 refreshToken
+func getYahooTokens(mailInfo *types.MailInfo, tokenURL string) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("client_id", mailInfo.ClientID)
+	if mailInfo.ClientSecret != "" {
+		data.Set("client_secret", mailInfo.ClientSecret)
+	}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", mailInfo.RefreshToken)
+	// Yahoo 要求 redirect_uri，刷新令牌时使用 oob 即可
+	data.Set("redirect_uri", "oob")
+
+	tokenResp, err := requestTokens(mailInfo, tokenURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenResp.RefreshToken == "" {
+		tokenResp.RefreshToken = mailInfo.RefreshToken
+	}
+
+	return tokenResp, nil
+}
@@ -0,0 +1,49 @@
+package token
+
+import (
+	"errors"
+	"sync"
+)
+
+// errFlightAborted 执行刷新的调用异常退出（panic）时，等待中的调用收到的错误
+var errFlightAborted = errors.New("令牌刷新异常中止")
+
+// flightCall 正在执行的调用
+type flightCall[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// flightGroup 合并相同键的并发调用：同一时间每个键只有一个调用在执行，其他调用等待并共享它的结果
+type flightGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+// Do 执行 fn 并返回结果；相同键的调用正在执行时不再执行 fn，等待并返回该调用的结果
+func (g *flightGroup[T]) Do(key string, fn func() (T, error)) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall[T])
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.val, call.err
+	}
+
+	call := &flightCall[T]{done: make(chan struct{}), err: errFlightAborted}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.val, call.err = fn()
+	return call.val, call.err
+}
@@ -0,0 +1,149 @@
+package token
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitJoined 等待 n 个调用进入 Do，再留出时间让它们开始等待正在执行的调用
+func waitJoined(t *testing.T, entered *atomic.Int32, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for entered.Load() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("等待调用进入 Do 超时: %d/%d", entered.Load(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestFlightGroupDo(t *testing.T) {
+	errRefresh := errors.New("refresh failed")
+
+	tests := []struct {
+		name      string
+		keys      []string // 每个并发调用使用的键
+		err       error    // fn 返回的错误
+		wantCalls int32    // fn 的执行次数
+	}{
+		{"相同键的调用合并", []string{"a", "a", "a", "a", "a"}, nil, 1},
+		{"相同键共享错误", []string{"a", "a", "a"}, errRefresh, 1},
+		{"不同键分别执行", []string{"a", "b", "c"}, nil, 3},
+		{"混合的键", []string{"a", "b", "a", "b"}, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g flightGroup[string]
+			var calls, entered atomic.Int32
+			release := make(chan struct{})
+
+			type result struct {
+				key, val string
+				err      error
+			}
+			results := make(chan result, len(tt.keys))
+
+			var wg sync.WaitGroup
+			for _, key := range tt.keys {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					entered.Add(1)
+					val, err := g.Do(key, func() (string, error) {
+						calls.Add(1)
+						<-release
+						return "token-" + key, tt.err
+					})
+					results <- result{key, val, err}
+				}()
+			}
+
+			waitJoined(t, &entered, int32(len(tt.keys)))
+			close(release)
+			wg.Wait()
+			close(results)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("fn 执行了 %d 次, want %d", got, tt.wantCalls)
+			}
+			for r := range results {
+				if !errors.Is(r.err, tt.err) {
+					t.Errorf("Do(%q) error = %v, want %v", r.key, r.err, tt.err)
+				}
+				if r.val != "token-"+r.key {
+					t.Errorf("Do(%q) = %q, want %q", r.key, r.val, "token-"+r.key)
+				}
+			}
+		})
+	}
+}
+
+func TestFlightGroupDoSequential(t *testing.T) {
+	var g flightGroup[int]
+	var calls int
+
+	// 调用结束后不保留结果，之后的调用重新执行
+	for i := 1; i <= 3; i++ {
+		val, err := g.Do("a", func() (int, error) {
+			calls++
+			return calls, nil
+		})
+		if err != nil || val != i {
+			t.Fatalf("第 %d 次 Do() = %d, %v, want %d", i, val, err, i)
+		}
+	}
+}
+
+func TestFlightGroupDoPanic(t *testing.T) {
+	var g flightGroup[string]
+	var entered atomic.Int32
+	release := make(chan struct{})
+
+	// 执行刷新的调用 panic，panic 继续向上传递
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() { panicked <- recover() }()
+		g.Do("a", func() (string, error) {
+			entered.Add(1)
+			<-release
+			panic("refresh panic")
+		})
+	}()
+	waitJoined(t, &entered, 1)
+
+	// 等待中的调用收到 errFlightAborted，而不是永远阻塞或拿到空结果
+	waiterErr := make(chan error, 1)
+	go func() {
+		entered.Add(1)
+		_, err := g.Do("a", func() (string, error) {
+			t.Error("等待中的调用不应执行 fn")
+			return "", nil
+		})
+		waiterErr <- err
+	}()
+	waitJoined(t, &entered, 2)
+	close(release)
+
+	if r := <-panicked; r != "refresh panic" {
+		t.Errorf("recover() = %v, want %q", r, "refresh panic")
+	}
+	select {
+	case err := <-waiterErr:
+		if !errors.Is(err, errFlightAborted) {
+			t.Errorf("等待中的调用 error = %v, want errFlightAborted", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待中的调用没有返回")
+	}
+
+	// panic 后键被释放，之后的调用正常执行
+	val, err := g.Do("a", func() (string, error) { return "token", nil })
+	if err != nil || val != "token" {
+		t.Errorf("panic 后 Do() = %q, %v, want %q", val, err, "token")
+	}
+}
@@ -0,0 +1,288 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gomailapi2/internal/cache/tokencache"
+	"gomailapi2/internal/config"
+	"gomailapi2/internal/origin/auth"
+	"gomailapi2/internal/types"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// 后台刷新的默认配置（配置值无效时使用）
+const (
+	defaultRefreshInterval    = time.Minute
+	defaultRefreshBefore      = 5 * time.Minute
+	defaultRefreshConcurrency = 8
+	defaultRefreshIdleTimeout = 2 * time.Hour
+	defaultRefreshMaxAccounts = 1000
+)
+
+// trackedAccount 后台刷新跟踪的账户
+type trackedAccount struct {
+	mailInfo types.MailInfo
+	cacheKey tokencache.TokenKey
+	lastUsed atomic.Int64 // 最近一次使用的时间（UnixNano）
+}
+
+// RefresherStats 后台刷新的统计数据
+type RefresherStats struct {
+	Enabled         bool      `json:"enabled"`
+	TrackedAccounts int       `json:"trackedAccounts"` // 当前跟踪的账户数
+	Runs            uint64    `json:"runs"`            // 检查次数
+	Refreshed       uint64    `json:"refreshed"`       // 刷新成功次数
+	Failed          uint64    `json:"failed"`          // 刷新失败次数
+	Evicted         uint64    `json:"evicted"`         // 因长时间未使用、超出容量或 refreshToken 失效而不再跟踪的账户数
+	LastRunAt       time.Time `json:"lastRunAt"`       // 最近一次检查的时间
+	LastRunDuration string    `json:"lastRunDuration"` // 最近一次检查的耗时
+}
+
+// Refresher access token 后台刷新器
+// 跟踪最近使用过的账户，在缓存的 access token 过期前提前刷新，使请求始终命中缓存
+type Refresher struct {
+	provider *TokenProvider
+	cfg      config.RefreshConfig
+	accounts *lru.Cache[string, *trackedAccount] // key: 缓存键
+
+	runs      atomic.Uint64
+	refreshed atomic.Uint64
+	failed    atomic.Uint64
+	evicted   atomic.Uint64
+
+	mu              sync.Mutex
+	lastRunAt       time.Time
+	lastRunDuration time.Duration
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+	done     chan struct{}
+}
+
+// EnableRefresher 按配置创建并启动后台刷新器（应在启动时调用），未启用时返回 nil
+func (p *TokenProvider) EnableRefresher(cfg config.RefreshConfig) (*Refresher, error) {
+	if !cfg.Enabled {
+		log.Info().Msg("access token 后台刷新未启用")
+		return nil, nil
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultRefreshInterval
+	}
+	if cfg.Before <= 0 {
+		cfg.Before = defaultRefreshBefore
+	}
+	if cfg.Jitter < 0 {
+		cfg.Jitter = 0
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultRefreshConcurrency
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultRefreshIdleTimeout
+	}
+	if cfg.MaxAccounts <= 0 {
+		cfg.MaxAccounts = defaultRefreshMaxAccounts
+	}
+
+	r := &Refresher{
+		provider: p,
+		cfg:      cfg,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	accounts, err := lru.NewWithEvict(cfg.MaxAccounts, func(string, *trackedAccount) {
+		r.evicted.Add(1)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建后台刷新账户列表失败: %w", err)
+	}
+	r.accounts = accounts
+
+	p.refresher = r
+	go r.run()
+
+	log.Info().
+		Dur("interval", cfg.Interval).
+		Dur("before", cfg.Before).
+		Dur("jitter", cfg.Jitter).
+		Int("concurrency", cfg.Concurrency).
+		Int("maxAccounts", cfg.MaxAccounts).
+		Msg("access token 后台刷新已启动")
+
+	return r, nil
+}
+
+// Stop 停止后台刷新，等待正在进行的检查结束
+func (r *Refresher) Stop() {
+	if r == nil {
+		return
+	}
+	r.stopOnce.Do(func() {
+		close(r.stopChan)
+		<-r.done
+		log.Info().Msg("access token 后台刷新已停止")
+	})
+}
+
+// Stats 获取后台刷新的统计数据
+func (r *Refresher) Stats() RefresherStats {
+	if r == nil {
+		return RefresherStats{}
+	}
+
+	r.mu.Lock()
+	lastRunAt, lastRunDuration := r.lastRunAt, r.lastRunDuration
+	r.mu.Unlock()
+
+	return RefresherStats{
+		Enabled:         true,
+		TrackedAccounts: r.accounts.Len(),
+		Runs:            r.runs.Load(),
+		Refreshed:       r.refreshed.Load(),
+		Failed:          r.failed.Load(),
+		Evicted:         r.evicted.Load(),
+		LastRunAt:       lastRunAt,
+		LastRunDuration: lastRunDuration.String(),
+	}
+}
+
+// RefresherStats 获取后台刷新的统计数据（未启用时 Enabled 为 false）
+func (p *TokenProvider) RefresherStats() RefresherStats {
+	return p.refresher.Stats()
+}
+
+// track 记录账户的使用，未启用后台刷新时不做任何事
+func (r *Refresher) track(mailInfo *types.MailInfo, cacheKey tokencache.TokenKey) {
+	if r == nil {
+		return
+	}
+
+	now := time.Now().UnixNano()
+	key := cacheKey.String()
+	if account, ok := r.accounts.Get(key); ok {
+		account.lastUsed.Store(now)
+		return
+	}
+
+	account := &trackedAccount{mailInfo: *mailInfo, cacheKey: cacheKey}
+	account.lastUsed.Store(now)
+	r.accounts.Add(key, account)
+}
+
+// run 按间隔检查跟踪的账户
+func (r *Refresher) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+			r.refreshDue()
+		}
+	}
+}
+
+// refreshDue 清理长时间未使用的账户，并刷新即将过期的 access token
+func (r *Refresher) refreshDue() {
+	start := time.Now()
+	idleBefore := start.Add(-r.cfg.IdleTimeout).UnixNano()
+
+	sem := make(chan struct{}, r.cfg.Concurrency)
+	var wg sync.WaitGroup
+	var due int
+
+	for _, key := range r.accounts.Keys() {
+		// Peek 不改变账户在 LRU 中的顺序，只有请求才算使用
+		account, ok := r.accounts.Peek(key)
+		if !ok {
+			continue
+		}
+
+		if account.lastUsed.Load() < idleBefore {
+			r.accounts.Remove(key)
+			continue
+		}
+
+		// 每个账户使用不同的随机偏移，分散刷新时间
+		threshold := r.cfg.Before
+		if r.cfg.Jitter > 0 {
+			threshold += rand.N(r.cfg.Jitter)
+		}
+		if _, ttl, err := r.provider.cache.GetAccessTokenWithTTL(account.cacheKey); err == nil && ttl > threshold {
+			continue
+		}
+
+		select {
+		case <-r.stopChan:
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+
+		due++
+		wg.Add(1)
+		go func(key string, account *trackedAccount) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.refreshAccount(key, account, threshold)
+		}(key, account)
+	}
+	wg.Wait()
+
+	duration := time.Since(start)
+	r.runs.Add(1)
+	r.mu.Lock()
+	r.lastRunAt = start
+	r.lastRunDuration = duration
+	r.mu.Unlock()
+
+	if due > 0 {
+		log.Debug().
+			Int("tracked", r.accounts.Len()).
+			Int("due", due).
+			Dur("duration", duration).
+			Msg("access token 后台刷新完成")
+	}
+}
+
+// refreshAccount 刷新单个账户的 access token，refreshToken 失效等不可重试的错误发生时不再跟踪该账户
+func (r *Refresher) refreshAccount(key string, account *trackedAccount, threshold time.Duration) {
+	mailInfo := &account.mailInfo
+
+	// 与请求共享同一次刷新：请求恰好缓存未命中时直接使用后台刷新的结果，反之亦然
+	_, err := r.provider.refreshShared(mailInfo, account.cacheKey, threshold)
+	if err == nil {
+		r.refreshed.Add(1)
+		return
+	}
+
+	r.failed.Add(1)
+
+	var tokenErr *auth.TokenError
+	if errors.As(err, &tokenErr) && tokenErr.Code != auth.ErrorCodeUnknown {
+		r.accounts.Remove(key)
+		log.Warn().
+			Err(err).
+			Str("email", mailInfo.Email).
+			Msg("后台刷新 access token 失败，不再跟踪该账户")
+		return
+	}
+
+	log.Warn().
+		Err(err).
+		Str("email", mailInfo.Email).
+		Msg("后台刷新 access token 失败，下次检查时重试")
+}
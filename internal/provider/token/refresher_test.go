@@ -0,0 +1,173 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gomailapi2/internal/cache/tokencache"
+	"gomailapi2/internal/config"
+	"gomailapi2/internal/types"
+)
+
+// newTestRefresher 创建使用本地缓存的后台刷新器，检查间隔足够长，测试中手动调用 refreshDue
+func newTestRefresher(t *testing.T, maxAccounts int) (*TokenProvider, *Refresher) {
+	t.Helper()
+	cache, err := tokencache.NewLocalCache(100)
+	if err != nil {
+		t.Fatalf("NewLocalCache 失败: %v", err)
+	}
+	provider := NewTokenProvider(cache)
+	r, err := provider.EnableRefresher(config.RefreshConfig{
+		Enabled:     true,
+		Interval:    time.Hour,
+		Before:      5 * time.Minute,
+		IdleTimeout: time.Hour,
+		MaxAccounts: maxAccounts,
+	})
+	if err != nil {
+		t.Fatalf("EnableRefresher 失败: %v", err)
+	}
+	t.Cleanup(r.Stop)
+	return provider, r
+}
+
+// testRefresherMailInfo 测试使用的账户
+func testRefresherMailInfo(email string, protocolType types.ProtocolType) *types.MailInfo {
+	return &types.MailInfo{
+		Email:           email,
+		ServiceProvider: types.ServiceProviderMicrosoft,
+		ClientID:        "client-1",
+		RefreshToken:    "rt-" + email,
+		ProtocolType:    protocolType,
+	}
+}
+
+func TestRefresherRefreshDue(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare 在 refreshDue 之前准备账户的状态
+		prepare     func(t *testing.T, provider *TokenProvider, account *trackedAccount)
+		protocol    types.ProtocolType
+		wantTracked bool
+		wantStats   RefresherStats
+	}{
+		{
+			name:     "长时间未使用的账户不再跟踪",
+			protocol: types.ProtocolTypeIMAP,
+			prepare: func(_ *testing.T, _ *TokenProvider, account *trackedAccount) {
+				account.lastUsed.Store(time.Now().Add(-2 * time.Hour).UnixNano())
+			},
+			wantTracked: false,
+			wantStats:   RefresherStats{Evicted: 1},
+		},
+		{
+			name:     "缓存剩余时间充足时不刷新",
+			protocol: types.ProtocolTypeIMAP,
+			prepare: func(t *testing.T, provider *TokenProvider, account *trackedAccount) {
+				if err := provider.cache.SetAccessToken(account.cacheKey, "access", time.Hour); err != nil {
+					t.Fatalf("SetAccessToken 失败: %v", err)
+				}
+			},
+			wantTracked: true,
+		},
+		{
+			// JMAP 账户不支持令牌刷新，返回的不是令牌错误，下次检查时重试
+			name:        "刷新失败但可以重试时继续跟踪",
+			protocol:    types.ProtocolTypeJMAP,
+			prepare:     func(*testing.T, *TokenProvider, *trackedAccount) {},
+			wantTracked: true,
+			wantStats:   RefresherStats{Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, r := newTestRefresher(t, 10)
+			mailInfo := testRefresherMailInfo("user@outlook.com", tt.protocol)
+			cacheKey := accessTokenCacheKey(mailInfo)
+			r.track(mailInfo, cacheKey)
+
+			account, ok := r.accounts.Peek(cacheKey.String())
+			if !ok {
+				t.Fatal("track 没有记录账户")
+			}
+			tt.prepare(t, provider, account)
+
+			r.refreshDue()
+
+			if got := r.accounts.Contains(cacheKey.String()); got != tt.wantTracked {
+				t.Errorf("账户仍被跟踪 = %v, want %v", got, tt.wantTracked)
+			}
+			stats := r.Stats()
+			if stats.Runs != 1 {
+				t.Errorf("Runs = %d, want 1", stats.Runs)
+			}
+			if stats.Evicted != tt.wantStats.Evicted || stats.Failed != tt.wantStats.Failed || stats.Refreshed != tt.wantStats.Refreshed {
+				t.Errorf("Stats() = %+v, want Evicted %d, Failed %d, Refreshed %d",
+					stats, tt.wantStats.Evicted, tt.wantStats.Failed, tt.wantStats.Refreshed)
+			}
+		})
+	}
+}
+
+func TestRefresherMaxAccounts(t *testing.T) {
+	const maxAccounts = 2
+	_, r := newTestRefresher(t, maxAccounts)
+
+	var keys []tokencache.TokenKey
+	for i := range maxAccounts + 1 {
+		mailInfo := testRefresherMailInfo(fmt.Sprintf("user%d@outlook.com", i), types.ProtocolTypeIMAP)
+		key := accessTokenCacheKey(mailInfo)
+		r.track(mailInfo, key)
+		keys = append(keys, key)
+
+		// 再次使用第一个账户，淘汰时跳过它
+		if i == 1 {
+			r.track(testRefresherMailInfo("user0@outlook.com", types.ProtocolTypeIMAP), keys[0])
+		}
+	}
+
+	stats := r.Stats()
+	if stats.TrackedAccounts != maxAccounts || stats.Evicted != 1 {
+		t.Errorf("Stats() = %+v, want TrackedAccounts %d, Evicted 1", stats, maxAccounts)
+	}
+
+	tests := []struct {
+		name        string
+		key         tokencache.TokenKey
+		wantTracked bool
+	}{
+		{"最近使用过的账户", keys[0], true},
+		{"最久未使用的账户", keys[1], false},
+		{"新账户", keys[2], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.accounts.Contains(tt.key.String()); got != tt.wantTracked {
+				t.Errorf("账户仍被跟踪 = %v, want %v", got, tt.wantTracked)
+			}
+		})
+	}
+}
+
+func TestRefresherDisabled(t *testing.T) {
+	cache, err := tokencache.NewLocalCache(10)
+	if err != nil {
+		t.Fatalf("NewLocalCache 失败: %v", err)
+	}
+	provider := NewTokenProvider(cache)
+
+	r, err := provider.EnableRefresher(config.RefreshConfig{Enabled: false})
+	if err != nil || r != nil {
+		t.Fatalf("EnableRefresher() = %v, %v, want nil, nil", r, err)
+	}
+
+	// 未启用时 track、Stats 和 Stop 不做任何事
+	mailInfo := testRefresherMailInfo("user@outlook.com", types.ProtocolTypeIMAP)
+	provider.refresher.track(mailInfo, accessTokenCacheKey(mailInfo))
+	if stats := provider.RefresherStats(); stats.Enabled {
+		t.Errorf("RefresherStats().Enabled = true, want false")
+	}
+	provider.refresher.Stop()
+}
@@ -0,0 +1,173 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gomailapi2/internal/store/tokenstore"
+	"gomailapi2/internal/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrTokenStoreDisabled 未启用 refresh token 存储时使用账户 ID 返回的错误
+var ErrTokenStoreDisabled = errors.New("未启用 refresh token 存储，不能使用 accountId")
+
+// SetTokenStore 设置 refresh token 存储（应在启动时调用）
+// autoRegister 为 true 时 refreshToken 变更会自动保存未注册的账户，否则只保存已注册账户的变更
+func (p *TokenProvider) SetTokenStore(store tokenstore.Store, autoRegister bool) {
+	p.store = store
+	p.autoRegister = autoRegister
+}
+
+// ResolveAccount 使用已保存的账户信息填充 mailInfo（mailInfo.AccountID 为空时不做任何事），需要提供账户的访问密钥
+// 请求中的 protocolType 不为空时保留，以便同一账户使用不同协议
+func (p *TokenProvider) ResolveAccount(mailInfo *types.MailInfo) error {
+	if mailInfo == nil || mailInfo.AccountID == "" {
+		return nil
+	}
+	if p.store == nil {
+		return ErrTokenStoreDisabled
+	}
+
+	ctx := context.Background()
+	if err := p.store.Authenticate(ctx, mailInfo.AccountID, mailInfo.AccountSecret); err != nil {
+		return fmt.Errorf("读取账户 %s 失败: %w", mailInfo.AccountID, err)
+	}
+	_, stored, err := p.store.Get(ctx, mailInfo.AccountID)
+	if err != nil {
+		return fmt.Errorf("读取账户 %s 失败: %w", mailInfo.AccountID, err)
+	}
+
+	protocolType := mailInfo.ProtocolType
+	*mailInfo = *stored
+	if protocolType != "" {
+		mailInfo.ProtocolType = protocolType
+	}
+	return nil
+}
+
+// RegisterAccount 保存账户，返回账户 ID 等基本信息和访问密钥（密钥只在保存时返回）
+// 带有账户 ID 和密钥，或 refreshToken 是已保存账户当前的 refreshToken 时更新该账户，账户 ID 不变，之前的密钥失效
+func (p *TokenProvider) RegisterAccount(mailInfo *types.MailInfo) (*tokenstore.Account, string, error) {
+	if p.store == nil {
+		return nil, "", ErrTokenStoreDisabled
+	}
+	if mailInfo.Email == "" {
+		return nil, "", errors.New("email 不能为空")
+	}
+	if !mailInfo.UsesPasswordAuth() && mailInfo.RefreshToken == "" {
+		return nil, "", errors.New("refreshToken 不能为空")
+	}
+
+	account, secret, err := p.store.Register(context.Background(), mailInfo)
+	if err != nil {
+		return nil, "", err
+	}
+
+	log.Info().
+		Str("email", mailInfo.Email).
+		Str("accountId", account.ID).
+		Msg("账户已保存")
+
+	return account, secret, nil
+}
+
+// GetAccount 获取已保存账户的基本信息，需要提供账户的访问密钥
+func (p *TokenProvider) GetAccount(id, secret string) (*tokenstore.Account, error) {
+	if err := p.authenticate(id, secret); err != nil {
+		return nil, err
+	}
+	account, _, err := p.store.Get(context.Background(), id)
+	return account, err
+}
+
+// AccountHistory 获取已保存账户的 refreshToken 变更记录（按时间倒序），需要提供账户的访问密钥
+func (p *TokenProvider) AccountHistory(id, secret string) ([]tokenstore.Rotation, error) {
+	if err := p.authenticate(id, secret); err != nil {
+		return nil, err
+	}
+	return p.store.History(context.Background(), id)
+}
+
+// DeleteAccount 删除已保存的账户及其变更记录，需要提供账户的访问密钥
+func (p *TokenProvider) DeleteAccount(id, secret string) error {
+	if err := p.authenticate(id, secret); err != nil {
+		return err
+	}
+	if err := p.store.Delete(context.Background(), id); err != nil {
+		return err
+	}
+
+	log.Info().Str("accountId", id).Msg("账户已删除")
+	return nil
+}
+
+// authenticate 校验账户的访问密钥
+func (p *TokenProvider) authenticate(id, secret string) error {
+	if p.store == nil {
+		return ErrTokenStoreDisabled
+	}
+	return p.store.Authenticate(context.Background(), id, secret)
+}
+
+// persistRotation 保存变更后的 refreshToken，返回是否已保存
+// 请求使用账户 ID 时更新该账户，否则根据旧的 refreshToken 查找账户；找不到时按 autoRegister 决定是否保存为新账户
+// 保存失败不影响返回结果（新的 refreshToken 仍然返回给调用方），只记录日志
+func (p *TokenProvider) persistRotation(mailInfo *types.MailInfo, newRefreshToken, source string) bool {
+	if p.store == nil || newRefreshToken == "" || newRefreshToken == mailInfo.RefreshToken {
+		return false
+	}
+
+	ctx := context.Background()
+	id := mailInfo.AccountID
+	if id == "" {
+		var err error
+		id, err = p.store.Lookup(ctx, mailInfo.RefreshToken)
+		switch {
+		case err == nil:
+		case !errors.Is(err, tokenstore.ErrAccountNotFound):
+			log.Warn().Err(err).Str("email", mailInfo.Email).Msg("查找已保存的账户失败，未保存新的 refreshToken")
+			return false
+		case !p.autoRegister:
+			return false
+		default:
+			// 先保存旧的 refreshToken，之后仍使用旧 refreshToken 的请求可以找到同一个账户
+			// 自动保存的账户没有可用的访问密钥，使用账户 ID 前需要调用方重新注册（获取密钥）
+			account, _, err := p.store.Register(ctx, mailInfo)
+			if err != nil {
+				log.Error().Err(err).Str("email", mailInfo.Email).Msg("自动保存账户失败")
+				return false
+			}
+			id = account.ID
+		}
+	}
+
+	// 已注册的账户只更新 refreshToken（请求中的 protocolType 可能与保存的不同）
+	_, stored, err := p.store.Get(ctx, id)
+	if err != nil {
+		log.Warn().Err(err).Str("email", mailInfo.Email).Msg("读取已保存的账户失败，未保存新的 refreshToken")
+		return false
+	}
+	updated := *stored
+	updated.RefreshToken = newRefreshToken
+	account, err := p.store.Save(ctx, &updated, source)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("email", mailInfo.Email).
+			Str("accountId", id).
+			Msg("保存新的 refreshToken 失败")
+		return false
+	}
+
+	log.Info().
+		Str("email", mailInfo.Email).
+		Str("accountId", id).
+		Str("fingerprint", account.Fingerprint).
+		Int("rotations", account.Rotations).
+		Msg("已保存新的 refreshToken")
+
+	return true
+}
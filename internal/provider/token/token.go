@@ -1,19 +1,67 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"gomailapi2/internal/cache/tokencache"
 	"gomailapi2/internal/origin/auth"
+	"gomailapi2/internal/store/tokenstore"
 	"gomailapi2/internal/types"
 
 	"github.com/rs/zerolog/log"
 )
 
+// 分布式刷新锁参数（仅 Redis 缓存）
+const (
+	// refreshLockTTL 刷新锁的过期时间，持有锁的实例异常退出时锁自动释放；也是等待其他实例刷新的最长时间
+	refreshLockTTL = 30 * time.Second
+	// refreshWaitInterval 其他实例正在刷新时检查缓存的间隔
+	refreshWaitInterval = 200 * time.Millisecond
+)
+
 // TokenProvider 协调缓存和原始数据层的 token 提供者
 type TokenProvider struct {
 	cache tokencache.Cache
+
+	// 合并同一账户的并发刷新（GetAccessToken、GetBothTokens 和后台刷新共用），所有等待的请求共享同一次刷新的结果
+	flight flightGroup[*refreshResult]
+
+	// 后台刷新器（未启用时为 nil）
+	refresher *Refresher
+
+	// refresh token 存储（未启用时为 nil）
+	store        tokenstore.Store
+	autoRegister bool
+}
+
+// refreshKind 刷新的类型
+type refreshKind int
+
+const (
+	refreshAccess refreshKind = iota // 获取 access token（GetAccessToken、后台刷新）
+	refreshBoth                      // 同时获取 access token 和新的 refresh token（GetBothTokens）
+	refreshScoped                    // 使用 Graph scope 刷新并返回完整的令牌响应（协议检测）
+)
+
+// refreshResult 一次刷新的结果
+type refreshResult struct {
+	kind     refreshKind
+	cacheKey tokencache.TokenKey // 刷新的 access token 的缓存键（协议对应的 scope）
+	resp     *auth.TokenResponse
+}
+
+// satisfies 本次刷新的结果能否直接用于 kind 类型、缓存键为 cacheKey 的刷新
+func (r *refreshResult) satisfies(kind refreshKind, cacheKey tokencache.TokenKey) bool {
+	switch kind {
+	case refreshAccess:
+		return (r.kind == refreshAccess || r.kind == refreshBoth) && r.cacheKey == cacheKey
+	case refreshBoth:
+		return r.kind == refreshBoth && r.cacheKey == cacheKey
+	default:
+		return r.kind == kind
+	}
 }
 
 // NewTokenProvider 创建新的 TokenProvider 实例
@@ -30,38 +78,147 @@ func (p *TokenProvider) GetAccessToken(mailInfo *types.MailInfo) (string, error)
 		Str("protocol", string(mailInfo.ProtocolType)).
 		Msg("开始获取 access token")
 
+	// 密码认证账户不需要访问令牌，只校验配置
+	if mailInfo.UsesPasswordAuth() {
+		return "", mailInfo.ValidatePasswordAuth()
+	}
+
 	// 1. 尝试从缓存获取
-	if token, err := p.cache.GetAccessToken(mailInfo.RefreshToken); err == nil && token != "" {
+	cacheKey := accessTokenCacheKey(mailInfo)
+	if token, err := p.cache.GetAccessToken(cacheKey); err == nil && token != "" {
 		log.Debug().
 			Str("email", mailInfo.Email).
 			Msg("从缓存获取到 access token")
+		p.refresher.track(mailInfo, cacheKey)
 		return token, nil
 	}
 
-	// 2. 缓存未命中，调用原始数据层
+	// 2. 缓存未命中，刷新 access token（同一账户的并发请求只刷新一次）
+	tokenResp, err := p.refreshShared(mailInfo, cacheKey, 0)
+	if err != nil {
+		return "", err
+	}
+	p.refresher.track(mailInfo, cacheKey)
+	return tokenResp.AccessToken, nil
+}
+
+// refreshShared 在账户的合并组中刷新 access token
+func (p *TokenProvider) refreshShared(mailInfo *types.MailInfo, cacheKey tokencache.TokenKey, minTTL time.Duration) (*auth.TokenResponse, error) {
+	return p.doFlight(mailInfo, refreshAccess, cacheKey, func() (*auth.TokenResponse, error) {
+		return p.refreshAccessToken(mailInfo, cacheKey, minTTL)
+	})
+}
+
+// doFlight 在账户的合并组中执行刷新：同一账户同一时间只有一次刷新在执行，所有等待的请求共享它的结果
+// 正在执行的刷新类型不同或属于其他协议（scope）时，等待它结束后再执行
+func (p *TokenProvider) doFlight(mailInfo *types.MailInfo, kind refreshKind, cacheKey tokencache.TokenKey, fn func() (*auth.TokenResponse, error)) (*auth.TokenResponse, error) {
+	for {
+		result, err := p.flight.Do(accountKey(mailInfo).String(), func() (*refreshResult, error) {
+			resp, err := fn()
+			return &refreshResult{kind: kind, cacheKey: cacheKey, resp: resp}, err
+		})
+		if result != nil && !result.satisfies(kind, cacheKey) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result.resp, nil
+	}
+}
+
+// refreshAccessToken 刷新 access token，缓存中剩余时间超过 minTTL 的令牌视为已刷新（由其他请求或实例完成）
+func (p *TokenProvider) refreshAccessToken(mailInfo *types.MailInfo, cacheKey tokencache.TokenKey, minTTL time.Duration) (*auth.TokenResponse, error) {
+	var token string
+	unlock, ready := p.lockAccount(mailInfo, func() bool {
+		var ok bool
+		token, ok = p.cachedAccessToken(cacheKey, minTTL)
+		return ok
+	})
+	defer unlock()
+
+	if ready {
+		log.Debug().
+			Str("email", mailInfo.Email).
+			Msg("使用其他实例刷新的 access token")
+		return &auth.TokenResponse{AccessToken: token}, nil
+	}
+	return p.fetchAccessToken(mailInfo, cacheKey)
+}
+
+// lockAccount 获取账户的分布式刷新锁（缓存不支持分布式锁时不加锁），返回释放锁的函数
+// 其他实例正在刷新时等待它释放锁；ready 不为 nil 时在获取锁后和等待期间检查，返回 true 表示其他实例已完成需要的刷新，
+// 此时 lockAccount 返回 true，调用方不需要再刷新。获取锁失败或等待超时时不加锁继续
+func (p *TokenProvider) lockAccount(mailInfo *types.MailInfo, ready func() bool) (func(), bool) {
+	noop := func() {}
+	locker, ok := p.cache.(tokencache.Locker)
+	if !ok {
+		return noop, false
+	}
+
+	deadline := time.Now().Add(refreshLockTTL)
+	for {
+		unlock, acquired, err := locker.TryLock(context.Background(), accountKey(mailInfo), refreshLockTTL)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("email", mailInfo.Email).
+				Msg("获取刷新锁失败，直接刷新")
+			return noop, false
+		}
+
+		if acquired {
+			// 获取锁之前其他实例可能刚完成刷新
+			return unlock, ready != nil && ready()
+		}
+
+		// 其他实例正在刷新，等待它完成
+		time.Sleep(refreshWaitInterval)
+		if ready != nil && ready() {
+			return noop, true
+		}
+
+		if time.Now().After(deadline) {
+			log.Warn().
+				Str("email", mailInfo.Email).
+				Msg("等待其他实例刷新超时，直接刷新")
+			return noop, false
+		}
+	}
+}
+
+// cachedAccessToken 获取缓存中剩余时间超过 minTTL 的 access token
+func (p *TokenProvider) cachedAccessToken(cacheKey tokencache.TokenKey, minTTL time.Duration) (string, bool) {
+	token, ttl, err := p.cache.GetAccessTokenWithTTL(cacheKey)
+	if err != nil || token == "" || ttl <= minTTL {
+		return "", false
+	}
+	return token, true
+}
+
+// fetchAccessToken 调用原始数据层获取 access token 并写入缓存
+func (p *TokenProvider) fetchAccessToken(mailInfo *types.MailInfo, cacheKey tokencache.TokenKey) (*auth.TokenResponse, error) {
 	log.Info().
 		Str("email", mailInfo.Email).
 		Msg("缓存中没有 access token，正在从原始数据层获取")
 
-	token, err := auth.GetAccessToken(mailInfo)
+	tokenResp, err := auth.GetAccessToken(mailInfo)
 	if err != nil {
-		return "", fmt.Errorf("从原始数据层获取 access token 失败: %w", err)
+		return nil, fmt.Errorf("从原始数据层获取 access token 失败: %w", err)
 	}
 
-	// 3. 将结果写入缓存（假设 token 有效期 50 分钟，留 10 分钟缓冲）
-	// todo 过期时间配置化
-	if err := p.cache.SetAccessToken(mailInfo.RefreshToken, token, 50*time.Minute); err != nil {
-		log.Warn().
-			Err(err).
-			Str("email", mailInfo.Email).
-			Msg("写入缓存失败，但不影响返回结果")
-	}
+	// 将结果写入缓存（缓存时间为实际有效期减去安全余量）
+	p.cacheAccessToken(mailInfo, cacheKey, tokenResp)
+
+	// 刷新时 refresh token 可能被轮换，保存新的 refresh token 并按新的缓存键缓存
+	p.cacheRotation(mailInfo, tokenResp, tokenstore.SourceRefresh)
 
 	log.Info().
 		Str("email", mailInfo.Email).
+		Int64("expiresIn", tokenResp.ExpiresIn).
 		Msg("成功获取并缓存 access token")
 
-	return token, nil
+	return tokenResp, nil
 }
 
 // GetRefreshToken 获取 refresh token（直接调用原始数据层）
@@ -76,6 +233,9 @@ func (p *TokenProvider) GetRefreshToken(mailInfo *types.MailInfo) (string, error
 		return "", fmt.Errorf("获取 refresh token 失败: %w", err)
 	}
 
+	// 保存轮换后的 refresh token，调用方丢失响应时仍可以通过账户 ID 使用
+	p.persistRotation(mailInfo, token, tokenstore.SourceRefresh)
+
 	// // 获取新的 refresh token 后，清除旧的 access token 缓存
 	// // ! 这个我倒是没想到
 	// if err := p.cache.DeleteAccessToken(mailInfo.RefreshToken); err != nil {
@@ -99,30 +259,127 @@ func (p *TokenProvider) GetBothTokens(mailInfo *types.MailInfo) (string, string,
 		Str("protocol", string(mailInfo.ProtocolType)).
 		Msg("开始同时获取 access token 和 refresh token")
 
-	// 直接调用原始数据层获取两个 token
-	accessToken, refreshToken, err := auth.GetBothTokens(mailInfo)
+	// 密码认证账户不需要访问令牌，只校验配置
+	if mailInfo.UsesPasswordAuth() {
+		return "", "", mailInfo.ValidatePasswordAuth()
+	}
+
+	// 直接调用原始数据层获取两个 token，同一账户的并发请求共享结果（包括轮换后的 refresh token）
+	// 与 GetAccessToken 共用合并组和分布式锁，同一个 refresh token 不会被并发使用；
+	// 正在执行的刷新只获取了 access token 或属于其他协议时，等待它结束后再获取
+	cacheKey := accessTokenCacheKey(mailInfo)
+	tokenResp, err := p.doFlight(mailInfo, refreshBoth, cacheKey, func() (*auth.TokenResponse, error) {
+		return p.refreshBothTokens(mailInfo, cacheKey)
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return tokenResp.AccessToken, tokenResp.RefreshToken, nil
+}
+
+// RefreshWithScope 使用 Graph scope 刷新令牌，返回完整的令牌响应（包括 scope），用于协议检测
+// 与其他刷新共用账户的合并组和分布式锁，轮换后的 refresh token 会被保存；
+// 调用方根据 scope 判断协议后调用 CacheAccessToken 缓存 access token
+func (p *TokenProvider) RefreshWithScope(mailInfo *types.MailInfo) (*auth.TokenResponse, error) {
+	return p.doFlight(mailInfo, refreshScoped, tokencache.TokenKey{}, func() (*auth.TokenResponse, error) {
+		unlock, _ := p.lockAccount(mailInfo, nil)
+		defer unlock()
+
+		tokenResp, err := auth.GetTokensWithScope(mailInfo, true)
+		if err != nil {
+			return nil, err
+		}
+
+		// 刷新时 refresh token 可能被轮换（Microsoft），保存新的 refresh token
+		p.persistRotation(mailInfo, tokenResp.RefreshToken, tokenstore.SourceRefresh)
+		return tokenResp, nil
+	})
+}
+
+// CacheAccessToken 按协议缓存 RefreshWithScope 获取的 access token（有效期不足安全余量时不缓存）
+// refresh token 被轮换时同时按新的缓存键缓存，使用账户 ID 的后续请求按新的 refresh token 读取
+func (p *TokenProvider) CacheAccessToken(mailInfo *types.MailInfo, protocolType types.ProtocolType, tokenResp *auth.TokenResponse) {
+	target := *mailInfo
+	target.ProtocolType = protocolType
+	p.cacheAccessToken(&target, accessTokenCacheKey(&target), tokenResp)
+
+	if p.store == nil || tokenResp.RefreshToken == "" || tokenResp.RefreshToken == mailInfo.RefreshToken {
+		return
+	}
+	target.RefreshToken = tokenResp.RefreshToken
+	p.cacheAccessToken(&target, accessTokenCacheKey(&target), tokenResp)
+}
+
+// refreshBothTokens 持有账户的分布式刷新锁，调用原始数据层同时获取两个 token
+func (p *TokenProvider) refreshBothTokens(mailInfo *types.MailInfo, cacheKey tokencache.TokenKey) (*auth.TokenResponse, error) {
+	unlock, _ := p.lockAccount(mailInfo, nil)
+	defer unlock()
+
+	tokenResp, err := auth.GetBothTokens(mailInfo)
 	if err != nil {
-		return "", "", fmt.Errorf("同时获取两个 token 失败: %w", err)
+		return nil, fmt.Errorf("同时获取两个 token 失败: %w", err)
 	}
 
 	// 缓存新的 access token
-	if err := p.cache.SetAccessToken(mailInfo.RefreshToken, accessToken, 50*time.Minute); err != nil {
+	p.cacheAccessToken(mailInfo, cacheKey, tokenResp)
+
+	// 保存轮换后的 refresh token
+	p.cacheRotation(mailInfo, tokenResp, tokenstore.SourceBoth)
+
+	log.Info().
+		Str("email", mailInfo.Email).
+		Bool("hasNewRefreshToken", tokenResp.RefreshToken != "").
+		Msg("成功同时获取两个 token")
+	return tokenResp, nil
+}
+
+// cacheRotation 保存轮换后的 refresh token，使用账户 ID 的后续请求按新的缓存键读取 access token，因此同时缓存
+func (p *TokenProvider) cacheRotation(mailInfo *types.MailInfo, tokenResp *auth.TokenResponse, source string) {
+	if !p.persistRotation(mailInfo, tokenResp.RefreshToken, source) {
+		return
+	}
+	rotated := *mailInfo
+	rotated.RefreshToken = tokenResp.RefreshToken
+	p.cacheAccessToken(&rotated, accessTokenCacheKey(&rotated), tokenResp)
+}
+
+// cacheAccessToken 按 token endpoint 返回的有效期缓存 access token，有效期不足安全余量时不缓存
+func (p *TokenProvider) cacheAccessToken(mailInfo *types.MailInfo, cacheKey tokencache.TokenKey, tokenResp *auth.TokenResponse) {
+	ttl := tokencache.TTLFromExpiresIn(tokenResp.ExpiresIn)
+	if ttl <= 0 {
+		log.Warn().
+			Str("email", mailInfo.Email).
+			Int64("expiresIn", tokenResp.ExpiresIn).
+			Msg("access token 有效期过短，不写入缓存")
+		return
+	}
+
+	if err := p.cache.SetAccessToken(cacheKey, tokenResp.AccessToken, ttl); err != nil {
 		log.Warn().
 			Err(err).
 			Str("email", mailInfo.Email).
-			Msg("缓存新 access token 失败")
+			Msg("写入缓存失败，但不影响返回结果")
 	}
+}
 
-	log.Info().
-		Str("email", mailInfo.Email).
-		Bool("hasNewRefreshToken", refreshToken != "").
-		Msg("成功同时获取两个 token")
+// accessTokenCacheKey 获取 access token 的缓存键（包含协议对应的 scope）
+func accessTokenCacheKey(mailInfo *types.MailInfo) tokencache.TokenKey {
+	return tokencache.NewTokenKey(mailInfo, auth.AccessTokenScope(mailInfo.ProtocolType))
+}
 
-	return accessToken, refreshToken, nil
+// accountKey 账户的刷新键（不含 scope），同一个 refresh token 的所有刷新共用合并组和分布式锁
+func accountKey(mailInfo *types.MailInfo) tokencache.TokenKey {
+	return tokencache.NewTokenKey(mailInfo, "")
 }
 
-// Close 关闭 TokenProvider，释放资源
+// Close 关闭 TokenProvider（包括后台刷新器和 refresh token 存储），释放资源
 func (p *TokenProvider) Close() error {
+	p.refresher.Stop()
+	if p.store != nil {
+		p.store.Close()
+	}
+
 	if p.cache != nil {
 		return p.cache.Close()
 	}
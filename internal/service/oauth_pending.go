@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gomailapi2/internal/config"
+	"gomailapi2/internal/types"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisPendingKeyPrefix 等待回调的授权请求的 Redis 键前缀
+const redisPendingKeyPrefix = "oauth:pending:"
+
+// pendingAuthorization 等待回调的授权请求
+type pendingAuthorization struct {
+	MailInfo     *types.MailInfo `json:"mailInfo"` // 服务提供商、clientId、客户端密钥和租户
+	RedirectURI  string          `json:"redirectUri"`
+	CodeVerifier string          `json:"codeVerifier"`
+	ExpiresAt    time.Time       `json:"expiresAt"`
+}
+
+// pendingStore 等待回调的授权请求存储
+type pendingStore interface {
+	// put 保存授权请求，到 ExpiresAt 时过期
+	put(ctx context.Context, state string, pending *pendingAuthorization) error
+	// take 取出并删除授权请求（每个授权请求只能使用一次），不存在或已过期时返回 nil
+	take(ctx context.Context, state string) (*pendingAuthorization, error)
+	// close 关闭存储
+	close() error
+}
+
+// memoryPendingStore 将授权请求保存在内存中（单实例部署）
+type memoryPendingStore struct {
+	mu      sync.Mutex
+	pending map[string]*pendingAuthorization // key: state
+}
+
+func newMemoryPendingStore() *memoryPendingStore {
+	return &memoryPendingStore{pending: make(map[string]*pendingAuthorization)}
+}
+
+func (s *memoryPendingStore) put(_ context.Context, state string, pending *pendingAuthorization) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 顺便清理过期的授权请求
+	now := time.Now()
+	for key, p := range s.pending {
+		if now.After(p.ExpiresAt) {
+			delete(s.pending, key)
+		}
+	}
+	s.pending[state] = pending
+	return nil
+}
+
+func (s *memoryPendingStore) take(_ context.Context, state string) (*pendingAuthorization, error) {
+	s.mu.Lock()
+	pending, exists := s.pending[state]
+	delete(s.pending, state)
+	s.mu.Unlock()
+
+	if !exists || time.Now().After(pending.ExpiresAt) {
+		return nil, nil
+	}
+	return pending, nil
+}
+
+func (s *memoryPendingStore) close() error {
+	return nil
+}
+
+// redisPendingStore 将授权请求保存在 Redis 中（多实例部署时回调可能到达任意实例）
+type redisPendingStore struct {
+	client *redis.Client
+}
+
+// newRedisPendingStore 创建 Redis 授权请求存储（使用 cache.redis 的连接配置）
+func newRedisPendingStore(redisConfig config.RedisConfig) (*redisPendingStore, error) {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", redisConfig.Host, redisConfig.Port),
+		Password: redisConfig.Password,
+		DB:       redisConfig.DB,
+	})
+
+	// 测试连接
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &redisPendingStore{client: rdb}, nil
+}
+
+func (s *redisPendingStore) put(ctx context.Context, state string, pending *pendingAuthorization) error {
+	content, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, redisPendingKeyPrefix+state, content, time.Until(pending.ExpiresAt)).Err()
+}
+
+func (s *redisPendingStore) take(ctx context.Context, state string) (*pendingAuthorization, error) {
+	// GETDEL 原子地读取并删除，同一个 state 并发回调时只有一个能取到
+	content, err := s.client.GetDel(ctx, redisPendingKeyPrefix+state).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pending pendingAuthorization
+	if err := json.Unmarshal(content, &pending); err != nil {
+		return nil, fmt.Errorf("解析授权请求失败: %w", err)
+	}
+	if time.Now().After(pending.ExpiresAt) {
+		return nil, nil
+	}
+	return &pending, nil
+}
+
+func (s *redisPendingStore) close() error {
+	return s.client.Close()
+}
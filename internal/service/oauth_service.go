@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gomailapi2/api/rest/dto"
+	"gomailapi2/internal/config"
+	"gomailapi2/internal/origin/auth"
+	"gomailapi2/internal/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// authorizationTimeout 授权请求的有效期（从开始授权到回调的最长时间）
+const authorizationTimeout = 10 * time.Minute
+
+// OAuthService OAuth 授权服务（授权码 PKCE 流程和设备码流程），用于获取新邮箱的 refreshToken
+type OAuthService struct {
+	protocolService    *ProtocolService
+	defaultRedirectURI string       // 默认回调地址
+	pending            pendingStore // 等待回调的授权请求
+}
+
+// NewOAuthService 创建新的 OAuth 授权服务，等待回调的授权请求默认保存在内存中
+func NewOAuthService(protocolService *ProtocolService, defaultRedirectURI string) *OAuthService {
+	return &OAuthService{
+		protocolService:    protocolService,
+		defaultRedirectURI: defaultRedirectURI,
+		pending:            newMemoryPendingStore(),
+	}
+}
+
+// EnableRedisPendingStore 将等待回调的授权请求保存到 Redis（应在启动时调用），
+// 多实例部署时授权回调可以由任意实例处理
+func (s *OAuthService) EnableRedisPendingStore(redisConfig config.RedisConfig) error {
+	store, err := newRedisPendingStore(redisConfig)
+	if err != nil {
+		return err
+	}
+	s.pending.close()
+	s.pending = store
+	return nil
+}
+
+// Close 关闭授权请求存储
+func (s *OAuthService) Close() error {
+	return s.pending.close()
+}
+
+// StartAuthorization 开始授权：生成 state 和 PKCE 参数，返回授权地址
+func (s *OAuthService) StartAuthorization(request *dto.OAuthStartRequest) (*dto.OAuthStartResponse, error) {
+	switch request.ServiceProvider {
+	case types.ServiceProviderMicrosoft:
+	case types.ServiceProviderGoogle:
+		// Google 的 Web 和桌面应用换取令牌时都需要客户端密钥
+		if request.ClientSecret == "" {
+			return nil, errors.New("Google 账户需要 clientSecret")
+		}
+	default:
+		return nil, fmt.Errorf("%s 账户不支持 OAuth 授权", request.ServiceProvider)
+	}
+	if request.ClientID == "" {
+		return nil, errors.New("clientId 不能为空")
+	}
+
+	redirectURI := request.RedirectURI
+	if redirectURI == "" {
+		redirectURI = s.defaultRedirectURI
+	}
+	if redirectURI == "" {
+		return nil, errors.New("未配置回调地址，请设置 webhook.base_url 或在请求中指定 redirectUri")
+	}
+
+	state, err := auth.NewState()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, codeChallenge, err := auth.NewPKCE()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := auth.BuildAuthorizationURL(&auth.AuthorizationRequest{
+		ServiceProvider: request.ServiceProvider,
+		ClientID:        request.ClientID,
+		Tenant:          request.Tenant,
+		RedirectURI:     redirectURI,
+		Scope:           request.Scope,
+		State:           state,
+		CodeChallenge:   codeChallenge,
+		LoginHint:       request.LoginHint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pending.put(context.Background(), state, &pendingAuthorization{
+		MailInfo: &types.MailInfo{
+			ClientID:        request.ClientID,
+			ClientSecret:    request.ClientSecret,
+			ServiceProvider: request.ServiceProvider,
+			Tenant:          request.Tenant,
+		},
+		RedirectURI:  redirectURI,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(authorizationTimeout),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("保存授权请求失败: %w", err)
+	}
+
+	log.Info().
+		Str("provider", string(request.ServiceProvider)).
+		Str("clientId", request.ClientID).
+		Str("redirectUri", redirectURI).
+		Msg("创建 OAuth 授权请求")
+
+	return &dto.OAuthStartResponse{
+		AuthURL:   authURL,
+		State:     state,
+		ExpiresIn: int(authorizationTimeout.Seconds()),
+	}, nil
+}
+
+// CompleteAuthorization 完成授权：使用授权码换取令牌，从 id_token 中获取邮箱地址并检测协议类型
+func (s *OAuthService) CompleteAuthorization(state, code string) (*types.MailInfo, error) {
+	if state == "" || code == "" {
+		return nil, errors.New("state 和 code 不能为空")
+	}
+
+	// 每个授权请求只能使用一次
+	pending, err := s.pending.take(context.Background(), state)
+	if err != nil {
+		return nil, fmt.Errorf("读取授权请求失败: %w", err)
+	}
+	if pending == nil {
+		return nil, errors.New("授权请求不存在或已过期，请重新开始授权")
+	}
+
+	tokenResp, err := auth.ExchangeAuthorizationCode(pending.MailInfo, code, pending.RedirectURI, pending.CodeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("换取令牌失败: %w", err)
+	}
+
+	return s.buildMailInfo(pending.MailInfo, tokenResp)
+}
+
+// StartDeviceAuthorization 开始设备码授权，返回用户需要输入的代码和验证页面地址，以及等待授权使用的账户信息
+func (s *OAuthService) StartDeviceAuthorization(request *dto.DeviceAuthorizationRequest) (*types.MailInfo, *auth.DeviceCodeResponse, error) {
+	if request.ClientID == "" {
+		return nil, nil, errors.New("clientId 不能为空")
+	}
+
+	mailInfo := &types.MailInfo{
+		ClientID:        request.ClientID,
+		ServiceProvider: request.ServiceProvider,
+		Tenant:          request.Tenant,
+	}
+
+	device, err := auth.RequestDeviceCode(mailInfo, request.Scope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("发起设备码请求失败: %w", err)
+	}
+
+	log.Info().
+		Str("provider", string(request.ServiceProvider)).
+		Str("clientId", request.ClientID).
+		Int64("expiresIn", device.ExpiresIn).
+		Msg("创建设备码授权请求")
+
+	return mailInfo, device, nil
+}
+
+// WaitDeviceAuthorization 轮询等待用户完成设备码授权，完成后从 id_token 中获取邮箱地址并检测协议类型
+func (s *OAuthService) WaitDeviceAuthorization(ctx context.Context, mailInfo *types.MailInfo, device *auth.DeviceCodeResponse) (*types.MailInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(device.ExpiresIn)*time.Second)
+	defer cancel()
+
+	tokenResp, err := auth.PollDeviceToken(ctx, mailInfo, device)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, errors.New("设备码已过期，请重新开始授权")
+		}
+		return nil, err
+	}
+
+	return s.buildMailInfo(mailInfo, tokenResp)
+}
+
+// buildMailInfo 根据授权得到的令牌生成可直接使用的邮箱信息
+// 协议类型检测失败时不影响已获取的 refreshToken，按 IMAP 返回
+func (s *OAuthService) buildMailInfo(base *types.MailInfo, tokenResp *auth.TokenResponse) (*types.MailInfo, error) {
+	if tokenResp.RefreshToken == "" {
+		return nil, errors.New("令牌响应中没有 refreshToken，请确认申请了 offline_access 权限")
+	}
+
+	email, err := auth.EmailFromIDToken(tokenResp.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mailInfo := *base
+	mailInfo.Email = email
+	mailInfo.RefreshToken = tokenResp.RefreshToken
+
+	mailInfo.ProtocolType = types.ProtocolTypeIMAP
+	if result, err := s.protocolService.DetectProtocolType(&mailInfo); err != nil {
+		log.Warn().Err(err).Str("email", email).Msg("OAuth 授权后检测协议类型失败，使用 IMAP 协议")
+	} else {
+		mailInfo.ProtocolType = result.ProtocolType
+	}
+
+	log.Info().
+		Str("email", email).
+		Str("provider", string(mailInfo.ServiceProvider)).
+		Str("protocol", string(mailInfo.ProtocolType)).
+		Msg("OAuth 授权完成")
+
+	return &mailInfo, nil
+}
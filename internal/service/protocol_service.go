@@ -3,11 +3,9 @@ package service
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"gomailapi2/api/rest/dto"
-	"gomailapi2/internal/cache/tokencache"
-	"gomailapi2/internal/origin/auth"
+	"gomailapi2/internal/provider/token"
 	"gomailapi2/internal/types"
 
 	"github.com/rs/zerolog/log"
@@ -20,13 +18,13 @@ type ProtocolDetectResult struct {
 
 // ProtocolService 协议检测服务
 type ProtocolService struct {
-	cache tokencache.Cache
+	tokenProvider *token.TokenProvider
 }
 
 // NewProtocolService 创建新的协议检测服务
-func NewProtocolService(cache tokencache.Cache) *ProtocolService {
+func NewProtocolService(tokenProvider *token.TokenProvider) *ProtocolService {
 	return &ProtocolService{
-		cache: cache,
+		tokenProvider: tokenProvider,
 	}
 }
 
@@ -37,6 +35,10 @@ func (s *ProtocolService) DetectProtocolType(mailInfo *types.MailInfo) (*Protoco
 	if mailInfo.Email == "" {
 		return nil, fmt.Errorf("email 不能为空")
 	}
+	// 密码认证账户不需要获取令牌，根据配置的服务器判断
+	if mailInfo.UsesPasswordAuth() {
+		return &ProtocolDetectResult{ProtocolType: s.detectPasswordAuthProtocol(mailInfo)}, nil
+	}
 	// todo 可空，为空默认雷鸟 clientId
 	if mailInfo.ClientID == "" {
 		return nil, fmt.Errorf("clientId 不能为空")
@@ -54,8 +56,8 @@ func (s *ProtocolService) DetectProtocolType(mailInfo *types.MailInfo) (*Protoco
 		Str("provider", string(mailInfo.ServiceProvider)).
 		Msg("开始检测邮件协议类型")
 
-	// 调用带 scope 的 GetTokensWithScope 方法
-	tokenResp, err := auth.GetTokensWithScope(mailInfo, true)
+	// 使用 Graph scope 刷新（与其他刷新共用账户的合并组和分布式锁）
+	tokenResp, err := s.tokenProvider.RefreshWithScope(mailInfo)
 	if err != nil {
 		log.Error().Err(err).Str("email", mailInfo.Email).Msg("获取 token 失败")
 		return nil, fmt.Errorf("获取 token 失败: %w", err)
@@ -63,22 +65,14 @@ func (s *ProtocolService) DetectProtocolType(mailInfo *types.MailInfo) (*Protoco
 
 	// 根据 scope 判断协议类型
 	var detectedType types.ProtocolType
-	if tokenResp.Scope != "" && s.isGraphScope(tokenResp.Scope) {
+	switch {
+	case tokenResp.Scope != "" && s.isGraphScope(tokenResp.Scope):
 		// Graph 协议：accessToken 有效，缓存起来
 		detectedType = types.ProtocolTypeGraph
 
 		if tokenResp.AccessToken != "" {
-			// 缓存 accessToken，假设有效期 50 分钟，留 10 分钟缓冲
-			if err := s.cache.SetAccessToken(mailInfo.RefreshToken, tokenResp.AccessToken, 50*time.Minute); err != nil {
-				log.Warn().
-					Err(err).
-					Str("email", mailInfo.Email).
-					Msg("缓存 Graph accessToken 失败，但不影响返回结果")
-			} else {
-				log.Info().
-					Str("email", mailInfo.Email).
-					Msg("成功缓存 Graph accessToken")
-			}
+			// 缓存 accessToken，缓存时间为实际有效期减去安全余量
+			s.tokenProvider.CacheAccessToken(mailInfo, types.ProtocolTypeGraph, tokenResp)
 		}
 
 		log.Info().
@@ -86,7 +80,22 @@ func (s *ProtocolService) DetectProtocolType(mailInfo *types.MailInfo) (*Protoco
 			Str("detectedType", string(types.ProtocolTypeGraph)).
 			Str("scope", tokenResp.Scope).
 			Msg("检测到 Graph 协议")
-	} else {
+
+	case mailInfo.ServiceProvider == types.ServiceProviderGoogle && s.isGmailAPIScope(tokenResp.Scope):
+		// Gmail API 协议：accessToken 同样可用于 Gmail API，缓存起来
+		detectedType = types.ProtocolTypeGmailAPI
+
+		if tokenResp.AccessToken != "" {
+			s.tokenProvider.CacheAccessToken(mailInfo, types.ProtocolTypeGmailAPI, tokenResp)
+		}
+
+		log.Info().
+			Str("email", mailInfo.Email).
+			Str("detectedType", string(types.ProtocolTypeGmailAPI)).
+			Str("scope", tokenResp.Scope).
+			Msg("检测到 Gmail API 协议")
+
+	default:
 		// IMAP 协议：此 token 对 Graph API 无效，舍弃
 		detectedType = types.ProtocolTypeIMAP
 
@@ -104,17 +113,44 @@ func (s *ProtocolService) DetectProtocolType(mailInfo *types.MailInfo) (*Protoco
 	log.Info().
 		Str("email", mailInfo.Email).
 		Str("detectedType", string(detectedType)).
-		Bool("accessTokenCached", detectedType == types.ProtocolTypeGraph).
+		Bool("accessTokenCached", detectedType != types.ProtocolTypeIMAP).
 		Msg("协议类型检测完成")
 
 	return result, nil
 }
 
+// detectPasswordAuthProtocol 判断密码认证账户的协议类型：默认 IMAP，没有配置 IMAP、POP3 服务器时依次为 JMAP、EWS
+func (s *ProtocolService) detectPasswordAuthProtocol(mailInfo *types.MailInfo) types.ProtocolType {
+	switch {
+	case mailInfo.Imap != nil || mailInfo.Pop3 != nil:
+		return types.ProtocolTypeIMAP
+	case mailInfo.JmapURL != "":
+		return types.ProtocolTypeJMAP
+	case mailInfo.EwsURL != "":
+		return types.ProtocolTypeEWS
+	default:
+		return types.ProtocolTypeIMAP
+	}
+}
+
 // isGraphScope 检查 scope 是否包含 Graph API 权限
 func (s *ProtocolService) isGraphScope(scope string) bool {
 	return strings.Contains(scope, "https://graph.microsoft.com/Mail.ReadWrite")
 }
 
+// isGmailAPIScope 检查 scope 是否包含 Gmail API 权限（完整邮箱权限、只读或修改权限）
+func (s *ProtocolService) isGmailAPIScope(scope string) bool {
+	for _, item := range strings.Fields(scope) {
+		switch item {
+		case "https://mail.google.com/",
+			"https://www.googleapis.com/auth/gmail.readonly",
+			"https://www.googleapis.com/auth/gmail.modify":
+			return true
+		}
+	}
+	return false
+}
+
 // BatchDetectProtocolType 批量检测邮件协议类型（并发处理）
 func (s *ProtocolService) BatchDetectProtocolType(mailInfos []*types.MailInfo) (*dto.BatchDetectProtocolTypeResponse, error) {
 	if len(mailInfos) == 0 {
@@ -0,0 +1,88 @@
+package tokenstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// encryptionKeySize AES-256 密钥长度
+const encryptionKeySize = 32
+
+// secretCipher 使用 AES-256-GCM 加密邮箱信息
+type secretCipher struct {
+	aead     cipher.AEAD
+	indexKey []byte // 计算 refreshToken 索引的 HMAC 密钥（由 encryption_key 派生）
+}
+
+// newSecretCipher 根据 base64 编码的 32 字节密钥创建加密器
+func newSecretCipher(encodedKey string) (*secretCipher, error) {
+	if encodedKey == "" {
+		return nil, errors.New("未配置 token_store.encryption_key")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("解码 encryption_key 失败: %w", err)
+	}
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("encryption_key 长度必须为 %d 字节，实际为 %d 字节", encryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &secretCipher{aead: aead, indexKey: hmacSHA256(key, []byte("token-store-credential-index"))}, nil
+}
+
+// credentialHash 计算 refreshToken 的索引（HMAC-SHA256），用于根据 refreshToken 查找账户
+// 使用带密钥的哈希，存储中的索引不能用来验证猜测的 refreshToken
+func (c *secretCipher) credentialHash(refreshToken string) string {
+	return hex.EncodeToString(hmacSHA256(c.indexKey, []byte(refreshToken)))
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// encrypt 加密数据，返回 base64 编码的 nonce + 密文；additionalData 为账户 ID，防止密文被移动到其他账户
+func (c *secretCipher) encrypt(plaintext []byte, additionalData string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, []byte(additionalData))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt 解密 encrypt 生成的数据
+func (c *secretCipher) decrypt(encoded string, additionalData string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("解码密文失败: %w", err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("密文长度无效")
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(additionalData))
+	if err != nil {
+		return nil, errors.New("解密失败，请检查 encryption_key 是否正确")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,115 @@
+package tokenstore
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+// testEncryptionKey 测试使用的 32 字节密钥（base64 编码）
+var testEncryptionKey = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x42}, encryptionKeySize))
+
+func mustSecretCipher(t *testing.T, encodedKey string) *secretCipher {
+	t.Helper()
+	c, err := newSecretCipher(encodedKey)
+	if err != nil {
+		t.Fatalf("newSecretCipher 失败: %v", err)
+	}
+	return c
+}
+
+func TestNewSecretCipher(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"有效密钥", testEncryptionKey, false},
+		{"未配置", "", true},
+		{"不是 base64", "not base64!", true},
+		{"长度不足", base64.StdEncoding.EncodeToString(make([]byte, 16)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSecretCipher(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newSecretCipher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecretCipherEncryptDecrypt(t *testing.T) {
+	c := mustSecretCipher(t, testEncryptionKey)
+	otherKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x24}, encryptionKeySize))
+	plaintext := []byte(`{"refreshToken":"M.C123"}`)
+
+	sealed, err := c.encrypt(plaintext, "acc_1")
+	if err != nil {
+		t.Fatalf("encrypt 失败: %v", err)
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(sealed)
+	raw[len(raw)-1] ^= 0x01
+	tampered := base64.StdEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name    string
+		cipher  *secretCipher
+		sealed  string
+		aad     string
+		wantErr bool
+	}{
+		{"相同账户 ID", c, sealed, "acc_1", false},
+		{"其他账户 ID", c, sealed, "acc_2", true},
+		{"空账户 ID", c, sealed, "", true},
+		{"其他密钥", mustSecretCipher(t, otherKey), sealed, "acc_1", true},
+		{"密文被修改", c, tampered, "acc_1", true},
+		{"密文过短", c, base64.StdEncoding.EncodeToString([]byte("short")), "acc_1", true},
+		{"不是 base64", c, "not base64!", "acc_1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cipher.decrypt(tt.sealed, tt.aad)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decrypt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, plaintext) {
+				t.Errorf("decrypt() = %s, want %s", got, plaintext)
+			}
+		})
+	}
+}
+
+func TestSecretCipherNonce(t *testing.T) {
+	c := mustSecretCipher(t, testEncryptionKey)
+
+	first, err := c.encrypt([]byte("same"), "acc_1")
+	if err != nil {
+		t.Fatalf("encrypt 失败: %v", err)
+	}
+	second, err := c.encrypt([]byte("same"), "acc_1")
+	if err != nil {
+		t.Fatalf("encrypt 失败: %v", err)
+	}
+	if first == second {
+		t.Error("相同明文两次加密的结果相同，nonce 没有随机生成")
+	}
+}
+
+func TestCredentialHash(t *testing.T) {
+	c := mustSecretCipher(t, testEncryptionKey)
+	other := mustSecretCipher(t, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x24}, encryptionKeySize)))
+
+	if c.credentialHash("rt-1") != c.credentialHash("rt-1") {
+		t.Error("相同 refreshToken 的索引不同")
+	}
+	if c.credentialHash("rt-1") == c.credentialHash("rt-2") {
+		t.Error("不同 refreshToken 的索引相同")
+	}
+	if c.credentialHash("rt-1") == other.credentialHash("rt-1") {
+		t.Error("不同密钥计算的索引相同")
+	}
+}
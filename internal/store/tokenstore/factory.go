@@ -0,0 +1,42 @@
+package tokenstore
+
+import (
+	"fmt"
+
+	"gomailapi2/internal/config"
+)
+
+// StoreType 存储类型
+type StoreType string
+
+const (
+	StoreTypeFile  StoreType = "file"  // JSON 文件（单实例部署）
+	StoreTypeRedis StoreType = "redis" // Redis（多实例部署共享）
+)
+
+// NewStore 根据配置创建 refresh token 存储，Redis 存储使用缓存的 Redis 连接配置
+func NewStore(storeConfig config.TokenStoreConfig, redisConfig config.RedisConfig) (Store, error) {
+	var (
+		b   backend
+		err error
+	)
+
+	switch StoreType(storeConfig.Type) {
+	case StoreTypeFile:
+		b, err = newFileBackend(storeConfig.Path)
+	case StoreTypeRedis:
+		b, err = newRedisBackend(redisConfig)
+	default:
+		return nil, fmt.Errorf("unsupported token store type: %s", storeConfig.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := newEncryptedStore(b, storeConfig.EncryptionKey, storeConfig.MaxHistory)
+	if err != nil {
+		b.close()
+		return nil, err
+	}
+	return store, nil
+}
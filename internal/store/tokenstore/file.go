@@ -0,0 +1,206 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// fileData 文件存储的内容
+type fileData struct {
+	Accounts    map[string]*record    `json:"accounts"`
+	History     map[string][]Rotation `json:"history"`     // 按时间正序
+	Credentials map[string]string     `json:"credentials"` // refreshToken 索引 -> 账户 ID
+}
+
+// fileBackend 将所有账户保存在一个 JSON 文件中（单实例部署使用）
+type fileBackend struct {
+	path string
+
+	mu   sync.RWMutex
+	data fileData
+}
+
+// newFileBackend 创建文件存储后端，文件不存在时在第一次保存时创建
+func newFileBackend(path string) (*fileBackend, error) {
+	if path == "" {
+		return nil, errors.New("未配置 token_store.path")
+	}
+
+	b := &fileBackend{
+		path: path,
+		data: fileData{
+			Accounts:    make(map[string]*record),
+			History:     make(map[string][]Rotation),
+			Credentials: make(map[string]string),
+		},
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取 %s 失败: %w", path, err)
+	}
+	if err := json.Unmarshal(content, &b.data); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	if b.data.Accounts == nil {
+		b.data.Accounts = make(map[string]*record)
+	}
+	if b.data.History == nil {
+		b.data.History = make(map[string][]Rotation)
+	}
+	if b.data.Credentials == nil {
+		b.data.Credentials = make(map[string]string)
+	}
+	return b, nil
+}
+
+func (b *fileBackend) load(_ context.Context, id string) (*record, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	rec, ok := b.data.Accounts[id]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	copied := *rec
+	return &copied, nil
+}
+
+func (b *fileBackend) lookup(_ context.Context, credential string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	id, ok := b.data.Credentials[credential]
+	if !ok {
+		return "", ErrAccountNotFound
+	}
+	return id, nil
+}
+
+func (b *fileBackend) save(_ context.Context, rec *record, rotation *Rotation, maxHistory int, staleCredentials []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := rec.Account.ID
+	previousRecord, hadRecord := b.data.Accounts[id]
+	previousHistory := b.data.History[id]
+	previousCredentials := b.credentialsLocked(append(slices.Clone(rec.Credentials), staleCredentials...))
+
+	b.data.Accounts[id] = rec
+	if rotation != nil {
+		history := append(slices.Clone(previousHistory), *rotation)
+		if len(history) > maxHistory {
+			history = history[len(history)-maxHistory:]
+		}
+		b.data.History[id] = history
+	}
+	for _, credential := range rec.Credentials {
+		b.data.Credentials[credential] = id
+	}
+	for _, credential := range staleCredentials {
+		delete(b.data.Credentials, credential)
+	}
+
+	if err := b.flushLocked(); err != nil {
+		// 写入失败时恢复内存中的数据，保持与文件一致
+		if hadRecord {
+			b.data.Accounts[id] = previousRecord
+		} else {
+			delete(b.data.Accounts, id)
+		}
+		b.data.History[id] = previousHistory
+		b.restoreCredentialsLocked(previousCredentials)
+		return err
+	}
+	return nil
+}
+
+func (b *fileBackend) history(_ context.Context, id string) ([]Rotation, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	history := slices.Clone(b.data.History[id])
+	slices.Reverse(history)
+	return history, nil
+}
+
+func (b *fileBackend) delete(_ context.Context, id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rec, ok := b.data.Accounts[id]
+	if !ok {
+		return ErrAccountNotFound
+	}
+	history := b.data.History[id]
+	credentials := b.credentialsLocked(rec.Credentials)
+
+	delete(b.data.Accounts, id)
+	delete(b.data.History, id)
+	for credential, owner := range credentials {
+		if owner == id {
+			delete(b.data.Credentials, credential)
+		}
+	}
+	if err := b.flushLocked(); err != nil {
+		b.data.Accounts[id] = rec
+		b.data.History[id] = history
+		b.restoreCredentialsLocked(credentials)
+		return err
+	}
+	return nil
+}
+
+// credentialsLocked 获取 refreshToken 索引的当前值（不存在的索引值为空），用于写入失败时恢复（调用方需持有锁）
+func (b *fileBackend) credentialsLocked(credentials []string) map[string]string {
+	saved := make(map[string]string, len(credentials))
+	for _, credential := range credentials {
+		saved[credential] = b.data.Credentials[credential]
+	}
+	return saved
+}
+
+// restoreCredentialsLocked 恢复 credentialsLocked 获取的 refreshToken 索引（调用方需持有锁）
+func (b *fileBackend) restoreCredentialsLocked(saved map[string]string) {
+	for credential, id := range saved {
+		if id == "" {
+			delete(b.data.Credentials, credential)
+		} else {
+			b.data.Credentials[credential] = id
+		}
+	}
+}
+
+func (b *fileBackend) close() error {
+	return nil
+}
+
+// flushLocked 将数据写入临时文件后重命名，避免写入中断时损坏文件（调用方需持有锁）
+func (b *fileBackend) flushLocked() error {
+	content, err := json.MarshalIndent(&b.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化存储数据失败: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("创建存储目录失败: %w", err)
+	}
+
+	tmpPath := b.path + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0o600); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, b.path); err != nil {
+		return fmt.Errorf("重命名 %s 失败: %w", tmpPath, err)
+	}
+	return nil
+}
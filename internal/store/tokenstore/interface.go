@@ -0,0 +1,118 @@
+package tokenstore
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"gomailapi2/internal/types"
+)
+
+// 账户相关错误
+var (
+	ErrAccountNotFound = errors.New("账户不存在")
+	ErrInvalidSecret   = errors.New("账户密钥无效")
+)
+
+// refreshToken 变更的来源
+const (
+	SourceRegister = "register" // 注册账户
+	SourceRefresh  = "refresh"  // 刷新 refreshToken
+	SourceBoth     = "both"     // 同时获取 access token 和 refresh token
+)
+
+// Account 已保存账户的基本信息（不包含 refreshToken 等凭据）
+type Account struct {
+	ID              string                `json:"id"`
+	Email           string                `json:"email"`
+	ServiceProvider types.ServiceProvider `json:"serviceProvider"`
+	ClientID        string                `json:"clientId"`
+	ProtocolType    types.ProtocolType    `json:"protocolType"`
+	Fingerprint     string                `json:"fingerprint"` // 当前 refreshToken 的指纹
+	Rotations       int                   `json:"rotations"`   // refreshToken 的变更次数（包括注册）
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+// Rotation refreshToken 的一次变更记录（只记录指纹，不保存历史 refreshToken）
+type Rotation struct {
+	Time                time.Time `json:"time"`
+	Source              string    `json:"source"`
+	Fingerprint         string    `json:"fingerprint"`
+	PreviousFingerprint string    `json:"previousFingerprint,omitempty"`
+}
+
+// Store refresh token 存储，邮箱信息（包括 refreshToken、客户端密钥和密码）加密保存
+// 账户 ID 和密钥都是随机生成的，不能从邮箱地址等公开信息推算；使用账户时必须同时提供 ID 和密钥
+type Store interface {
+	// Register 保存账户并生成新的访问密钥，返回账户基本信息和密钥（存储中只保存密钥的哈希，之后无法再次获取）
+	// mailInfo 带有账户 ID 和密钥，或 refreshToken 是已保存账户当前的 refreshToken 时更新该账户（ID 不变、旧密钥失效），
+	// 只匹配到之前使用过的 refreshToken 时返回错误；否则创建新账户
+	Register(ctx context.Context, mailInfo *types.MailInfo) (*Account, string, error)
+
+	// Save 更新已保存的账户（mailInfo.AccountID），refreshToken 与已保存的不同时记录一次变更
+	Save(ctx context.Context, mailInfo *types.MailInfo, source string) (*Account, error)
+
+	// Lookup 根据账户当前或之前使用过的 refreshToken 查找账户 ID
+	Lookup(ctx context.Context, refreshToken string) (string, error)
+
+	// Authenticate 校验账户的访问密钥，密钥不正确时返回 ErrInvalidSecret
+	Authenticate(ctx context.Context, id, secret string) error
+
+	// Get 获取账户的基本信息和解密后的邮箱信息
+	Get(ctx context.Context, id string) (*Account, *types.MailInfo, error)
+
+	// History 获取账户的 refreshToken 变更记录（按时间倒序）
+	History(ctx context.Context, id string) ([]Rotation, error)
+
+	// Delete 删除账户及其变更记录
+	Delete(ctx context.Context, id string) error
+
+	// Close 关闭存储
+	Close() error
+}
+
+// newAccountID 生成随机的账户 ID
+func newAccountID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "acc_" + hex.EncodeToString(buf), nil
+}
+
+// newSecret 生成随机的账户访问密钥
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashSecret 计算访问密钥的哈希（存储中只保存哈希）
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// secretMatches 比较访问密钥与保存的哈希（固定时间比较）
+func secretMatches(secret, secretHash string) bool {
+	if secret == "" || secretHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(secretHash)) == 1
+}
+
+// Fingerprint 生成 refreshToken 的指纹（SHA-256 的前 8 字节），用于审计时区分不同的 refreshToken
+func Fingerprint(refreshToken string) string {
+	if refreshToken == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:8])
+}
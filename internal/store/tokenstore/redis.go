@@ -0,0 +1,134 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gomailapi2/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis 键前缀
+const (
+	redisAccountKeyPrefix    = "token_store:account:"    // 账户记录（JSON）
+	redisHistoryKeyPrefix    = "token_store:history:"    // 变更记录列表（最新的在最前）
+	redisCredentialKeyPrefix = "token_store:credential:" // refreshToken 索引 -> 账户 ID
+)
+
+// redisBackend 将账户保存在 Redis 中（多实例部署共享）
+type redisBackend struct {
+	client *redis.Client
+}
+
+// newRedisBackend 创建 Redis 存储后端（使用 cache.redis 的连接配置）
+func newRedisBackend(redisConfig config.RedisConfig) (*redisBackend, error) {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", redisConfig.Host, redisConfig.Port),
+		Password: redisConfig.Password,
+		DB:       redisConfig.DB,
+	})
+
+	// 测试连接
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &redisBackend{client: rdb}, nil
+}
+
+func (b *redisBackend) load(ctx context.Context, id string) (*record, error) {
+	content, err := b.client.Get(ctx, redisAccountKeyPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec record
+	if err := json.Unmarshal(content, &rec); err != nil {
+		return nil, fmt.Errorf("解析账户记录失败: %w", err)
+	}
+	return &rec, nil
+}
+
+func (b *redisBackend) lookup(ctx context.Context, credential string) (string, error) {
+	id, err := b.client.Get(ctx, redisCredentialKeyPrefix+credential).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrAccountNotFound
+	}
+	return id, err
+}
+
+func (b *redisBackend) save(ctx context.Context, rec *record, rotation *Rotation, maxHistory int, staleCredentials []string) error {
+	content, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	var rotationContent []byte
+	if rotation != nil {
+		if rotationContent, err = json.Marshal(rotation); err != nil {
+			return err
+		}
+	}
+
+	id := rec.Account.ID
+	_, err = b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, redisAccountKeyPrefix+id, content, 0)
+		for _, credential := range rec.Credentials {
+			pipe.Set(ctx, redisCredentialKeyPrefix+credential, id, 0)
+		}
+		for _, credential := range staleCredentials {
+			pipe.Del(ctx, redisCredentialKeyPrefix+credential)
+		}
+		if rotationContent != nil {
+			pipe.LPush(ctx, redisHistoryKeyPrefix+id, rotationContent)
+			pipe.LTrim(ctx, redisHistoryKeyPrefix+id, 0, int64(maxHistory-1))
+		}
+		return nil
+	})
+	return err
+}
+
+func (b *redisBackend) history(ctx context.Context, id string) ([]Rotation, error) {
+	items, err := b.client.LRange(ctx, redisHistoryKeyPrefix+id, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]Rotation, 0, len(items))
+	for _, item := range items {
+		var rotation Rotation
+		if err := json.Unmarshal([]byte(item), &rotation); err != nil {
+			return nil, fmt.Errorf("解析变更记录失败: %w", err)
+		}
+		history = append(history, rotation)
+	}
+	return history, nil
+}
+
+func (b *redisBackend) delete(ctx context.Context, id string) error {
+	rec, err := b.load(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{redisAccountKeyPrefix + id, redisHistoryKeyPrefix + id}
+	for _, credential := range rec.Credentials {
+		keys = append(keys, redisCredentialKeyPrefix+credential)
+	}
+	return b.client.Del(ctx, keys...).Err()
+}
+
+func (b *redisBackend) close() error {
+	return b.client.Close()
+}
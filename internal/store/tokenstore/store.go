@@ -0,0 +1,310 @@
+package tokenstore
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gomailapi2/internal/types"
+)
+
+// 确保 encryptedStore 实现了 Store 接口
+var _ Store = (*encryptedStore)(nil)
+
+// defaultMaxHistory 每个账户默认保留的变更记录数
+const defaultMaxHistory = 100
+
+// record 账户的存储格式
+type record struct {
+	Account     Account  `json:"account"`
+	Secret      string   `json:"secret"`      // 加密的邮箱信息
+	SecretHash  string   `json:"secretHash"`  // 访问密钥的哈希
+	Credentials []string `json:"credentials"` // 当前和之前使用过的 refreshToken 的索引（按时间正序）
+}
+
+// backend 存储后端（文件或 Redis），只负责读写，不处理加密
+type backend interface {
+	// load 读取账户，不存在时返回 ErrAccountNotFound
+	load(ctx context.Context, id string) (*record, error)
+	// lookup 根据 refreshToken 的索引查找账户 ID，不存在时返回 ErrAccountNotFound
+	lookup(ctx context.Context, credential string) (string, error)
+	// save 保存账户并更新 refreshToken 索引（删除 staleCredentials），
+	// rotation 不为 nil 时追加变更记录并只保留最近 maxHistory 条
+	save(ctx context.Context, rec *record, rotation *Rotation, maxHistory int, staleCredentials []string) error
+	// history 读取变更记录（按时间倒序）
+	history(ctx context.Context, id string) ([]Rotation, error)
+	// delete 删除账户及其变更记录和 refreshToken 索引
+	delete(ctx context.Context, id string) error
+	// close 关闭后端
+	close() error
+}
+
+// encryptedStore 加密邮箱信息后保存到存储后端
+type encryptedStore struct {
+	backend    backend
+	cipher     *secretCipher
+	maxHistory int
+
+	// 保存账户需要先读取旧记录，同一实例内串行执行
+	mu sync.Mutex
+}
+
+// newEncryptedStore 创建加密存储
+func newEncryptedStore(backend backend, encryptionKey string, maxHistory int) (*encryptedStore, error) {
+	cipher, err := newSecretCipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	if maxHistory <= 0 {
+		maxHistory = defaultMaxHistory
+	}
+	return &encryptedStore{
+		backend:    backend,
+		cipher:     cipher,
+		maxHistory: maxHistory,
+	}, nil
+}
+
+// Register 保存账户并生成新的访问密钥：提供了账户 ID 和密钥，或 refreshToken 是已保存账户当前的 refreshToken 时更新该账户，
+// 否则创建新账户
+func (s *encryptedStore) Register(ctx context.Context, mailInfo *types.MailInfo) (*Account, string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return nil, "", fmt.Errorf("生成账户密钥失败: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 重新注册已保存的账户（获取新的密钥）需要提供账户 ID 和当前密钥，或者账户当前的 refreshToken；
+	// 只知道之前使用过的 refreshToken 不能重新注册，否则拿到旧 refreshToken 的人可以使原密钥失效
+	var existing *record
+	id := mailInfo.AccountID
+	if id != "" {
+		if existing, err = s.backend.load(ctx, id); err != nil {
+			return nil, "", err
+		}
+		if !secretMatches(mailInfo.AccountSecret, existing.SecretHash) {
+			return nil, "", ErrInvalidSecret
+		}
+	} else {
+		id, err = s.Lookup(ctx, mailInfo.RefreshToken)
+		switch {
+		case err == nil:
+			existing, err = s.backend.load(ctx, id)
+			if err != nil && !errors.Is(err, ErrAccountNotFound) {
+				return nil, "", err
+			}
+		case !errors.Is(err, ErrAccountNotFound):
+			return nil, "", err
+		}
+	}
+
+	if existing != nil {
+		if mailInfo, err = s.reregistration(existing, mailInfo); err != nil {
+			return nil, "", err
+		}
+	}
+	if existing == nil {
+		if id, err = newAccountID(); err != nil {
+			return nil, "", fmt.Errorf("生成账户 ID 失败: %w", err)
+		}
+	}
+
+	account, err := s.saveLocked(ctx, id, mailInfo, SourceRegister, existing, hashSecret(secret))
+	if err != nil {
+		return nil, "", err
+	}
+	return account, secret, nil
+}
+
+// reregistration 检查重新注册的邮箱信息，返回要保存的邮箱信息
+// 服务提供商、clientId 和邮箱地址必须与已保存的一致；refreshToken 是账户之前使用过的（不是当前的）时，
+// 只有提供了账户密钥才允许重新注册，并且保留已保存的（更新的）refreshToken
+func (s *encryptedStore) reregistration(existing *record, mailInfo *types.MailInfo) (*types.MailInfo, error) {
+	account := existing.Account
+	if mailInfo.ServiceProvider != account.ServiceProvider ||
+		mailInfo.ClientID != account.ClientID ||
+		!strings.EqualFold(mailInfo.Email, account.Email) {
+		return nil, errors.New("服务提供商、clientId 和邮箱地址必须与已保存的账户一致")
+	}
+
+	plaintext, err := s.cipher.decrypt(existing.Secret, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	var stored types.MailInfo
+	if err := json.Unmarshal(plaintext, &stored); err != nil {
+		return nil, fmt.Errorf("解析邮箱信息失败: %w", err)
+	}
+
+	if mailInfo.RefreshToken == "" ||
+		subtle.ConstantTimeCompare([]byte(mailInfo.RefreshToken), []byte(stored.RefreshToken)) == 1 {
+		return mailInfo, nil
+	}
+
+	if !secretMatches(mailInfo.AccountSecret, existing.SecretHash) {
+		return nil, errors.New("refreshToken 已被轮换，请使用当前的 refreshToken 或提供账户 ID 和密钥")
+	}
+	// 不用旧的 refreshToken 覆盖更新的 refreshToken
+	if slices.Contains(existing.Credentials, s.cipher.credentialHash(mailInfo.RefreshToken)) {
+		updated := *mailInfo
+		updated.RefreshToken = stored.RefreshToken
+		return &updated, nil
+	}
+	return mailInfo, nil
+}
+
+// Save 更新已保存的账户，refreshToken 与已保存的不同时记录一次变更
+func (s *encryptedStore) Save(ctx context.Context, mailInfo *types.MailInfo, source string) (*Account, error) {
+	if mailInfo.AccountID == "" {
+		return nil, ErrAccountNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.backend.load(ctx, mailInfo.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveLocked(ctx, mailInfo.AccountID, mailInfo, source, existing, existing.SecretHash)
+}
+
+// saveLocked 加密并保存账户（调用方需持有锁），existing 为已保存的记录（新账户为 nil）
+func (s *encryptedStore) saveLocked(ctx context.Context, id string, mailInfo *types.MailInfo, source string, existing *record, secretHash string) (*Account, error) {
+	stored := *mailInfo
+	stored.AccountID = id
+	stored.AccountSecret = ""
+	plaintext, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, fmt.Errorf("序列化邮箱信息失败: %w", err)
+	}
+	secret, err := s.cipher.encrypt(plaintext, id)
+	if err != nil {
+		return nil, fmt.Errorf("加密邮箱信息失败: %w", err)
+	}
+
+	now := time.Now().UTC()
+	account := Account{
+		ID:              id,
+		Email:           mailInfo.Email,
+		ServiceProvider: mailInfo.ServiceProvider,
+		ClientID:        mailInfo.ClientID,
+		ProtocolType:    mailInfo.ProtocolType,
+		Fingerprint:     Fingerprint(mailInfo.RefreshToken),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	var rotation *Rotation
+	var credentials []string
+	if existing == nil {
+		rotation = &Rotation{Time: now, Source: source, Fingerprint: account.Fingerprint}
+	} else {
+		account.CreatedAt = existing.Account.CreatedAt
+		account.Rotations = existing.Account.Rotations
+		if existing.Account.Fingerprint != account.Fingerprint {
+			rotation = &Rotation{
+				Time:                now,
+				Source:              source,
+				Fingerprint:         account.Fingerprint,
+				PreviousFingerprint: existing.Account.Fingerprint,
+			}
+		}
+		credentials = slices.Clone(existing.Credentials)
+	}
+	if rotation != nil {
+		account.Rotations++
+	}
+
+	// 索引新的 refreshToken，之前的 refreshToken 与变更记录一样只保留最近 maxHistory 个
+	if mailInfo.RefreshToken != "" {
+		if credential := s.cipher.credentialHash(mailInfo.RefreshToken); !slices.Contains(credentials, credential) {
+			credentials = append(credentials, credential)
+		}
+	}
+	var staleCredentials []string
+	if len(credentials) > s.maxHistory {
+		staleCredentials = credentials[:len(credentials)-s.maxHistory]
+		credentials = credentials[len(credentials)-s.maxHistory:]
+	}
+
+	rec := &record{
+		Account:     account,
+		Secret:      secret,
+		SecretHash:  secretHash,
+		Credentials: credentials,
+	}
+	if err := s.backend.save(ctx, rec, rotation, s.maxHistory, staleCredentials); err != nil {
+		return nil, fmt.Errorf("保存账户失败: %w", err)
+	}
+	return &account, nil
+}
+
+// Lookup 根据账户当前或之前使用过的 refreshToken 查找账户 ID，refreshToken 为空时返回 ErrAccountNotFound
+func (s *encryptedStore) Lookup(ctx context.Context, refreshToken string) (string, error) {
+	if refreshToken == "" {
+		return "", ErrAccountNotFound
+	}
+	return s.backend.lookup(ctx, s.cipher.credentialHash(refreshToken))
+}
+
+// Authenticate 校验账户的访问密钥
+func (s *encryptedStore) Authenticate(ctx context.Context, id, secret string) error {
+	rec, err := s.backend.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !secretMatches(secret, rec.SecretHash) {
+		return ErrInvalidSecret
+	}
+	return nil
+}
+
+// Get 获取账户的基本信息和解密后的邮箱信息
+func (s *encryptedStore) Get(ctx context.Context, id string) (*Account, *types.MailInfo, error) {
+	rec, err := s.backend.load(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plaintext, err := s.cipher.decrypt(rec.Secret, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mailInfo types.MailInfo
+	if err := json.Unmarshal(plaintext, &mailInfo); err != nil {
+		return nil, nil, fmt.Errorf("解析邮箱信息失败: %w", err)
+	}
+	mailInfo.AccountID = id
+
+	return &rec.Account, &mailInfo, nil
+}
+
+// History 获取账户的 refreshToken 变更记录（按时间倒序）
+func (s *encryptedStore) History(ctx context.Context, id string) ([]Rotation, error) {
+	if _, err := s.backend.load(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.backend.history(ctx, id)
+}
+
+// Delete 删除账户及其变更记录
+func (s *encryptedStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backend.delete(ctx, id)
+}
+
+// Close 关闭存储
+func (s *encryptedStore) Close() error {
+	return s.backend.close()
+}
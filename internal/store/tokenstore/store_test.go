@@ -0,0 +1,345 @@
+package tokenstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"gomailapi2/internal/types"
+)
+
+// newTestStore 创建使用临时文件的加密存储
+func newTestStore(t *testing.T, maxHistory int) *encryptedStore {
+	t.Helper()
+	b, err := newFileBackend(filepath.Join(t.TempDir(), "token_store.json"))
+	if err != nil {
+		t.Fatalf("newFileBackend 失败: %v", err)
+	}
+	s, err := newEncryptedStore(b, testEncryptionKey, maxHistory)
+	if err != nil {
+		t.Fatalf("newEncryptedStore 失败: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// testMailInfo 测试使用的微软账户
+func testMailInfo(refreshToken string) *types.MailInfo {
+	return &types.MailInfo{
+		Email:           "user@outlook.com",
+		ServiceProvider: types.ServiceProviderMicrosoft,
+		ClientID:        "client-1",
+		RefreshToken:    refreshToken,
+		ProtocolType:    types.ProtocolTypeIMAP,
+	}
+}
+
+// mustRegister 注册账户，返回账户 ID 和密钥
+func mustRegister(t *testing.T, s *encryptedStore, mailInfo *types.MailInfo) (string, string) {
+	t.Helper()
+	account, secret, err := s.Register(context.Background(), mailInfo)
+	if err != nil {
+		t.Fatalf("Register 失败: %v", err)
+	}
+	return account.ID, secret
+}
+
+// rotate 将账户的 refreshToken 更新为 refreshToken
+func rotate(t *testing.T, s *encryptedStore, id, refreshToken string) {
+	t.Helper()
+	mailInfo := testMailInfo(refreshToken)
+	mailInfo.AccountID = id
+	if _, err := s.Save(context.Background(), mailInfo, SourceRefresh); err != nil {
+		t.Fatalf("Save 失败: %v", err)
+	}
+}
+
+func TestRegisterAndSave(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, 0)
+
+	id, secret := mustRegister(t, s, testMailInfo("rt-1"))
+	if id == "" || secret == "" {
+		t.Fatalf("Register 返回空的账户 ID 或密钥: %q, %q", id, secret)
+	}
+
+	rotate(t, s, id, "rt-2")
+	// refreshToken 没有变化时不记录变更
+	rotate(t, s, id, "rt-2")
+
+	account, mailInfo, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get 失败: %v", err)
+	}
+	if mailInfo.RefreshToken != "rt-2" {
+		t.Errorf("RefreshToken = %q, want %q", mailInfo.RefreshToken, "rt-2")
+	}
+	if mailInfo.AccountSecret != "" {
+		t.Error("保存的邮箱信息包含账户密钥")
+	}
+	if account.Rotations != 2 {
+		t.Errorf("Rotations = %d, want 2", account.Rotations)
+	}
+	if account.Fingerprint != Fingerprint("rt-2") {
+		t.Errorf("Fingerprint = %q, want %q", account.Fingerprint, Fingerprint("rt-2"))
+	}
+
+	history, err := s.History(ctx, id)
+	if err != nil {
+		t.Fatalf("History 失败: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("len(History) = %d, want 2", len(history))
+	}
+	if history[0].Source != SourceRefresh || history[0].PreviousFingerprint != Fingerprint("rt-1") {
+		t.Errorf("最近的变更记录 = %+v", history[0])
+	}
+	if history[1].Source != SourceRegister {
+		t.Errorf("最早的变更记录 Source = %q, want %q", history[1].Source, SourceRegister)
+	}
+
+	// 当前和之前使用过的 refreshToken 都能找到账户
+	for _, refreshToken := range []string{"rt-1", "rt-2"} {
+		if got, err := s.Lookup(ctx, refreshToken); err != nil || got != id {
+			t.Errorf("Lookup(%q) = %q, %v, want %q", refreshToken, got, err, id)
+		}
+	}
+	if _, err := s.Lookup(ctx, "rt-unknown"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("Lookup 未知的 refreshToken error = %v, want ErrAccountNotFound", err)
+	}
+}
+
+func TestSaveHistoryLimit(t *testing.T) {
+	ctx := context.Background()
+	const maxHistory = 3
+	s := newTestStore(t, maxHistory)
+
+	id, _ := mustRegister(t, s, testMailInfo("rt-0"))
+	for i := 1; i <= 5; i++ {
+		rotate(t, s, id, fmt.Sprintf("rt-%d", i))
+	}
+
+	history, err := s.History(ctx, id)
+	if err != nil {
+		t.Fatalf("History 失败: %v", err)
+	}
+	if len(history) != maxHistory {
+		t.Fatalf("len(History) = %d, want %d", len(history), maxHistory)
+	}
+	if history[0].Fingerprint != Fingerprint("rt-5") {
+		t.Errorf("最近的变更记录 Fingerprint = %q, want %q", history[0].Fingerprint, Fingerprint("rt-5"))
+	}
+
+	account, _, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get 失败: %v", err)
+	}
+	if account.Rotations != 6 {
+		t.Errorf("Rotations = %d, want 6", account.Rotations)
+	}
+
+	// refreshToken 索引与变更记录一样只保留最近 maxHistory 个
+	tests := []struct {
+		refreshToken string
+		wantFound    bool
+	}{
+		{"rt-0", false},
+		{"rt-2", false},
+		{"rt-3", true},
+		{"rt-5", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.refreshToken, func(t *testing.T) {
+			got, err := s.Lookup(ctx, tt.refreshToken)
+			if tt.wantFound && (err != nil || got != id) {
+				t.Errorf("Lookup() = %q, %v, want %q", got, err, id)
+			}
+			if !tt.wantFound && !errors.Is(err, ErrAccountNotFound) {
+				t.Errorf("Lookup() error = %v, want ErrAccountNotFound", err)
+			}
+		})
+	}
+}
+
+func TestSaveUnknownAccount(t *testing.T) {
+	s := newTestStore(t, 0)
+
+	tests := []struct {
+		name      string
+		accountID string
+	}{
+		{"没有账户 ID", ""},
+		{"账户不存在", "acc_missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailInfo := testMailInfo("rt-1")
+			mailInfo.AccountID = tt.accountID
+			if _, err := s.Save(context.Background(), mailInfo, SourceRefresh); !errors.Is(err, ErrAccountNotFound) {
+				t.Errorf("Save() error = %v, want ErrAccountNotFound", err)
+			}
+		})
+	}
+}
+
+func TestReregister(t *testing.T) {
+	tests := []struct {
+		name string
+		// mailInfo 根据已注册账户的 ID 和密钥构建重新注册的邮箱信息（账户已从 rt-1 轮换到 rt-2）
+		mailInfo    func(id, secret string) *types.MailInfo
+		wantErr     error // 为 nil 时只检查是否出错
+		wantFail    bool
+		wantSameID  bool
+		wantStored  string // 重新注册后保存的 refreshToken
+		wantOldAuth bool   // 原密钥是否仍然有效
+	}{
+		{
+			name:       "当前的 refreshToken",
+			mailInfo:   func(string, string) *types.MailInfo { return testMailInfo("rt-2") },
+			wantSameID: true,
+			wantStored: "rt-2",
+		},
+		{
+			name:        "之前使用过的 refreshToken",
+			mailInfo:    func(string, string) *types.MailInfo { return testMailInfo("rt-1") },
+			wantFail:    true,
+			wantOldAuth: true,
+		},
+		{
+			name: "之前使用过的 refreshToken 和正确的密钥",
+			mailInfo: func(_, secret string) *types.MailInfo {
+				mailInfo := testMailInfo("rt-1")
+				mailInfo.AccountSecret = secret
+				return mailInfo
+			},
+			wantSameID: true,
+			wantStored: "rt-2",
+		},
+		{
+			name: "账户 ID 和正确的密钥",
+			mailInfo: func(id, secret string) *types.MailInfo {
+				mailInfo := testMailInfo("rt-3")
+				mailInfo.AccountID = id
+				mailInfo.AccountSecret = secret
+				return mailInfo
+			},
+			wantSameID: true,
+			wantStored: "rt-3",
+		},
+		{
+			name: "账户 ID 和错误的密钥",
+			mailInfo: func(id, _ string) *types.MailInfo {
+				mailInfo := testMailInfo("rt-3")
+				mailInfo.AccountID = id
+				mailInfo.AccountSecret = "wrong"
+				return mailInfo
+			},
+			wantErr:     ErrInvalidSecret,
+			wantFail:    true,
+			wantOldAuth: true,
+		},
+		{
+			name: "邮箱地址不一致",
+			mailInfo: func(string, string) *types.MailInfo {
+				mailInfo := testMailInfo("rt-2")
+				mailInfo.Email = "other@outlook.com"
+				return mailInfo
+			},
+			wantFail:    true,
+			wantOldAuth: true,
+		},
+		{
+			name:       "未保存的 refreshToken",
+			mailInfo:   func(string, string) *types.MailInfo { return testMailInfo("rt-new") },
+			wantStored: "rt-new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestStore(t, 0)
+			id, secret := mustRegister(t, s, testMailInfo("rt-1"))
+			rotate(t, s, id, "rt-2")
+
+			account, newSecret, err := s.Register(ctx, tt.mailInfo(id, secret))
+			if tt.wantFail {
+				if err == nil {
+					t.Fatal("Register() 没有返回错误")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("Register() error = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Register() error = %v", err)
+				}
+				if (account.ID == id) != tt.wantSameID {
+					t.Errorf("Register() 账户 ID = %q, 原账户 ID = %q, wantSameID %v", account.ID, id, tt.wantSameID)
+				}
+				_, stored, err := s.Get(ctx, account.ID)
+				if err != nil {
+					t.Fatalf("Get 失败: %v", err)
+				}
+				if stored.RefreshToken != tt.wantStored {
+					t.Errorf("保存的 RefreshToken = %q, want %q", stored.RefreshToken, tt.wantStored)
+				}
+				if err := s.Authenticate(ctx, account.ID, newSecret); err != nil {
+					t.Errorf("新密钥无效: %v", err)
+				}
+			}
+
+			// 重新注册同一个账户后原密钥失效
+			if err := s.Authenticate(ctx, id, secret); (err == nil) != (tt.wantOldAuth || !tt.wantSameID) {
+				t.Errorf("原密钥 Authenticate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, 0)
+	id, secret := mustRegister(t, s, testMailInfo("rt-1"))
+
+	tests := []struct {
+		name    string
+		id      string
+		secret  string
+		wantErr error
+	}{
+		{"正确的密钥", id, secret, nil},
+		{"错误的密钥", id, "wrong", ErrInvalidSecret},
+		{"空密钥", id, "", ErrInvalidSecret},
+		{"密钥的哈希", id, hashSecret(secret), ErrInvalidSecret},
+		{"账户不存在", "acc_missing", secret, ErrAccountNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Authenticate(ctx, tt.id, tt.secret); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewAccountIDAndSecret(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		id, err := newAccountID()
+		if err != nil {
+			t.Fatalf("newAccountID 失败: %v", err)
+		}
+		secret, err := newSecret()
+		if err != nil {
+			t.Fatalf("newSecret 失败: %v", err)
+		}
+		if seen[id] || seen[secret] {
+			t.Fatalf("生成了重复的账户 ID 或密钥: %q, %q", id, secret)
+		}
+		seen[id], seen[secret] = true, true
+	}
+}
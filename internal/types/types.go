@@ -1,26 +1,141 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+)
+
 // ServiceProvider 服务提供商类型
 type ServiceProvider string
 
 const (
 	ServiceProviderMicrosoft ServiceProvider = "MICROSOFT"
 	ServiceProviderGoogle    ServiceProvider = "GOOGLE"
+	ServiceProviderGeneric   ServiceProvider = "GENERIC" // 通用邮件服务器（用户名/密码或应用专用密码认证，支持 IMAP、POP3、JMAP 和 EWS 协议）
+	ServiceProviderYahoo     ServiceProvider = "YAHOO"   // Yahoo 邮箱（OAuth2，仅支持 IMAP 协议）
+	ServiceProviderAOL       ServiceProvider = "AOL"     // AOL 邮箱（OAuth2，仅支持 IMAP 协议）
 )
 
 // ProtocolType 协议类型
 type ProtocolType string
 
 const (
-	ProtocolTypeIMAP  ProtocolType = "IMAP"
-	ProtocolTypeGraph ProtocolType = "GRAPH"
+	ProtocolTypeIMAP     ProtocolType = "IMAP"
+	ProtocolTypeGraph    ProtocolType = "GRAPH"
+	ProtocolTypeGmailAPI ProtocolType = "GMAIL_API" // Gmail REST API（仅 Google 账户）
+	ProtocolTypePOP3     ProtocolType = "POP3"      // POP3（只能访问收件箱，不支持 Yahoo、AOL 账户）
+	ProtocolTypeJMAP     ProtocolType = "JMAP"      // JMAP（RFC 8620/8621，如 Fastmail、Stalwart，仅 GENERIC 账户）
+	ProtocolTypeEWS      ProtocolType = "EWS"       // Exchange Web Services（本地部署的 Exchange 使用 GENERIC 账户，Exchange Online 使用微软账户）
+)
+
+// SecurityMode 连接加密方式
+type SecurityMode string
+
+const (
+	SecurityModeTLS      SecurityMode = "TLS"      // 直接使用 TLS 连接（默认）
+	SecurityModeSTARTTLS SecurityMode = "STARTTLS" // 明文连接后通过 STARTTLS 升级
 )
 
+// AuthMechanism 密码认证方式
+type AuthMechanism string
+
+const (
+	AuthMechanismLogin  AuthMechanism = "LOGIN"  // IMAP LOGIN 命令 / SMTP AUTH LOGIN（默认）
+	AuthMechanismPlain  AuthMechanism = "PLAIN"  // SASL PLAIN
+	AuthMechanismBearer AuthMechanism = "BEARER" // 将 password 作为 API 令牌，使用 HTTP Bearer 认证（仅 JMAP 和 EWS 协议）
+	AuthMechanismNTLM   AuthMechanism = "NTLM"   // NTLM 认证，username 可以是 DOMAIN\user 格式（仅 EWS 协议）
+)
+
+// ServerSettings 邮件服务器连接配置（GENERIC 账户使用）
+type ServerSettings struct {
+	Host     string       `json:"host"`
+	Port     int          `json:"port,omitempty"`     // 为空时按加密方式使用默认端口（IMAP 993/143，SMTP 465/587）
+	Security SecurityMode `json:"security,omitempty"` // 为空时使用 TLS
+}
+
 // MailInfo 邮件信息
 type MailInfo struct {
+	AccountID       string          `json:"accountId,omitempty"`     // 已保存账户的 ID，设置后其他字段从 refresh token 存储中读取（protocolType 不为空时使用请求中的值）
+	AccountSecret   string          `json:"accountSecret,omitempty"` // 已保存账户的访问密钥（保存账户时返回），使用 accountId 时必填
 	Email           string          `json:"email"`
 	ClientID        string          `json:"clientId"`
+	ClientSecret    string          `json:"clientSecret,omitempty"` // 客户端密钥（Google、Yahoo、AOL 账户需要，微软机密客户端可选）
 	RefreshToken    string          `json:"refreshToken"`
 	ProtocolType    ProtocolType    `json:"protocolType"`
 	ServiceProvider ServiceProvider `json:"serviceProvider"`
+
+	// 微软工作/学校账户（Entra ID）
+	Tenant            string `json:"tenant,omitempty"`            // 租户 ID、租户域名，或 common、organizations、consumers，为空时使用 consumers（个人账户）
+	ClientCertificate string `json:"clientCertificate,omitempty"` // 机密客户端的 PEM 证书（未设置 clientSecret 时用于生成证书断言）
+	ClientPrivateKey  string `json:"clientPrivateKey,omitempty"`  // 证书对应的 PEM 私钥（RSA），为空时从 clientCertificate 中查找
+
+	// 密码认证（GENERIC 账户使用，不需要 clientId 和 refreshToken）
+	Username      string          `json:"username,omitempty"`      // 登录用户名，为空时使用 email
+	Password      string          `json:"password,omitempty"`      // 密码或应用专用密码
+	AuthMechanism AuthMechanism   `json:"authMechanism,omitempty"` // 为空时使用 LOGIN
+	Imap          *ServerSettings `json:"imap,omitempty"`          // IMAP 服务器（IMAP 协议时必填）
+	Smtp          *ServerSettings `json:"smtp,omitempty"`          // SMTP 服务器（发送、回复邮件时必填）
+	Pop3          *ServerSettings `json:"pop3,omitempty"`          // POP3 服务器（POP3 协议时必填）
+	JmapURL       string          `json:"jmapUrl,omitempty"`       // JMAP 会话地址或服务器域名（JMAP 协议时必填）
+	EwsURL        string          `json:"ewsUrl,omitempty"`        // EWS 地址，如 https://mail.example.com/EWS/Exchange.asmx（EWS 协议时必填，微软账户为空时使用 Exchange Online）
+}
+
+// UsesPasswordAuth 是否使用密码认证（不需要获取访问令牌）
+func (m *MailInfo) UsesPasswordAuth() bool {
+	return m.ServiceProvider == ServiceProviderGeneric
+}
+
+// ValidatePasswordAuth 校验密码认证账户的配置
+func (m *MailInfo) ValidatePasswordAuth() error {
+	if m.Password == "" {
+		return errors.New("password 不能为空")
+	}
+
+	switch m.ProtocolType {
+	case ProtocolTypeIMAP:
+		if m.Imap == nil || m.Imap.Host == "" {
+			return errors.New("imap.host 不能为空")
+		}
+	case ProtocolTypePOP3:
+		if m.Pop3 == nil || m.Pop3.Host == "" {
+			return errors.New("pop3.host 不能为空")
+		}
+	case ProtocolTypeJMAP:
+		if m.JmapURL == "" {
+			return errors.New("jmapUrl 不能为空")
+		}
+	case ProtocolTypeEWS:
+		if m.EwsURL == "" {
+			return errors.New("ewsUrl 不能为空")
+		}
+	default:
+		return fmt.Errorf("%s 账户只支持 IMAP、POP3、JMAP 和 EWS 协议", m.ServiceProvider)
+	}
+
+	switch m.AuthMechanism {
+	case "", AuthMechanismLogin, AuthMechanismPlain:
+	case AuthMechanismBearer:
+		if m.ProtocolType != ProtocolTypeJMAP && m.ProtocolType != ProtocolTypeEWS {
+			return fmt.Errorf("%s 认证方式只支持 JMAP 和 EWS 协议", m.AuthMechanism)
+		}
+	case AuthMechanismNTLM:
+		if m.ProtocolType != ProtocolTypeEWS {
+			return fmt.Errorf("%s 认证方式只支持 EWS 协议", m.AuthMechanism)
+		}
+	default:
+		return fmt.Errorf("不支持的认证方式: %s", m.AuthMechanism)
+	}
+
+	for name, settings := range map[string]*ServerSettings{"imap": m.Imap, "smtp": m.Smtp, "pop3": m.Pop3} {
+		if settings == nil {
+			continue
+		}
+		switch settings.Security {
+		case "", SecurityModeTLS, SecurityModeSTARTTLS:
+		default:
+			return fmt.Errorf("%s.security 不支持: %s", name, settings.Security)
+		}
+	}
+
+	return nil
 }
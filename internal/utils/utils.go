@@ -9,11 +9,19 @@ import (
 	"github.com/cespare/xxhash"
 )
 
-// GenerateCacheKey 生成基于 refresh token 短哈希的缓存键
-func GenerateCacheKey(refreshToken string) string {
-	hash := xxhash.Sum64([]byte(refreshToken))
+// 缓存键格式
+const (
+	CacheKeyPrefix        = "access_token:v2:"              // 当前格式的缓存键前缀
+	LegacyCacheKeyPattern = "access_token:????????????????" // 旧格式缓存键（只包含 refresh token 短哈希）的匹配模式
+)
+
+// GenerateCacheKey 生成基于服务提供商、clientId、scope 和 refresh token 短哈希的缓存键
+// 同一个 refresh token 换取的不同 scope（受众）的 access token 使用不同的键，如 IMAP 和 Graph
+func GenerateCacheKey(provider, clientID, scope, refreshToken string) string {
+	// 使用 \x00 分隔各字段，避免字段拼接产生歧义
+	hash := xxhash.Sum64String(provider + "\x00" + clientID + "\x00" + scope + "\x00" + refreshToken)
 	shortHash := fmt.Sprintf("%016x", hash) // 16字符十六进制
-	return fmt.Sprintf("access_token:%s", shortHash)
+	return CacheKeyPrefix + shortHash
 }
 
 // CleanEmailAddress 清理邮件地址，支持 *mail.Address 和 *domain.EmailAddress
@@ -22,9 +30,15 @@ func CleanEmailAddress(emailAddress interface{}) *domain.EmailAddress {
 
 	switch addr := emailAddress.(type) {
 	case *domain.EmailAddress:
+		if addr == nil {
+			return nil
+		}
 		name = addr.Name
 		address = addr.Address
 	case *mail.Address:
+		if addr == nil {
+			return nil
+		}
 		name = addr.Name
 		address = addr.Address
 	default:
@@ -26,6 +26,9 @@ type ServiceProvider int32
 const (
 	ServiceProvider_MICROSOFT ServiceProvider = 0
 	ServiceProvider_GOOGLE    ServiceProvider = 1
+	ServiceProvider_GENERIC   ServiceProvider = 2 // 通用邮件服务器（用户名/密码认证，支持 IMAP、POP3、JMAP 和 EWS 协议）
+	ServiceProvider_YAHOO     ServiceProvider = 3 // Yahoo 邮箱（OAuth2，仅支持 IMAP 协议）
+	ServiceProvider_AOL       ServiceProvider = 4 // AOL 邮箱（OAuth2，仅支持 IMAP 协议）
 )
 
 // Enum value maps for ServiceProvider.
@@ -33,10 +36,16 @@ var (
 	ServiceProvider_name = map[int32]string{
 		0: "MICROSOFT",
 		1: "GOOGLE",
+		2: "GENERIC",
+		3: "YAHOO",
+		4: "AOL",
 	}
 	ServiceProvider_value = map[string]int32{
 		"MICROSOFT": 0,
 		"GOOGLE":    1,
+		"GENERIC":   2,
+		"YAHOO":     3,
+		"AOL":       4,
 	}
 )
 
@@ -71,8 +80,12 @@ func (ServiceProvider) EnumDescriptor() ([]byte, []int) {
 type ProtocolType int32
 
 const (
-	ProtocolType_IMAP  ProtocolType = 0
-	ProtocolType_GRAPH ProtocolType = 1
+	ProtocolType_IMAP      ProtocolType = 0
+	ProtocolType_GRAPH     ProtocolType = 1
+	ProtocolType_GMAIL_API ProtocolType = 2 // Gmail REST API（仅 Google 账户）
+	ProtocolType_POP3      ProtocolType = 3 // POP3（只能访问收件箱，不支持 Yahoo、AOL 账户）
+	ProtocolType_JMAP      ProtocolType = 4 // JMAP（如 Fastmail、Stalwart，仅 GENERIC 账户）
+	ProtocolType_EWS       ProtocolType = 5 // Exchange Web Services（本地部署的 Exchange 使用 GENERIC 账户，Exchange Online 使用微软账户）
 )
 
 // Enum value maps for ProtocolType.
@@ -80,10 +93,18 @@ var (
 	ProtocolType_name = map[int32]string{
 		0: "IMAP",
 		1: "GRAPH",
+		2: "GMAIL_API",
+		3: "POP3",
+		4: "JMAP",
+		5: "EWS",
 	}
 	ProtocolType_value = map[string]int32{
-		"IMAP":  0,
-		"GRAPH": 1,
+		"IMAP":      0,
+		"GRAPH":     1,
+		"GMAIL_API": 2,
+		"POP3":      3,
+		"JMAP":      4,
+		"EWS":       5,
 	}
 )
 
@@ -114,6 +135,156 @@ func (ProtocolType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{1}
 }
 
+// 连接加密方式
+type SecurityMode int32
+
+const (
+	SecurityMode_TLS      SecurityMode = 0 // 直接使用 TLS 连接
+	SecurityMode_STARTTLS SecurityMode = 1 // 明文连接后通过 STARTTLS 升级
+)
+
+// Enum value maps for SecurityMode.
+var (
+	SecurityMode_name = map[int32]string{
+		0: "TLS",
+		1: "STARTTLS",
+	}
+	SecurityMode_value = map[string]int32{
+		"TLS":      0,
+		"STARTTLS": 1,
+	}
+)
+
+func (x SecurityMode) Enum() *SecurityMode {
+	p := new(SecurityMode)
+	*p = x
+	return p
+}
+
+func (x SecurityMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SecurityMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_server_proto_enumTypes[2].Descriptor()
+}
+
+func (SecurityMode) Type() protoreflect.EnumType {
+	return &file_proto_server_proto_enumTypes[2]
+}
+
+func (x SecurityMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SecurityMode.Descriptor instead.
+func (SecurityMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{2}
+}
+
+// 密码认证方式
+type AuthMechanism int32
+
+const (
+	AuthMechanism_LOGIN  AuthMechanism = 0 // IMAP LOGIN 命令 / SMTP AUTH LOGIN
+	AuthMechanism_PLAIN  AuthMechanism = 1 // SASL PLAIN
+	AuthMechanism_BEARER AuthMechanism = 2 // 将 password 作为 API 令牌，使用 HTTP Bearer 认证（仅 JMAP 和 EWS 协议）
+	AuthMechanism_NTLM   AuthMechanism = 3 // NTLM 认证，username 可以是 DOMAIN\user 格式（仅 EWS 协议）
+)
+
+// Enum value maps for AuthMechanism.
+var (
+	AuthMechanism_name = map[int32]string{
+		0: "LOGIN",
+		1: "PLAIN",
+		2: "BEARER",
+		3: "NTLM",
+	}
+	AuthMechanism_value = map[string]int32{
+		"LOGIN":  0,
+		"PLAIN":  1,
+		"BEARER": 2,
+		"NTLM":   3,
+	}
+)
+
+func (x AuthMechanism) Enum() *AuthMechanism {
+	p := new(AuthMechanism)
+	*p = x
+	return p
+}
+
+func (x AuthMechanism) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthMechanism) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_server_proto_enumTypes[3].Descriptor()
+}
+
+func (AuthMechanism) Type() protoreflect.EnumType {
+	return &file_proto_server_proto_enumTypes[3]
+}
+
+func (x AuthMechanism) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthMechanism.Descriptor instead.
+func (AuthMechanism) EnumDescriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{3}
+}
+
+// 回复方式（对应 domain.ReplyMode）
+type ReplyMode int32
+
+const (
+	ReplyMode_REPLY     ReplyMode = 0
+	ReplyMode_REPLY_ALL ReplyMode = 1
+	ReplyMode_FORWARD   ReplyMode = 2
+)
+
+// Enum value maps for ReplyMode.
+var (
+	ReplyMode_name = map[int32]string{
+		0: "REPLY",
+		1: "REPLY_ALL",
+		2: "FORWARD",
+	}
+	ReplyMode_value = map[string]int32{
+		"REPLY":     0,
+		"REPLY_ALL": 1,
+		"FORWARD":   2,
+	}
+)
+
+func (x ReplyMode) Enum() *ReplyMode {
+	p := new(ReplyMode)
+	*p = x
+	return p
+}
+
+func (x ReplyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReplyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_server_proto_enumTypes[4].Descriptor()
+}
+
+func (ReplyMode) Type() protoreflect.EnumType {
+	return &file_proto_server_proto_enumTypes[4]
+}
+
+func (x ReplyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReplyMode.Descriptor instead.
+func (ReplyMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{4}
+}
+
 // 邮件信息（对应 types.MailInfo）
 type MailInfo struct {
 	state         protoimpl.MessageState
@@ -125,6 +296,22 @@ type MailInfo struct {
 	RefreshToken    string          `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	ProtoType       ProtocolType    `protobuf:"varint,4,opt,name=proto_type,json=protoType,proto3,enum=ProtocolType" json:"proto_type,omitempty"`
 	ServiceProvider ServiceProvider `protobuf:"varint,5,opt,name=service_provider,json=serviceProvider,proto3,enum=ServiceProvider" json:"service_provider,omitempty"`
+	ClientSecret    string          `protobuf:"bytes,6,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // 客户端密钥（Google、Yahoo、AOL 账户需要，微软机密客户端可选）
+	// 密码认证（GENERIC 账户使用，不需要 client_id 和 refresh_token）
+	Username      string          `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"` // 登录用户名，为空时使用 email
+	Password      string          `protobuf:"bytes,8,opt,name=password,proto3" json:"password,omitempty"` // 密码或应用专用密码
+	AuthMechanism AuthMechanism   `protobuf:"varint,9,opt,name=auth_mechanism,json=authMechanism,proto3,enum=AuthMechanism" json:"auth_mechanism,omitempty"`
+	Imap          *ServerSettings `protobuf:"bytes,10,opt,name=imap,proto3" json:"imap,omitempty"` // IMAP 服务器（IMAP 协议时必填）
+	Smtp          *ServerSettings `protobuf:"bytes,11,opt,name=smtp,proto3" json:"smtp,omitempty"` // SMTP 服务器（发送、回复邮件时必填）
+	// 微软工作/学校账户（Entra ID）
+	Tenant            string          `protobuf:"bytes,12,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                // 租户 ID、租户域名，或 common、organizations、consumers，为空时使用 consumers
+	ClientCertificate string          `protobuf:"bytes,13,opt,name=client_certificate,json=clientCertificate,proto3" json:"client_certificate,omitempty"` // 机密客户端的 PEM 证书（未设置 client_secret 时用于生成证书断言）
+	ClientPrivateKey  string          `protobuf:"bytes,14,opt,name=client_private_key,json=clientPrivateKey,proto3" json:"client_private_key,omitempty"`  // 证书对应的 PEM 私钥（RSA），为空时从 client_certificate 中查找
+	Pop3              *ServerSettings `protobuf:"bytes,15,opt,name=pop3,proto3" json:"pop3,omitempty"`                                                    // POP3 服务器（GENERIC 账户使用 POP3 协议时必填）
+	JmapUrl           string          `protobuf:"bytes,16,opt,name=jmap_url,json=jmapUrl,proto3" json:"jmap_url,omitempty"`                               // JMAP 会话地址或服务器域名（GENERIC 账户使用 JMAP 协议时必填）
+	EwsUrl            string          `protobuf:"bytes,17,opt,name=ews_url,json=ewsUrl,proto3" json:"ews_url,omitempty"`                                  // EWS 地址（GENERIC 账户使用 EWS 协议时必填，微软账户为空时使用 Exchange Online）
+	AccountId         string          `protobuf:"bytes,18,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`                         // 已保存账户的 ID，设置后其他字段（包括 proto_type）从 refresh token 存储中读取
+	AccountSecret     string          `protobuf:"bytes,19,opt,name=account_secret,json=accountSecret,proto3" json:"account_secret,omitempty"`             // 已保存账户的访问密钥（保存账户时返回），使用 account_id 时必填
 }
 
 func (x *MailInfo) Reset() {
@@ -192,6 +379,166 @@ func (x *MailInfo) GetServiceProvider() ServiceProvider {
 	return ServiceProvider_MICROSOFT
 }
 
+func (x *MailInfo) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *MailInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MailInfo) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *MailInfo) GetAuthMechanism() AuthMechanism {
+	if x != nil {
+		return x.AuthMechanism
+	}
+	return AuthMechanism_LOGIN
+}
+
+func (x *MailInfo) GetImap() *ServerSettings {
+	if x != nil {
+		return x.Imap
+	}
+	return nil
+}
+
+func (x *MailInfo) GetSmtp() *ServerSettings {
+	if x != nil {
+		return x.Smtp
+	}
+	return nil
+}
+
+func (x *MailInfo) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *MailInfo) GetClientCertificate() string {
+	if x != nil {
+		return x.ClientCertificate
+	}
+	return ""
+}
+
+func (x *MailInfo) GetClientPrivateKey() string {
+	if x != nil {
+		return x.ClientPrivateKey
+	}
+	return ""
+}
+
+func (x *MailInfo) GetPop3() *ServerSettings {
+	if x != nil {
+		return x.Pop3
+	}
+	return nil
+}
+
+func (x *MailInfo) GetJmapUrl() string {
+	if x != nil {
+		return x.JmapUrl
+	}
+	return ""
+}
+
+func (x *MailInfo) GetEwsUrl() string {
+	if x != nil {
+		return x.EwsUrl
+	}
+	return ""
+}
+
+func (x *MailInfo) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *MailInfo) GetAccountSecret() string {
+	if x != nil {
+		return x.AccountSecret
+	}
+	return ""
+}
+
+// 邮件服务器连接配置（对应 types.ServerSettings）
+type ServerSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host     string       `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port     int32        `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"` // 为 0 时按加密方式使用默认端口（IMAP 993/143，SMTP 465/587）
+	Security SecurityMode `protobuf:"varint,3,opt,name=security,proto3,enum=SecurityMode" json:"security,omitempty"`
+}
+
+func (x *ServerSettings) Reset() {
+	*x = ServerSettings{}
+	mi := &file_proto_server_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerSettings) ProtoMessage() {}
+
+func (x *ServerSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerSettings.ProtoReflect.Descriptor instead.
+func (*ServerSettings) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServerSettings) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ServerSettings) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ServerSettings) GetSecurity() SecurityMode {
+	if x != nil {
+		return x.Security
+	}
+	return SecurityMode_TLS
+}
+
 // 邮件地址（对应 domain.EmailAddress）
 type EmailAddress struct {
 	state         protoimpl.MessageState
@@ -204,7 +551,7 @@ type EmailAddress struct {
 
 func (x *EmailAddress) Reset() {
 	*x = EmailAddress{}
-	mi := &file_proto_server_proto_msgTypes[1]
+	mi := &file_proto_server_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +563,7 @@ func (x *EmailAddress) String() string {
 func (*EmailAddress) ProtoMessage() {}
 
 func (x *EmailAddress) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[1]
+	mi := &file_proto_server_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +576,7 @@ func (x *EmailAddress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmailAddress.ProtoReflect.Descriptor instead.
 func (*EmailAddress) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{1}
+	return file_proto_server_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *EmailAddress) GetName() string {
@@ -252,18 +599,26 @@ type Email struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Subject string        `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
-	From    *EmailAddress `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
-	To      *EmailAddress `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
-	Date    string        `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
-	Text    string        `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
-	Html    string        `protobuf:"bytes,7,opt,name=html,proto3" json:"html,omitempty"`
+	Id            string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subject       string          `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	From          *EmailAddress   `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            *EmailAddress   `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"` // 第一个收件人（完整列表见 to_recipients）
+	Date          string          `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	Text          string          `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Html          string          `protobuf:"bytes,7,opt,name=html,proto3" json:"html,omitempty"`
+	Attachments   []*Attachment   `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"` // 附件元数据
+	Sender        *EmailAddress   `protobuf:"bytes,9,opt,name=sender,proto3,oneof" json:"sender,omitempty"`     // 实际发送者（代发时与 from 不同）
+	ToRecipients  []*EmailAddress `protobuf:"bytes,10,rep,name=to_recipients,json=toRecipients,proto3" json:"to_recipients,omitempty"`
+	CcRecipients  []*EmailAddress `protobuf:"bytes,11,rep,name=cc_recipients,json=ccRecipients,proto3" json:"cc_recipients,omitempty"`
+	BccRecipients []*EmailAddress `protobuf:"bytes,12,rep,name=bcc_recipients,json=bccRecipients,proto3" json:"bcc_recipients,omitempty"` // 通常只有已发送的邮件才有
+	ReplyTo       []*EmailAddress `protobuf:"bytes,13,rep,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	Truncated     bool            `protobuf:"varint,14,opt,name=truncated,proto3" json:"truncated,omitempty"`  // 正文是否因超过大小限制而被截断
+	References    []string        `protobuf:"bytes,15,rep,name=references,proto3" json:"references,omitempty"` // 会话中前序邮件的 Message-ID 列表（IMAP 和 Gmail API 提供）
 }
 
 func (x *Email) Reset() {
 	*x = Email{}
-	mi := &file_proto_server_proto_msgTypes[2]
+	mi := &file_proto_server_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -275,7 +630,7 @@ func (x *Email) String() string {
 func (*Email) ProtoMessage() {}
 
 func (x *Email) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[2]
+	mi := &file_proto_server_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,7 +643,7 @@ func (x *Email) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Email.ProtoReflect.Descriptor instead.
 func (*Email) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{2}
+	return file_proto_server_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Email) GetId() string {
@@ -340,84 +695,90 @@ func (x *Email) GetHtml() string {
 	return ""
 }
 
-// 获取最新邮件请求（对应 dto.GetNewMailRequest）
-type GetNewMailRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	MailInfo      *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
-	RefreshNeeded bool      `protobuf:"varint,2,opt,name=refresh_needed,json=refreshNeeded,proto3" json:"refresh_needed,omitempty"`
+func (x *Email) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
 }
 
-func (x *GetNewMailRequest) Reset() {
-	*x = GetNewMailRequest{}
-	mi := &file_proto_server_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Email) GetSender() *EmailAddress {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
 }
 
-func (x *GetNewMailRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Email) GetToRecipients() []*EmailAddress {
+	if x != nil {
+		return x.ToRecipients
+	}
+	return nil
 }
 
-func (*GetNewMailRequest) ProtoMessage() {}
-
-func (x *GetNewMailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[3]
+func (x *Email) GetCcRecipients() []*EmailAddress {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CcRecipients
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetNewMailRequest.ProtoReflect.Descriptor instead.
-func (*GetNewMailRequest) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{3}
+func (x *Email) GetBccRecipients() []*EmailAddress {
+	if x != nil {
+		return x.BccRecipients
+	}
+	return nil
 }
 
-func (x *GetNewMailRequest) GetMailInfo() *MailInfo {
+func (x *Email) GetReplyTo() []*EmailAddress {
 	if x != nil {
-		return x.MailInfo
+		return x.ReplyTo
 	}
 	return nil
 }
 
-func (x *GetNewMailRequest) GetRefreshNeeded() bool {
+func (x *Email) GetTruncated() bool {
 	if x != nil {
-		return x.RefreshNeeded
+		return x.Truncated
 	}
 	return false
 }
 
-// 获取最新邮件响应
-type GetNewMailResponse struct {
+func (x *Email) GetReferences() []string {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+// 附件元数据（对应 domain.Attachment）
+type Attachment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email        *Email  `protobuf:"bytes,1,opt,name=email,proto3,oneof" json:"email,omitempty"`                                   // 没有找到邮件时为空
-	RefreshToken *string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3,oneof" json:"refresh_token,omitempty"` // 当 refresh_needed=true 时返回
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // IMAP 为 MIME 分段路径，Graph 和 Gmail API 为附件 ID
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	ContentId   string `protobuf:"bytes,5,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"` // IMAP 和 Gmail API 提供
+	IsInline    bool   `protobuf:"varint,6,opt,name=is_inline,json=isInline,proto3" json:"is_inline,omitempty"`
 }
 
-func (x *GetNewMailResponse) Reset() {
-	*x = GetNewMailResponse{}
+func (x *Attachment) Reset() {
+	*x = Attachment{}
 	mi := &file_proto_server_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNewMailResponse) String() string {
+func (x *Attachment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNewMailResponse) ProtoMessage() {}
+func (*Attachment) ProtoMessage() {}
 
-func (x *GetNewMailResponse) ProtoReflect() protoreflect.Message {
+func (x *Attachment) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -429,49 +790,78 @@ func (x *GetNewMailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNewMailResponse.ProtoReflect.Descriptor instead.
-func (*GetNewMailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetNewMailResponse) GetEmail() *Email {
+func (x *Attachment) GetId() string {
 	if x != nil {
-		return x.Email
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *GetNewMailResponse) GetRefreshToken() string {
-	if x != nil && x.RefreshToken != nil {
-		return *x.RefreshToken
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-// 查找邮件请求（对应 dto.FindMailRequest）
-type FindMailRequest struct {
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetContentId() string {
+	if x != nil {
+		return x.ContentId
+	}
+	return ""
+}
+
+func (x *Attachment) GetIsInline() bool {
+	if x != nil {
+		return x.IsInline
+	}
+	return false
+}
+
+// 获取最新邮件请求（对应 dto.GetNewMailRequest）
+type GetNewMailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
-	EmailId  string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // 从 URL 路径参数获取
+	MailInfo      *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	RefreshNeeded bool      `protobuf:"varint,2,opt,name=refresh_needed,json=refreshNeeded,proto3" json:"refresh_needed,omitempty"`
+	Folder        string    `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱
 }
 
-func (x *FindMailRequest) Reset() {
-	*x = FindMailRequest{}
+func (x *GetNewMailRequest) Reset() {
+	*x = GetNewMailRequest{}
 	mi := &file_proto_server_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindMailRequest) String() string {
+func (x *GetNewMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindMailRequest) ProtoMessage() {}
+func (*GetNewMailRequest) ProtoMessage() {}
 
-func (x *FindMailRequest) ProtoReflect() protoreflect.Message {
+func (x *GetNewMailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -483,48 +873,56 @@ func (x *FindMailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindMailRequest.ProtoReflect.Descriptor instead.
-func (*FindMailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetNewMailRequest.ProtoReflect.Descriptor instead.
+func (*GetNewMailRequest) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *FindMailRequest) GetMailInfo() *MailInfo {
+func (x *GetNewMailRequest) GetMailInfo() *MailInfo {
 	if x != nil {
 		return x.MailInfo
 	}
 	return nil
 }
 
-func (x *FindMailRequest) GetEmailId() string {
+func (x *GetNewMailRequest) GetRefreshNeeded() bool {
 	if x != nil {
-		return x.EmailId
+		return x.RefreshNeeded
+	}
+	return false
+}
+
+func (x *GetNewMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
 	}
 	return ""
 }
 
-// 查找邮件响应
-type FindMailResponse struct {
+// 获取最新邮件响应
+type GetNewMailResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email *Email `protobuf:"bytes,1,opt,name=email,proto3,oneof" json:"email,omitempty"` // 没有找到邮件时为空
+	Email        *Email  `protobuf:"bytes,1,opt,name=email,proto3,oneof" json:"email,omitempty"`                                   // 没有找到邮件时为空
+	RefreshToken *string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3,oneof" json:"refresh_token,omitempty"` // 当 refresh_needed=true 时返回
 }
 
-func (x *FindMailResponse) Reset() {
-	*x = FindMailResponse{}
+func (x *GetNewMailResponse) Reset() {
+	*x = GetNewMailResponse{}
 	mi := &file_proto_server_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindMailResponse) String() string {
+func (x *GetNewMailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindMailResponse) ProtoMessage() {}
+func (*GetNewMailResponse) ProtoMessage() {}
 
-func (x *FindMailResponse) ProtoReflect() protoreflect.Message {
+func (x *GetNewMailResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -536,41 +934,50 @@ func (x *FindMailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindMailResponse.ProtoReflect.Descriptor instead.
-func (*FindMailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetNewMailResponse.ProtoReflect.Descriptor instead.
+func (*GetNewMailResponse) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *FindMailResponse) GetEmail() *Email {
+func (x *GetNewMailResponse) GetEmail() *Email {
 	if x != nil {
 		return x.Email
 	}
 	return nil
 }
 
-// 获取垃圾邮件请求（对应 dto.GetNewJunkMailRequest）
-type GetNewJunkMailRequest struct {
+func (x *GetNewMailResponse) GetRefreshToken() string {
+	if x != nil && x.RefreshToken != nil {
+		return *x.RefreshToken
+	}
+	return ""
+}
+
+// 查找邮件请求（对应 dto.FindMailRequest）
+type FindMailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId  string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // 从 URL 路径参数获取
+	Folder   string    `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"`                  // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
 }
 
-func (x *GetNewJunkMailRequest) Reset() {
-	*x = GetNewJunkMailRequest{}
+func (x *FindMailRequest) Reset() {
+	*x = FindMailRequest{}
 	mi := &file_proto_server_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNewJunkMailRequest) String() string {
+func (x *FindMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNewJunkMailRequest) ProtoMessage() {}
+func (*FindMailRequest) ProtoMessage() {}
 
-func (x *GetNewJunkMailRequest) ProtoReflect() protoreflect.Message {
+func (x *FindMailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -582,20 +989,34 @@ func (x *GetNewJunkMailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNewJunkMailRequest.ProtoReflect.Descriptor instead.
-func (*GetNewJunkMailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FindMailRequest.ProtoReflect.Descriptor instead.
+func (*FindMailRequest) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetNewJunkMailRequest) GetMailInfo() *MailInfo {
+func (x *FindMailRequest) GetMailInfo() *MailInfo {
 	if x != nil {
 		return x.MailInfo
 	}
 	return nil
 }
 
-// 获取垃圾邮件响应
-type GetNewJunkMailResponse struct {
+func (x *FindMailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *FindMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+// 查找邮件响应
+type FindMailResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -603,20 +1024,20 @@ type GetNewJunkMailResponse struct {
 	Email *Email `protobuf:"bytes,1,opt,name=email,proto3,oneof" json:"email,omitempty"` // 没有找到邮件时为空
 }
 
-func (x *GetNewJunkMailResponse) Reset() {
-	*x = GetNewJunkMailResponse{}
+func (x *FindMailResponse) Reset() {
+	*x = FindMailResponse{}
 	mi := &file_proto_server_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNewJunkMailResponse) String() string {
+func (x *FindMailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNewJunkMailResponse) ProtoMessage() {}
+func (*FindMailResponse) ProtoMessage() {}
 
-func (x *GetNewJunkMailResponse) ProtoReflect() protoreflect.Message {
+func (x *FindMailResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -628,42 +1049,44 @@ func (x *GetNewJunkMailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNewJunkMailResponse.ProtoReflect.Descriptor instead.
-func (*GetNewJunkMailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FindMailResponse.ProtoReflect.Descriptor instead.
+func (*FindMailResponse) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetNewJunkMailResponse) GetEmail() *Email {
+func (x *FindMailResponse) GetEmail() *Email {
 	if x != nil {
 		return x.Email
 	}
 	return nil
 }
 
-// 邮件订阅请求（对应 dto.SubscribeMailRequest）
-type SubscribeMailRequest struct {
+// 邮件列表请求（对应 dto.ListMailRequest）
+type ListMailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MailInfo      *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
-	RefreshNeeded bool      `protobuf:"varint,2,opt,name=refresh_needed,json=refreshNeeded,proto3" json:"refresh_needed,omitempty"`
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	Limit    int32     `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 每页数量，默认 10，最大 50
+	Cursor   string    `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // 分页游标，为空表示从最新的邮件开始
+	Folder   string    `protobuf:"bytes,4,opt,name=folder,proto3" json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱
 }
 
-func (x *SubscribeMailRequest) Reset() {
-	*x = SubscribeMailRequest{}
+func (x *ListMailRequest) Reset() {
+	*x = ListMailRequest{}
 	mi := &file_proto_server_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeMailRequest) String() string {
+func (x *ListMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeMailRequest) ProtoMessage() {}
+func (*ListMailRequest) ProtoMessage() {}
 
-func (x *SubscribeMailRequest) ProtoReflect() protoreflect.Message {
+func (x *ListMailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -675,51 +1098,63 @@ func (x *SubscribeMailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeMailRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeMailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListMailRequest.ProtoReflect.Descriptor instead.
+func (*ListMailRequest) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SubscribeMailRequest) GetMailInfo() *MailInfo {
+func (x *ListMailRequest) GetMailInfo() *MailInfo {
 	if x != nil {
 		return x.MailInfo
 	}
 	return nil
 }
 
-func (x *SubscribeMailRequest) GetRefreshNeeded() bool {
+func (x *ListMailRequest) GetLimit() int32 {
 	if x != nil {
-		return x.RefreshNeeded
+		return x.Limit
 	}
-	return false
+	return 0
 }
 
-// 邮件事件（SSE 流事件）- 简化版本
-type MailEvent struct {
+func (x *ListMailRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+// 邮件列表响应（对应 domain.EmailPage）
+type ListMailResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EventType    string  `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`                // "connected", "email", "heartbeat", "error", "closed"
-	Email        *Email  `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`                                   // 仅当 event_type="email" 时使用
-	Message      *string `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`                               // 用于 connected/heartbeat/error/closed 消息
-	RefreshToken *string `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3,oneof" json:"refresh_token,omitempty"` // 仅当连接成功且需要刷新时返回
+	Emails     []*Email `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`                                 // 按时间倒序排列
+	NextCursor *string  `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"` // 没有更多邮件时为空
 }
 
-func (x *MailEvent) Reset() {
-	*x = MailEvent{}
+func (x *ListMailResponse) Reset() {
+	*x = ListMailResponse{}
 	mi := &file_proto_server_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MailEvent) String() string {
+func (x *ListMailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MailEvent) ProtoMessage() {}
+func (*ListMailResponse) ProtoMessage() {}
 
-func (x *MailEvent) ProtoReflect() protoreflect.Message {
+func (x *ListMailResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -731,62 +1166,55 @@ func (x *MailEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MailEvent.ProtoReflect.Descriptor instead.
-func (*MailEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListMailResponse.ProtoReflect.Descriptor instead.
+func (*ListMailResponse) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *MailEvent) GetEventType() string {
-	if x != nil {
-		return x.EventType
-	}
-	return ""
-}
-
-func (x *MailEvent) GetEmail() *Email {
+func (x *ListMailResponse) GetEmails() []*Email {
 	if x != nil {
-		return x.Email
+		return x.Emails
 	}
 	return nil
 }
 
-func (x *MailEvent) GetMessage() string {
-	if x != nil && x.Message != nil {
-		return *x.Message
-	}
-	return ""
-}
-
-func (x *MailEvent) GetRefreshToken() string {
-	if x != nil && x.RefreshToken != nil {
-		return *x.RefreshToken
+func (x *ListMailResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
 	}
 	return ""
 }
 
-// 刷新 Token 请求（对应 dto.RefreshTokenRequest）
-type RefreshTokenRequest struct {
+// 邮件搜索条件（对应 domain.SearchCriteria，空值表示不限制）
+type SearchCriteria struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	From       string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                                // 发件人包含（匹配发件人的名称或地址）
+	To         string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                                    // 收件人包含（匹配收件人的名称或地址）
+	Subject    string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`                          // 主题包含
+	Body       string `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`                                // 正文包含
+	Since      string `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`                              // 起始时间（包含），RFC3339 格式
+	Before     string `protobuf:"bytes,6,opt,name=before,proto3" json:"before,omitempty"`                            // 截止时间（不包含），RFC3339 格式
+	UnreadOnly bool   `protobuf:"varint,7,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"` // 只搜索未读邮件
+	Folder     string `protobuf:"bytes,8,opt,name=folder,proto3" json:"folder,omitempty"`                            // 文件夹，为空表示收件箱
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
+func (x *SearchCriteria) Reset() {
+	*x = SearchCriteria{}
 	mi := &file_proto_server_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *SearchCriteria) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*SearchCriteria) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchCriteria) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -798,88 +1226,94 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchCriteria.ProtoReflect.Descriptor instead.
+func (*SearchCriteria) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *RefreshTokenRequest) GetMailInfo() *MailInfo {
+func (x *SearchCriteria) GetFrom() string {
 	if x != nil {
-		return x.MailInfo
+		return x.From
 	}
-	return nil
+	return ""
 }
 
-// 刷新 Token 响应
-type RefreshTokenResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	NewRefreshToken string `protobuf:"bytes,1,opt,name=new_refresh_token,json=newRefreshToken,proto3" json:"new_refresh_token,omitempty"`
+func (x *SearchCriteria) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
 }
 
-func (x *RefreshTokenResponse) Reset() {
-	*x = RefreshTokenResponse{}
-	mi := &file_proto_server_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SearchCriteria) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
 }
 
-func (x *RefreshTokenResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SearchCriteria) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
 }
 
-func (*RefreshTokenResponse) ProtoMessage() {}
-
-func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[12]
+func (x *SearchCriteria) GetSince() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Since
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{12}
+func (x *SearchCriteria) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
 }
 
-func (x *RefreshTokenResponse) GetNewRefreshToken() string {
+func (x *SearchCriteria) GetUnreadOnly() bool {
 	if x != nil {
-		return x.NewRefreshToken
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *SearchCriteria) GetFolder() string {
+	if x != nil {
+		return x.Folder
 	}
 	return ""
 }
 
-// 批量刷新 Token 请求（对应 dto.BatchRefreshTokenRequest）
-type BatchRefreshTokenRequest struct {
+// 邮件搜索请求（对应 dto.SearchMailRequest），响应与 ListMail 相同
+type SearchMailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MailInfos []*MailInfo `protobuf:"bytes,1,rep,name=mail_infos,json=mailInfos,proto3" json:"mail_infos,omitempty"`
+	MailInfo *MailInfo       `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	Criteria *SearchCriteria `protobuf:"bytes,2,opt,name=criteria,proto3" json:"criteria,omitempty"`
+	Limit    int32           `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor   string          `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
-func (x *BatchRefreshTokenRequest) Reset() {
-	*x = BatchRefreshTokenRequest{}
-	mi := &file_proto_server_proto_msgTypes[13]
+func (x *SearchMailRequest) Reset() {
+	*x = SearchMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchRefreshTokenRequest) String() string {
+func (x *SearchMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchRefreshTokenRequest) ProtoMessage() {}
+func (*SearchMailRequest) ProtoMessage() {}
 
-func (x *BatchRefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[13]
+func (x *SearchMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,44 +1324,69 @@ func (x *BatchRefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchRefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*BatchRefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SearchMailRequest.ProtoReflect.Descriptor instead.
+func (*SearchMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *BatchRefreshTokenRequest) GetMailInfos() []*MailInfo {
+func (x *SearchMailRequest) GetMailInfo() *MailInfo {
 	if x != nil {
-		return x.MailInfos
+		return x.MailInfo
 	}
 	return nil
 }
 
-// 批量刷新结果项（对应 dto.BatchRefreshResult）
-type BatchRefreshResult struct {
+func (x *SearchMailRequest) GetCriteria() *SearchCriteria {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *SearchMailRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchMailRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// 文件夹（对应 domain.Folder）
+type Folder struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email           string  `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	NewRefreshToken string  `protobuf:"bytes,2,opt,name=new_refresh_token,json=newRefreshToken,proto3" json:"new_refresh_token,omitempty"`
-	Error           *string `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"` // 只有失败时才设置
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Path        string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                         // 完整路径
+	ParentId    string `protobuf:"bytes,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // 顶层文件夹为空
+	Role        string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`                         // inbox/sent/drafts/junk/trash/archive，普通文件夹为空
+	TotalCount  int32  `protobuf:"varint,6,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	UnreadCount int32  `protobuf:"varint,7,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
 }
 
-func (x *BatchRefreshResult) Reset() {
-	*x = BatchRefreshResult{}
-	mi := &file_proto_server_proto_msgTypes[14]
+func (x *Folder) Reset() {
+	*x = Folder{}
+	mi := &file_proto_server_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchRefreshResult) String() string {
+func (x *Folder) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchRefreshResult) ProtoMessage() {}
+func (*Folder) ProtoMessage() {}
 
-func (x *BatchRefreshResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[14]
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -938,58 +1397,84 @@ func (x *BatchRefreshResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchRefreshResult.ProtoReflect.Descriptor instead.
-func (*BatchRefreshResult) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *BatchRefreshResult) GetEmail() string {
+func (x *Folder) GetId() string {
 	if x != nil {
-		return x.Email
+		return x.Id
 	}
 	return ""
 }
 
-func (x *BatchRefreshResult) GetNewRefreshToken() string {
+func (x *Folder) GetName() string {
 	if x != nil {
-		return x.NewRefreshToken
+		return x.Name
 	}
 	return ""
 }
 
-func (x *BatchRefreshResult) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
+func (x *Folder) GetPath() string {
+	if x != nil {
+		return x.Path
 	}
 	return ""
 }
 
-// 批量刷新 Token 响应（对应 dto.BatchRefreshTokenData）
-type BatchRefreshTokenResponse struct {
+func (x *Folder) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Folder) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Folder) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *Folder) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+// 获取文件夹列表请求（对应 dto.ListFoldersRequest）
+type ListFoldersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SuccessCount int32                 `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
-	FailCount    int32                 `protobuf:"varint,2,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`
-	Results      []*BatchRefreshResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
 }
 
-func (x *BatchRefreshTokenResponse) Reset() {
-	*x = BatchRefreshTokenResponse{}
-	mi := &file_proto_server_proto_msgTypes[15]
+func (x *ListFoldersRequest) Reset() {
+	*x = ListFoldersRequest{}
+	mi := &file_proto_server_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchRefreshTokenResponse) String() string {
+func (x *ListFoldersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchRefreshTokenResponse) ProtoMessage() {}
+func (*ListFoldersRequest) ProtoMessage() {}
 
-func (x *BatchRefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[15]
+func (x *ListFoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1000,55 +1485,89 @@ func (x *BatchRefreshTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchRefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*BatchRefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListFoldersRequest.ProtoReflect.Descriptor instead.
+func (*ListFoldersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *BatchRefreshTokenResponse) GetSuccessCount() int32 {
+func (x *ListFoldersRequest) GetMailInfo() *MailInfo {
 	if x != nil {
-		return x.SuccessCount
+		return x.MailInfo
 	}
-	return 0
+	return nil
 }
 
-func (x *BatchRefreshTokenResponse) GetFailCount() int32 {
+// 获取文件夹列表响应
+type ListFoldersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Folders []*Folder `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+}
+
+func (x *ListFoldersResponse) Reset() {
+	*x = ListFoldersResponse{}
+	mi := &file_proto_server_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFoldersResponse) ProtoMessage() {}
+
+func (x *ListFoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[15]
 	if x != nil {
-		return x.FailCount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *BatchRefreshTokenResponse) GetResults() []*BatchRefreshResult {
+// Deprecated: Use ListFoldersResponse.ProtoReflect.Descriptor instead.
+func (*ListFoldersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFoldersResponse) GetFolders() []*Folder {
 	if x != nil {
-		return x.Results
+		return x.Folders
 	}
 	return nil
 }
 
-// 协议检测请求（对应 dto.DetectProtocolTypeRequest）
-type DetectProtocolTypeRequest struct {
+// 导出邮件请求（对应 dto.ExportMailRequest）
+type ExportMailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"` // 邮箱信息（不包含 protoType）
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId  string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Folder   string    `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
 }
 
-func (x *DetectProtocolTypeRequest) Reset() {
-	*x = DetectProtocolTypeRequest{}
+func (x *ExportMailRequest) Reset() {
+	*x = ExportMailRequest{}
 	mi := &file_proto_server_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetectProtocolTypeRequest) String() string {
+func (x *ExportMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetectProtocolTypeRequest) ProtoMessage() {}
+func (*ExportMailRequest) ProtoMessage() {}
 
-func (x *DetectProtocolTypeRequest) ProtoReflect() protoreflect.Message {
+func (x *ExportMailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1060,41 +1579,55 @@ func (x *DetectProtocolTypeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetectProtocolTypeRequest.ProtoReflect.Descriptor instead.
-func (*DetectProtocolTypeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportMailRequest.ProtoReflect.Descriptor instead.
+func (*ExportMailRequest) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DetectProtocolTypeRequest) GetMailInfo() *MailInfo {
+func (x *ExportMailRequest) GetMailInfo() *MailInfo {
 	if x != nil {
 		return x.MailInfo
 	}
 	return nil
 }
 
-// 协议检测响应（对应 dto.DetectProtocolTypeResponse）
-type DetectProtocolTypeResponse struct {
+func (x *ExportMailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *ExportMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+// 邮件原始内容数据块
+type RawMailChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProtoType ProtocolType `protobuf:"varint,1,opt,name=proto_type,json=protoType,proto3,enum=ProtocolType" json:"proto_type,omitempty"` // 检测到的协议类型
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *DetectProtocolTypeResponse) Reset() {
-	*x = DetectProtocolTypeResponse{}
+func (x *RawMailChunk) Reset() {
+	*x = RawMailChunk{}
 	mi := &file_proto_server_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetectProtocolTypeResponse) String() string {
+func (x *RawMailChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetectProtocolTypeResponse) ProtoMessage() {}
+func (*RawMailChunk) ProtoMessage() {}
 
-func (x *DetectProtocolTypeResponse) ProtoReflect() protoreflect.Message {
+func (x *RawMailChunk) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1106,41 +1639,44 @@ func (x *DetectProtocolTypeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetectProtocolTypeResponse.ProtoReflect.Descriptor instead.
-func (*DetectProtocolTypeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RawMailChunk.ProtoReflect.Descriptor instead.
+func (*RawMailChunk) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *DetectProtocolTypeResponse) GetProtoType() ProtocolType {
+func (x *RawMailChunk) GetData() []byte {
 	if x != nil {
-		return x.ProtoType
+		return x.Data
 	}
-	return ProtocolType_IMAP
+	return nil
 }
 
-// 批量检测协议类型请求（对应 dto.BatchDetectProtocolTypeRequest）
-type BatchDetectProtocolTypeRequest struct {
+// 下载附件请求（对应 dto.DownloadAttachmentRequest）
+type DownloadAttachmentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MailInfos []*MailInfo `protobuf:"bytes,1,rep,name=mail_infos,json=mailInfos,proto3" json:"mail_infos,omitempty"` // 需要检测的邮箱信息列表
+	MailInfo     *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId      string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	AttachmentId string    `protobuf:"bytes,3,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	Folder       string    `protobuf:"bytes,4,opt,name=folder,proto3" json:"folder,omitempty"` // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
 }
 
-func (x *BatchDetectProtocolTypeRequest) Reset() {
-	*x = BatchDetectProtocolTypeRequest{}
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
 	mi := &file_proto_server_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchDetectProtocolTypeRequest) String() string {
+func (x *DownloadAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchDetectProtocolTypeRequest) ProtoMessage() {}
+func (*DownloadAttachmentRequest) ProtoMessage() {}
 
-func (x *BatchDetectProtocolTypeRequest) ProtoReflect() protoreflect.Message {
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1152,43 +1688,63 @@ func (x *BatchDetectProtocolTypeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchDetectProtocolTypeRequest.ProtoReflect.Descriptor instead.
-func (*BatchDetectProtocolTypeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *BatchDetectProtocolTypeRequest) GetMailInfos() []*MailInfo {
+func (x *DownloadAttachmentRequest) GetMailInfo() *MailInfo {
 	if x != nil {
-		return x.MailInfos
+		return x.MailInfo
 	}
 	return nil
 }
 
-// 批量检测协议类型结果项（对应 dto.BatchDetectProtocolTypeResult）
-type BatchDetectProtocolTypeResult struct {
+func (x *DownloadAttachmentRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+// 附件数据块
+type AttachmentChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email     string       `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                                             // 邮箱地址
-	ProtoType ProtocolType `protobuf:"varint,2,opt,name=proto_type,json=protoType,proto3,enum=ProtocolType" json:"proto_type,omitempty"` // 检测到的协议类型（成功时）
-	Error     *string      `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`                                       // 错误信息（失败时）
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof" json:"attachment,omitempty"` // 仅第一个数据块携带附件元数据
+	Data       []byte      `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *BatchDetectProtocolTypeResult) Reset() {
-	*x = BatchDetectProtocolTypeResult{}
+func (x *AttachmentChunk) Reset() {
+	*x = AttachmentChunk{}
 	mi := &file_proto_server_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchDetectProtocolTypeResult) String() string {
+func (x *AttachmentChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchDetectProtocolTypeResult) ProtoMessage() {}
+func (*AttachmentChunk) ProtoMessage() {}
 
-func (x *BatchDetectProtocolTypeResult) ProtoReflect() protoreflect.Message {
+func (x *AttachmentChunk) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_server_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1200,58 +1756,1736 @@ func (x *BatchDetectProtocolTypeResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchDetectProtocolTypeResult.ProtoReflect.Descriptor instead.
-func (*BatchDetectProtocolTypeResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttachmentChunk.ProtoReflect.Descriptor instead.
+func (*AttachmentChunk) Descriptor() ([]byte, []int) {
 	return file_proto_server_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *BatchDetectProtocolTypeResult) GetEmail() string {
+func (x *AttachmentChunk) GetAttachment() *Attachment {
 	if x != nil {
-		return x.Email
+		return x.Attachment
 	}
-	return ""
+	return nil
 }
 
-func (x *BatchDetectProtocolTypeResult) GetProtoType() ProtocolType {
+func (x *AttachmentChunk) GetData() []byte {
 	if x != nil {
-		return x.ProtoType
+		return x.Data
 	}
-	return ProtocolType_IMAP
-}
-
-func (x *BatchDetectProtocolTypeResult) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
-	}
-	return ""
+	return nil
 }
 
-// 批量检测协议类型响应（对应 dto.BatchDetectProtocolTypeResponse）
-type BatchDetectProtocolTypeResponse struct {
+// 修改邮件状态请求（对应 dto.MarkMailRequest），is_read 和 is_flagged 至少指定一个
+type MarkMailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SuccessCount int32                            `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"` // 成功检测的数量
-	FailCount    int32                            `protobuf:"varint,2,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`          // 失败检测的数量
-	Results      []*BatchDetectProtocolTypeResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`                                // 详细结果列表
+	MailInfo  *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId   string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Folder    string    `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"`                               // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
+	IsRead    *bool     `protobuf:"varint,4,opt,name=is_read,json=isRead,proto3,oneof" json:"is_read,omitempty"`          // 为空表示不修改
+	IsFlagged *bool     `protobuf:"varint,5,opt,name=is_flagged,json=isFlagged,proto3,oneof" json:"is_flagged,omitempty"` // 为空表示不修改
 }
 
-func (x *BatchDetectProtocolTypeResponse) Reset() {
-	*x = BatchDetectProtocolTypeResponse{}
+func (x *MarkMailRequest) Reset() {
+	*x = MarkMailRequest{}
 	mi := &file_proto_server_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
+func (x *MarkMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkMailRequest) ProtoMessage() {}
+
+func (x *MarkMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkMailRequest.ProtoReflect.Descriptor instead.
+func (*MarkMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MarkMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+func (x *MarkMailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *MarkMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *MarkMailRequest) GetIsRead() bool {
+	if x != nil && x.IsRead != nil {
+		return *x.IsRead
+	}
+	return false
+}
+
+func (x *MarkMailRequest) GetIsFlagged() bool {
+	if x != nil && x.IsFlagged != nil {
+		return *x.IsFlagged
+	}
+	return false
+}
+
+// 修改邮件状态响应
+type MarkMailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MarkMailResponse) Reset() {
+	*x = MarkMailResponse{}
+	mi := &file_proto_server_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkMailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkMailResponse) ProtoMessage() {}
+
+func (x *MarkMailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkMailResponse.ProtoReflect.Descriptor instead.
+func (*MarkMailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{21}
+}
+
+// 移动邮件请求（对应 dto.MoveMailRequest）
+type MoveMailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo     *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId      string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Folder       string    `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"`                                 // 邮件当前所在文件夹，为空表示收件箱（仅 IMAP 需要）
+	TargetFolder string    `protobuf:"bytes,4,opt,name=target_folder,json=targetFolder,proto3" json:"target_folder,omitempty"` // 目标文件夹（角色或文件夹 ID）
+}
+
+func (x *MoveMailRequest) Reset() {
+	*x = MoveMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveMailRequest) ProtoMessage() {}
+
+func (x *MoveMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveMailRequest.ProtoReflect.Descriptor instead.
+func (*MoveMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MoveMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+func (x *MoveMailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *MoveMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *MoveMailRequest) GetTargetFolder() string {
+	if x != nil {
+		return x.TargetFolder
+	}
+	return ""
+}
+
+// 移动邮件响应
+type MoveMailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmailId string `protobuf:"bytes,1,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // 移动后的邮件 ID（Graph 会分配新 ID，IMAP 的 Message-ID 不变）
+}
+
+func (x *MoveMailResponse) Reset() {
+	*x = MoveMailResponse{}
+	mi := &file_proto_server_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveMailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveMailResponse) ProtoMessage() {}
+
+func (x *MoveMailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveMailResponse.ProtoReflect.Descriptor instead.
+func (*MoveMailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MoveMailResponse) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+// 删除邮件请求（对应 dto.DeleteMailRequest）
+type DeleteMailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo  *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId   string    `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Folder    string    `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"`        // 文件夹（角色或文件夹 ID），为空表示收件箱（仅 IMAP 需要）
+	Permanent bool      `protobuf:"varint,4,opt,name=permanent,proto3" json:"permanent,omitempty"` // 为 true 时永久删除，否则移动到已删除邮件
+}
+
+func (x *DeleteMailRequest) Reset() {
+	*x = DeleteMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMailRequest) ProtoMessage() {}
+
+func (x *DeleteMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMailRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+func (x *DeleteMailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *DeleteMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *DeleteMailRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+// 删除邮件响应
+type DeleteMailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteMailResponse) Reset() {
+	*x = DeleteMailResponse{}
+	mi := &file_proto_server_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMailResponse) ProtoMessage() {}
+
+func (x *DeleteMailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMailResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{25}
+}
+
+// 待发送的附件（对应 domain.OutgoingAttachment）
+type OutgoingAttachment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // 为空时根据文件名推断
+	Content     []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ContentId   string `protobuf:"bytes,4,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"` // HTML 正文通过 cid: 引用内联图片时使用
+	IsInline    bool   `protobuf:"varint,5,opt,name=is_inline,json=isInline,proto3" json:"is_inline,omitempty"`
+}
+
+func (x *OutgoingAttachment) Reset() {
+	*x = OutgoingAttachment{}
+	mi := &file_proto_server_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutgoingAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutgoingAttachment) ProtoMessage() {}
+
+func (x *OutgoingAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutgoingAttachment.ProtoReflect.Descriptor instead.
+func (*OutgoingAttachment) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OutgoingAttachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OutgoingAttachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *OutgoingAttachment) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *OutgoingAttachment) GetContentId() string {
+	if x != nil {
+		return x.ContentId
+	}
+	return ""
+}
+
+func (x *OutgoingAttachment) GetIsInline() bool {
+	if x != nil {
+		return x.IsInline
+	}
+	return false
+}
+
+// 待发送的邮件（对应 domain.OutgoingEmail）
+type OutgoingEmail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	To          []*EmailAddress       `protobuf:"bytes,1,rep,name=to,proto3" json:"to,omitempty"`
+	Cc          []*EmailAddress       `protobuf:"bytes,2,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc         []*EmailAddress       `protobuf:"bytes,3,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	ReplyTo     []*EmailAddress       `protobuf:"bytes,4,rep,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	Subject     string                `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Text        string                `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"` // 纯文本正文
+	Html        string                `protobuf:"bytes,7,opt,name=html,proto3" json:"html,omitempty"` // HTML 正文（Graph 只支持一种正文，同时指定时使用 HTML）
+	Attachments []*OutgoingAttachment `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	InReplyTo   string                `protobuf:"bytes,9,opt,name=in_reply_to,json=inReplyTo,proto3" json:"in_reply_to,omitempty"` // 回复的邮件 ID（IMAP 为 Message-ID，Graph 为邮件 ID）
+}
+
+func (x *OutgoingEmail) Reset() {
+	*x = OutgoingEmail{}
+	mi := &file_proto_server_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutgoingEmail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutgoingEmail) ProtoMessage() {}
+
+func (x *OutgoingEmail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutgoingEmail.ProtoReflect.Descriptor instead.
+func (*OutgoingEmail) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OutgoingEmail) GetTo() []*EmailAddress {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *OutgoingEmail) GetCc() []*EmailAddress {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *OutgoingEmail) GetBcc() []*EmailAddress {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *OutgoingEmail) GetReplyTo() []*EmailAddress {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return nil
+}
+
+func (x *OutgoingEmail) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *OutgoingEmail) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *OutgoingEmail) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *OutgoingEmail) GetAttachments() []*OutgoingAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *OutgoingEmail) GetInReplyTo() string {
+	if x != nil {
+		return x.InReplyTo
+	}
+	return ""
+}
+
+// 回复/转发邮件请求（对应 dto.ReplyMailRequest），响应与 SendMail 相同
+type ReplyMailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo    *MailInfo             `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	EmailId     string                `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Folder      string                `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"` // 原邮件所在文件夹，为空表示收件箱（仅 IMAP 需要）
+	Mode        ReplyMode             `protobuf:"varint,4,opt,name=mode,proto3,enum=ReplyMode" json:"mode,omitempty"`
+	To          []*EmailAddress       `protobuf:"bytes,5,rep,name=to,proto3" json:"to,omitempty"` // 回复时为空表示根据原邮件自动确定，转发时必填
+	Cc          []*EmailAddress       `protobuf:"bytes,6,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc         []*EmailAddress       `protobuf:"bytes,7,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	Text        string                `protobuf:"bytes,8,opt,name=text,proto3" json:"text,omitempty"`                // 纯文本回复内容
+	Html        string                `protobuf:"bytes,9,opt,name=html,proto3" json:"html,omitempty"`                // HTML 回复内容
+	Attachments []*OutgoingAttachment `protobuf:"bytes,10,rep,name=attachments,proto3" json:"attachments,omitempty"` // 附加的附件（转发时原邮件的附件会自动带上）
+}
+
+func (x *ReplyMailRequest) Reset() {
+	*x = ReplyMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplyMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyMailRequest) ProtoMessage() {}
+
+func (x *ReplyMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyMailRequest.ProtoReflect.Descriptor instead.
+func (*ReplyMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReplyMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+func (x *ReplyMailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *ReplyMailRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *ReplyMailRequest) GetMode() ReplyMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ReplyMode_REPLY
+}
+
+func (x *ReplyMailRequest) GetTo() []*EmailAddress {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ReplyMailRequest) GetCc() []*EmailAddress {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ReplyMailRequest) GetBcc() []*EmailAddress {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ReplyMailRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ReplyMailRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *ReplyMailRequest) GetAttachments() []*OutgoingAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// 发送邮件请求（对应 dto.SendMailRequest）
+type SendMailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo *MailInfo      `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	Message  *OutgoingEmail `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SendMailRequest) Reset() {
+	*x = SendMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMailRequest) ProtoMessage() {}
+
+func (x *SendMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMailRequest.ProtoReflect.Descriptor instead.
+func (*SendMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SendMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+func (x *SendMailRequest) GetMessage() *OutgoingEmail {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// 发送邮件响应
+type SendMailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId *string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3,oneof" json:"message_id,omitempty"` // 生成的 Message-ID（仅 SMTP 发送时返回）
+}
+
+func (x *SendMailResponse) Reset() {
+	*x = SendMailResponse{}
+	mi := &file_proto_server_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMailResponse) ProtoMessage() {}
+
+func (x *SendMailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMailResponse.ProtoReflect.Descriptor instead.
+func (*SendMailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SendMailResponse) GetMessageId() string {
+	if x != nil && x.MessageId != nil {
+		return *x.MessageId
+	}
+	return ""
+}
+
+// 获取垃圾邮件请求（对应 dto.GetNewJunkMailRequest）
+type GetNewJunkMailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+}
+
+func (x *GetNewJunkMailRequest) Reset() {
+	*x = GetNewJunkMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNewJunkMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNewJunkMailRequest) ProtoMessage() {}
+
+func (x *GetNewJunkMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNewJunkMailRequest.ProtoReflect.Descriptor instead.
+func (*GetNewJunkMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetNewJunkMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+// 获取垃圾邮件响应
+type GetNewJunkMailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email *Email `protobuf:"bytes,1,opt,name=email,proto3,oneof" json:"email,omitempty"` // 没有找到邮件时为空
+}
+
+func (x *GetNewJunkMailResponse) Reset() {
+	*x = GetNewJunkMailResponse{}
+	mi := &file_proto_server_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNewJunkMailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNewJunkMailResponse) ProtoMessage() {}
+
+func (x *GetNewJunkMailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNewJunkMailResponse.ProtoReflect.Descriptor instead.
+func (*GetNewJunkMailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetNewJunkMailResponse) GetEmail() *Email {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+// 邮件订阅请求（对应 dto.SubscribeMailRequest）
+type SubscribeMailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo      *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+	RefreshNeeded bool      `protobuf:"varint,2,opt,name=refresh_needed,json=refreshNeeded,proto3" json:"refresh_needed,omitempty"`
+}
+
+func (x *SubscribeMailRequest) Reset() {
+	*x = SubscribeMailRequest{}
+	mi := &file_proto_server_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMailRequest) ProtoMessage() {}
+
+func (x *SubscribeMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMailRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeMailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubscribeMailRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+func (x *SubscribeMailRequest) GetRefreshNeeded() bool {
+	if x != nil {
+		return x.RefreshNeeded
+	}
+	return false
+}
+
+// 邮件事件（SSE 流事件）- 简化版本
+type MailEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventType    string  `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`                // "connected", "email", "heartbeat", "error", "closed"
+	Email        *Email  `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`                                   // 仅当 event_type="email" 时使用
+	Message      *string `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`                               // 用于 connected/heartbeat/error/closed 消息
+	RefreshToken *string `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3,oneof" json:"refresh_token,omitempty"` // 仅当连接成功且需要刷新时返回
+}
+
+func (x *MailEvent) Reset() {
+	*x = MailEvent{}
+	mi := &file_proto_server_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailEvent) ProtoMessage() {}
+
+func (x *MailEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailEvent.ProtoReflect.Descriptor instead.
+func (*MailEvent) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MailEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *MailEvent) GetEmail() *Email {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *MailEvent) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *MailEvent) GetRefreshToken() string {
+	if x != nil && x.RefreshToken != nil {
+		return *x.RefreshToken
+	}
+	return ""
+}
+
+// 刷新 Token 请求（对应 dto.RefreshTokenRequest）
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"`
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_proto_server_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RefreshTokenRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+// 刷新 Token 响应
+type RefreshTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NewRefreshToken string `protobuf:"bytes,1,opt,name=new_refresh_token,json=newRefreshToken,proto3" json:"new_refresh_token,omitempty"`
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_proto_server_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RefreshTokenResponse) GetNewRefreshToken() string {
+	if x != nil {
+		return x.NewRefreshToken
+	}
+	return ""
+}
+
+// 批量刷新 Token 请求（对应 dto.BatchRefreshTokenRequest）
+type BatchRefreshTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfos []*MailInfo `protobuf:"bytes,1,rep,name=mail_infos,json=mailInfos,proto3" json:"mail_infos,omitempty"`
+}
+
+func (x *BatchRefreshTokenRequest) Reset() {
+	*x = BatchRefreshTokenRequest{}
+	mi := &file_proto_server_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRefreshTokenRequest) ProtoMessage() {}
+
+func (x *BatchRefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*BatchRefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BatchRefreshTokenRequest) GetMailInfos() []*MailInfo {
+	if x != nil {
+		return x.MailInfos
+	}
+	return nil
+}
+
+// 批量刷新结果项（对应 dto.BatchRefreshResult）
+type BatchRefreshResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email           string  `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	NewRefreshToken string  `protobuf:"bytes,2,opt,name=new_refresh_token,json=newRefreshToken,proto3" json:"new_refresh_token,omitempty"`
+	Error           *string `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"` // 只有失败时才设置
+}
+
+func (x *BatchRefreshResult) Reset() {
+	*x = BatchRefreshResult{}
+	mi := &file_proto_server_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRefreshResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRefreshResult) ProtoMessage() {}
+
+func (x *BatchRefreshResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRefreshResult.ProtoReflect.Descriptor instead.
+func (*BatchRefreshResult) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BatchRefreshResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BatchRefreshResult) GetNewRefreshToken() string {
+	if x != nil {
+		return x.NewRefreshToken
+	}
+	return ""
+}
+
+func (x *BatchRefreshResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// 批量刷新 Token 响应（对应 dto.BatchRefreshTokenData）
+type BatchRefreshTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SuccessCount int32                 `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	FailCount    int32                 `protobuf:"varint,2,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`
+	Results      []*BatchRefreshResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchRefreshTokenResponse) Reset() {
+	*x = BatchRefreshTokenResponse{}
+	mi := &file_proto_server_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRefreshTokenResponse) ProtoMessage() {}
+
+func (x *BatchRefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*BatchRefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BatchRefreshTokenResponse) GetSuccessCount() int32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+func (x *BatchRefreshTokenResponse) GetFailCount() int32 {
+	if x != nil {
+		return x.FailCount
+	}
+	return 0
+}
+
+func (x *BatchRefreshTokenResponse) GetResults() []*BatchRefreshResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// 协议检测请求（对应 dto.DetectProtocolTypeRequest）
+type DetectProtocolTypeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"` // 邮箱信息（不包含 protoType）
+}
+
+func (x *DetectProtocolTypeRequest) Reset() {
+	*x = DetectProtocolTypeRequest{}
+	mi := &file_proto_server_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectProtocolTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectProtocolTypeRequest) ProtoMessage() {}
+
+func (x *DetectProtocolTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectProtocolTypeRequest.ProtoReflect.Descriptor instead.
+func (*DetectProtocolTypeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DetectProtocolTypeRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+// 协议检测响应（对应 dto.DetectProtocolTypeResponse）
+type DetectProtocolTypeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProtoType ProtocolType `protobuf:"varint,1,opt,name=proto_type,json=protoType,proto3,enum=ProtocolType" json:"proto_type,omitempty"` // 检测到的协议类型
+}
+
+func (x *DetectProtocolTypeResponse) Reset() {
+	*x = DetectProtocolTypeResponse{}
+	mi := &file_proto_server_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectProtocolTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectProtocolTypeResponse) ProtoMessage() {}
+
+func (x *DetectProtocolTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectProtocolTypeResponse.ProtoReflect.Descriptor instead.
+func (*DetectProtocolTypeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DetectProtocolTypeResponse) GetProtoType() ProtocolType {
+	if x != nil {
+		return x.ProtoType
+	}
+	return ProtocolType_IMAP
+}
+
+// 批量检测协议类型请求（对应 dto.BatchDetectProtocolTypeRequest）
+type BatchDetectProtocolTypeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfos []*MailInfo `protobuf:"bytes,1,rep,name=mail_infos,json=mailInfos,proto3" json:"mail_infos,omitempty"` // 需要检测的邮箱信息列表
+}
+
+func (x *BatchDetectProtocolTypeRequest) Reset() {
+	*x = BatchDetectProtocolTypeRequest{}
+	mi := &file_proto_server_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDetectProtocolTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDetectProtocolTypeRequest) ProtoMessage() {}
+
+func (x *BatchDetectProtocolTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDetectProtocolTypeRequest.ProtoReflect.Descriptor instead.
+func (*BatchDetectProtocolTypeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BatchDetectProtocolTypeRequest) GetMailInfos() []*MailInfo {
+	if x != nil {
+		return x.MailInfos
+	}
+	return nil
+}
+
+// 批量检测协议类型结果项（对应 dto.BatchDetectProtocolTypeResult）
+type BatchDetectProtocolTypeResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email     string       `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                                             // 邮箱地址
+	ProtoType ProtocolType `protobuf:"varint,2,opt,name=proto_type,json=protoType,proto3,enum=ProtocolType" json:"proto_type,omitempty"` // 检测到的协议类型（成功时）
+	Error     *string      `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`                                       // 错误信息（失败时）
+}
+
+func (x *BatchDetectProtocolTypeResult) Reset() {
+	*x = BatchDetectProtocolTypeResult{}
+	mi := &file_proto_server_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDetectProtocolTypeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDetectProtocolTypeResult) ProtoMessage() {}
+
+func (x *BatchDetectProtocolTypeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDetectProtocolTypeResult.ProtoReflect.Descriptor instead.
+func (*BatchDetectProtocolTypeResult) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BatchDetectProtocolTypeResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BatchDetectProtocolTypeResult) GetProtoType() ProtocolType {
+	if x != nil {
+		return x.ProtoType
+	}
+	return ProtocolType_IMAP
+}
+
+func (x *BatchDetectProtocolTypeResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// 批量检测协议类型响应（对应 dto.BatchDetectProtocolTypeResponse）
+type BatchDetectProtocolTypeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SuccessCount int32                            `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"` // 成功检测的数量
+	FailCount    int32                            `protobuf:"varint,2,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`          // 失败检测的数量
+	Results      []*BatchDetectProtocolTypeResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`                                // 详细结果列表
+}
+
+func (x *BatchDetectProtocolTypeResponse) Reset() {
+	*x = BatchDetectProtocolTypeResponse{}
+	mi := &file_proto_server_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
 func (x *BatchDetectProtocolTypeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchDetectProtocolTypeResponse) ProtoMessage() {}
+func (*BatchDetectProtocolTypeResponse) ProtoMessage() {}
+
+func (x *BatchDetectProtocolTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDetectProtocolTypeResponse.ProtoReflect.Descriptor instead.
+func (*BatchDetectProtocolTypeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BatchDetectProtocolTypeResponse) GetSuccessCount() int32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+func (x *BatchDetectProtocolTypeResponse) GetFailCount() int32 {
+	if x != nil {
+		return x.FailCount
+	}
+	return 0
+}
+
+func (x *BatchDetectProtocolTypeResponse) GetResults() []*BatchDetectProtocolTypeResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// 设备码授权请求（对应 dto.DeviceAuthorizationRequest，仅支持微软账户）
+type DeviceAuthorizationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceProvider ServiceProvider `protobuf:"varint,1,opt,name=service_provider,json=serviceProvider,proto3,enum=ServiceProvider" json:"service_provider,omitempty"`
+	ClientId        string          `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // 需要在应用中允许公共客户端流
+	Tenant          string          `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`                     // 微软租户，为空时使用 consumers
+	Scope           string          `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`                       // 申请的权限（空格分隔），为空时使用默认权限
+}
+
+func (x *DeviceAuthorizationRequest) Reset() {
+	*x = DeviceAuthorizationRequest{}
+	mi := &file_proto_server_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorizationRequest) ProtoMessage() {}
+
+func (x *DeviceAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeviceAuthorizationRequest) GetServiceProvider() ServiceProvider {
+	if x != nil {
+		return x.ServiceProvider
+	}
+	return ServiceProvider_MICROSOFT
+}
+
+func (x *DeviceAuthorizationRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+// 设备码授权事件
+type DeviceAuthorizationEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventType       string    `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`                         // "device_code", "heartbeat", "complete"
+	UserCode        *string   `protobuf:"bytes,2,opt,name=user_code,json=userCode,proto3,oneof" json:"user_code,omitempty"`                      // 仅当 event_type="device_code" 时使用
+	VerificationUri *string   `protobuf:"bytes,3,opt,name=verification_uri,json=verificationUri,proto3,oneof" json:"verification_uri,omitempty"` // 仅当 event_type="device_code" 时使用
+	ExpiresIn       *int64    `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3,oneof" json:"expires_in,omitempty"`                  // 设备码有效期（秒），仅当 event_type="device_code" 时使用
+	Message         *string   `protobuf:"bytes,5,opt,name=message,proto3,oneof" json:"message,omitempty"`                                        // 提示用户的说明文字
+	MailInfo        *MailInfo `protobuf:"bytes,6,opt,name=mail_info,json=mailInfo,proto3,oneof" json:"mail_info,omitempty"`                      // 仅当 event_type="complete" 时使用
+}
+
+func (x *DeviceAuthorizationEvent) Reset() {
+	*x = DeviceAuthorizationEvent{}
+	mi := &file_proto_server_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceAuthorizationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorizationEvent) ProtoMessage() {}
+
+func (x *DeviceAuthorizationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorizationEvent.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorizationEvent) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeviceAuthorizationEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationEvent) GetUserCode() string {
+	if x != nil && x.UserCode != nil {
+		return *x.UserCode
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationEvent) GetVerificationUri() string {
+	if x != nil && x.VerificationUri != nil {
+		return *x.VerificationUri
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationEvent) GetExpiresIn() int64 {
+	if x != nil && x.ExpiresIn != nil {
+		return *x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *DeviceAuthorizationEvent) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationEvent) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+// 保存账户请求（对应 dto.RegisterAccountRequest）
+type RegisterAccountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailInfo *MailInfo `protobuf:"bytes,1,opt,name=mail_info,json=mailInfo,proto3" json:"mail_info,omitempty"` // 邮箱信息（包含 refresh_token 或密码）
+}
+
+func (x *RegisterAccountRequest) Reset() {
+	*x = RegisterAccountRequest{}
+	mi := &file_proto_server_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAccountRequest) ProtoMessage() {}
+
+func (x *RegisterAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAccountRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RegisterAccountRequest) GetMailInfo() *MailInfo {
+	if x != nil {
+		return x.MailInfo
+	}
+	return nil
+}
+
+// 已保存账户请求
+type AccountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountId     string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountSecret string `protobuf:"bytes,2,opt,name=account_secret,json=accountSecret,proto3" json:"account_secret,omitempty"` // 账户的访问密钥
+}
+
+func (x *AccountRequest) Reset() {
+	*x = AccountRequest{}
+	mi := &file_proto_server_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountRequest) ProtoMessage() {}
 
-func (x *BatchDetectProtocolTypeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_server_proto_msgTypes[20]
+func (x *AccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1262,37 +3496,318 @@ func (x *BatchDetectProtocolTypeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchDetectProtocolTypeResponse.ProtoReflect.Descriptor instead.
-func (*BatchDetectProtocolTypeResponse) Descriptor() ([]byte, []int) {
-	return file_proto_server_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use AccountRequest.ProtoReflect.Descriptor instead.
+func (*AccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *BatchDetectProtocolTypeResponse) GetSuccessCount() int32 {
+func (x *AccountRequest) GetAccountId() string {
 	if x != nil {
-		return x.SuccessCount
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountRequest) GetAccountSecret() string {
+	if x != nil {
+		return x.AccountSecret
+	}
+	return ""
+}
+
+// 已保存账户的基本信息（不包含凭据，访问密钥只在保存账户时返回）
+type Account struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email           string          `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	ServiceProvider ServiceProvider `protobuf:"varint,3,opt,name=service_provider,json=serviceProvider,proto3,enum=ServiceProvider" json:"service_provider,omitempty"`
+	ClientId        string          `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ProtoType       ProtocolType    `protobuf:"varint,5,opt,name=proto_type,json=protoType,proto3,enum=ProtocolType" json:"proto_type,omitempty"`
+	Fingerprint     string          `protobuf:"bytes,6,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`               // 当前 refreshToken 的指纹
+	Rotations       int32           `protobuf:"varint,7,opt,name=rotations,proto3" json:"rotations,omitempty"`                  // refreshToken 的变更次数（包括注册）
+	CreatedAt       int64           `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix 时间戳（秒）
+	UpdatedAt       int64           `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix 时间戳（秒）
+	Secret          string          `protobuf:"bytes,10,opt,name=secret,proto3" json:"secret,omitempty"`                        // 账户的访问密钥，只在保存账户时返回，之后使用 account_id 时必须同时提供
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_proto_server_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Account) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Account) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Account) GetServiceProvider() ServiceProvider {
+	if x != nil {
+		return x.ServiceProvider
+	}
+	return ServiceProvider_MICROSOFT
+}
+
+func (x *Account) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Account) GetProtoType() ProtocolType {
+	if x != nil {
+		return x.ProtoType
+	}
+	return ProtocolType_IMAP
+}
+
+func (x *Account) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *Account) GetRotations() int32 {
+	if x != nil {
+		return x.Rotations
 	}
 	return 0
 }
 
-func (x *BatchDetectProtocolTypeResponse) GetFailCount() int32 {
+func (x *Account) GetCreatedAt() int64 {
 	if x != nil {
-		return x.FailCount
+		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *BatchDetectProtocolTypeResponse) GetResults() []*BatchDetectProtocolTypeResult {
+func (x *Account) GetUpdatedAt() int64 {
 	if x != nil {
-		return x.Results
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Account) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// refreshToken 的一次变更记录
+type TokenRotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`                                                         // Unix 时间戳（秒）
+	Source              string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                                                      // "register", "refresh", "both"
+	Fingerprint         string `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`                                            // 新 refreshToken 的指纹
+	PreviousFingerprint string `protobuf:"bytes,4,opt,name=previous_fingerprint,json=previousFingerprint,proto3" json:"previous_fingerprint,omitempty"` // 旧 refreshToken 的指纹
+}
+
+func (x *TokenRotation) Reset() {
+	*x = TokenRotation{}
+	mi := &file_proto_server_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenRotation) ProtoMessage() {}
+
+func (x *TokenRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenRotation.ProtoReflect.Descriptor instead.
+func (*TokenRotation) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TokenRotation) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *TokenRotation) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TokenRotation) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *TokenRotation) GetPreviousFingerprint() string {
+	if x != nil {
+		return x.PreviousFingerprint
+	}
+	return ""
+}
+
+// 账户变更记录响应（对应 dto.AccountHistoryResponse）
+type AccountHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account *Account         `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	History []*TokenRotation `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"` // 按时间倒序
+}
+
+func (x *AccountHistoryResponse) Reset() {
+	*x = AccountHistoryResponse{}
+	mi := &file_proto_server_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountHistoryResponse) ProtoMessage() {}
+
+func (x *AccountHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountHistoryResponse.ProtoReflect.Descriptor instead.
+func (*AccountHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AccountHistoryResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
 	}
 	return nil
 }
 
+func (x *AccountHistoryResponse) GetHistory() []*TokenRotation {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// 删除账户响应
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_proto_server_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_server_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_server_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 var File_proto_server_proto protoreflect.FileDescriptor
 
 var file_proto_server_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcd, 0x01, 0x0a, 0x08, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbf, 0x05, 0x0a, 0x08, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66,
 	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e,
 	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65,
@@ -1305,176 +3820,573 @@ var file_proto_server_proto_rawDesc = []byte{
 	0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69,
 	0x64, 0x65, 0x72, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x22, 0x3c, 0x0a, 0x0c, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
-	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x1d, 0x0a, 0x02, 0x74, 0x6f, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x74, 0x6d, 0x6c, 0x22, 0x62, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4d, 0x61,
-	0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69,
+	0x69, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x12, 0x35, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x65, 0x63, 0x68, 0x61, 0x6e,
+	0x69, 0x73, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x4d,
+	0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x23, 0x0a, 0x04, 0x69, 0x6d, 0x61, 0x70,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x04, 0x69, 0x6d, 0x61, 0x70, 0x12, 0x23, 0x0a,
+	0x04, 0x73, 0x6d, 0x74, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x04, 0x73, 0x6d,
+	0x74, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x04, 0x70, 0x6f, 0x70, 0x33, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x04, 0x70, 0x6f, 0x70, 0x33, 0x12, 0x19, 0x0a, 0x08,
+	0x6a, 0x6d, 0x61, 0x70, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6a, 0x6d, 0x61, 0x70, 0x55, 0x72, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x77, 0x73, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x77, 0x73, 0x55, 0x72, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x63, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x29, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x22, 0x3c, 0x0a, 0x0c, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x9b, 0x04, 0x0a, 0x05, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x21, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x1d, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x02, 0x74, 0x6f, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x12, 0x2d, 0x0a, 0x0b, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x32, 0x0a, 0x0d, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0c, 0x74, 0x6f,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x0d, 0x63, 0x63,
+	0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x0c, 0x63, 0x63, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x34,
+	0x0a, 0x0e, 0x62, 0x63, 0x63, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0d, 0x62, 0x63, 0x63, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0xa3, 0x01, 0x0a, 0x0a, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x7a, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4e, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x7d, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x4e, 0x65, 0x77, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06,
+	0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88,
+	0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06,
+	0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6c, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x64,
+	0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d,
+	0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x3f, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61,
+	0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a,
+	0x06, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x7f, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61,
+	0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x68, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x06,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0b,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x88,
+	0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x22, 0xc9, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72, 0x69,
+	0x74, 0x65, 0x72, 0x69, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x75, 0x6e, 0x72, 0x65,
+	0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x96,
+	0x01, 0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2b, 0x0a, 0x08,
+	0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x52,
+	0x08, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xb5, 0x01, 0x0a, 0x06, 0x46, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x3c, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x38, 0x0a,
+	0x13, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x07, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x07,
+	0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x22, 0x6e, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09,
+	0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x22, 0x0a, 0x0c, 0x52, 0x61, 0x77, 0x4d, 0x61,
+	0x69, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x9b, 0x01, 0x0a, 0x19,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69,
 	0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d,
 	0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x6e, 0x65, 0x65,
-	0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65,
-	0x73, 0x68, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x7d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4e,
-	0x65, 0x77, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21,
-	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e,
-	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88, 0x01,
-	0x01, 0x12, 0x28, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72,
-	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f,
-	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73,
-	0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x54, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x64, 0x4d,
+	0x6f, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d,
+	0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x66, 0x0a, 0x0f, 0x41, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x30, 0x0a, 0x0a,
+	0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x22, 0xc9, 0x01, 0x0a, 0x0f, 0x4d, 0x61, 0x72, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a,
+	0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x06, 0x69, 0x73, 0x52, 0x65, 0x61, 0x64, 0x88, 0x01, 0x01, 0x12, 0x22,
+	0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x01, 0x52, 0x09, 0x69, 0x73, 0x46, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x88,
+	0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x42, 0x0d,
+	0x0a, 0x0b, 0x5f, 0x69, 0x73, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x22, 0x12, 0x0a,
+	0x10, 0x4d, 0x61, 0x72, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x91, 0x01, 0x0a, 0x0f, 0x4d, 0x6f, 0x76, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a,
+	0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x66, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x2d, 0x0a, 0x10, 0x4d, 0x6f, 0x76, 0x65, 0x4d, 0x61, 0x69,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x49, 0x64, 0x22, 0x8c, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d,
 	0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61,
 	0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
 	0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e,
 	0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x22, 0x3f, 0x0a,
-	0x10, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x06, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69,
-	0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x3f,
-	0x0a, 0x15, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f,
-	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69,
-	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22,
-	0x45, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c,
-	0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06,
-	0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x65, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
-	0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61,
-	0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73,
-	0x68, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
-	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0xbe, 0x01,
-	0x0a, 0x09, 0x4d, 0x61, 0x69, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x6d,
-	0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x6d, 0x61, 0x69,
-	0x6c, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d,
-	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c,
-	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x10, 0x0a, 0x0e,
-	0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3d,
-	0x0a, 0x13, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e,
-	0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x42, 0x0a,
-	0x14, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x66,
-	0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0f, 0x6e, 0x65, 0x77, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x22, 0x44, 0x0a, 0x18, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
-	0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a,
-	0x0a, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6d, 0x61,
-	0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0x7b, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
-	0x61, 0x69, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65,
-	0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
-	0x6e, 0x65, 0x77, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x19, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x22, 0x8e, 0x01, 0x0a, 0x19, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x61, 0x69,
-	0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
-	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x43, 0x0a, 0x19, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x65,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x65, 0x72, 0x6d, 0x61, 0x6e,
+	0x65, 0x6e, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x69,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x12, 0x4f, 0x75,
+	0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xb1, 0x02,
+	0x0a, 0x0d, 0x4f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12,
+	0x1d, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1d,
+	0x0a, 0x02, 0x63, 0x63, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x02, 0x63, 0x63, 0x12, 0x1f, 0x0a,
+	0x03, 0x62, 0x63, 0x63, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x03, 0x62, 0x63, 0x63, 0x12, 0x28,
+	0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x07, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x12, 0x35, 0x0a, 0x0b, 0x61, 0x74,
+	0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x4f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54,
+	0x6f, 0x22, 0xcb, 0x02, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69,
+	0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x12, 0x1e, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0a, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x12, 0x1d, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x02, 0x74, 0x6f,
+	0x12, 0x1d, 0x0a, 0x02, 0x63, 0x63, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x02, 0x63, 0x63, 0x12,
+	0x1f, 0x0a, 0x03, 0x62, 0x63, 0x63, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x03, 0x62, 0x63, 0x63,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x12, 0x35, 0x0a, 0x0b, 0x61, 0x74, 0x74, 0x61,
+	0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x4f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x0b, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x63, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x4a, 0x0a, 0x1a, 0x44, 0x65,
-	0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x22, 0x4a, 0x0a, 0x1e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44,
-	0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6c,
-	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d,
-	0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66,
-	0x6f, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x1d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65,
-	0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x2c, 0x0a, 0x0a, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d,
-	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9f, 0x01,
-	0x0a, 0x1f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x61, 0x69, 0x6c,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65,
-	0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x2a,
-	0x2c, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x49, 0x43, 0x52, 0x4f, 0x53, 0x4f, 0x46, 0x54, 0x10,
-	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4f, 0x4f, 0x47, 0x4c, 0x45, 0x10, 0x01, 0x2a, 0x23, 0x0a,
-	0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a,
-	0x04, 0x49, 0x4d, 0x41, 0x50, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x41, 0x50, 0x48,
-	0x10, 0x01, 0x32, 0xa4, 0x04, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x38, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4d,
-	0x61, 0x69, 0x6c, 0x12, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4d, 0x61, 0x69, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77,
-	0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08,
-	0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d,
-	0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x46, 0x69, 0x6e,
-	0x64, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x0b, 0x47, 0x65, 0x74, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x2e, 0x47,
+	0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x4f, 0x75,
+	0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x45, 0x0a, 0x10, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x22, 0x3f, 0x0a, 0x15, 0x47,
 	0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75, 0x6e,
-	0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a,
-	0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x15,
-	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x14, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x52, 0x65, 0x66, 0x72,
-	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x4a, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66,
-	0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x12,
-	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x1a, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
-	0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x17, 0x42,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x45, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x22, 0x65, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d,
+	0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x6e,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0xbe, 0x01, 0x0a, 0x09, 0x4d,
+	0x61, 0x69, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x02, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x72, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3d, 0x0a, 0x13, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x42, 0x0a, 0x14, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6e,
+	0x65, 0x77, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x44,
+	0x0a, 0x18, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0a, 0x6d, 0x61,
+	0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x73, 0x22, 0x7b, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x77,
+	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x8e, 0x01, 0x0a, 0x19, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0x43, 0x0a, 0x19, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d,
+	0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x4a, 0x0a, 0x1a, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x54,
+	0x79, 0x70, 0x65, 0x22, 0x4a, 0x0a, 0x1e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22,
+	0x88, 0x01, 0x0a, 0x1d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x2c, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01,
+	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9f, 0x01, 0x0a, 0x1f, 0x42,
 	0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65,
-	0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44,
+	0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xa4, 0x01, 0x0a,
+	0x1a, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x10, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x22, 0xc7, 0x02, 0x0a, 0x18, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x20, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x2e, 0x0a, 0x10, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0f, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x69, 0x88, 0x01,
+	0x01, 0x12, 0x22, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x49, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66,
+	0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x48, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x88, 0x01,
+	0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x75, 0x72, 0x69, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x69, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42,
+	0x0c, 0x0a, 0x0a, 0x5f, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x40, 0x0a,
+	0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6c, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x69,
+	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22,
+	0x56, 0x0a, 0x0e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0xcd, 0x02, 0x0a, 0x07, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x3b, 0x0a, 0x10, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x90, 0x01, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x72, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x14, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46,
+	0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x22, 0x66, 0x0a, 0x16, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x22, 0x31, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x2a, 0x4d, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x49, 0x43, 0x52,
+	0x4f, 0x53, 0x4f, 0x46, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4f, 0x4f, 0x47, 0x4c,
+	0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x49, 0x43, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x59, 0x41, 0x48, 0x4f, 0x4f, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x41,
+	0x4f, 0x4c, 0x10, 0x04, 0x2a, 0x4f, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4d, 0x41, 0x50, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x47, 0x52, 0x41, 0x50, 0x48, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x47, 0x4d, 0x41,
+	0x49, 0x4c, 0x5f, 0x41, 0x50, 0x49, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x50, 0x33,
+	0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x4d, 0x41, 0x50, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03,
+	0x45, 0x57, 0x53, 0x10, 0x05, 0x2a, 0x25, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
+	0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4c, 0x53, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x53, 0x54, 0x41, 0x52, 0x54, 0x54, 0x4c, 0x53, 0x10, 0x01, 0x2a, 0x3b, 0x0a, 0x0d,
+	0x41, 0x75, 0x74, 0x68, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x09, 0x0a,
+	0x05, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4c, 0x41, 0x49,
+	0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x45, 0x41, 0x52, 0x45, 0x52, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x4e, 0x54, 0x4c, 0x4d, 0x10, 0x03, 0x2a, 0x32, 0x0a, 0x09, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x50, 0x4c, 0x59, 0x10,
+	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x50, 0x4c, 0x59, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x01,
+	0x12, 0x0b, 0x0a, 0x07, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x10, 0x02, 0x32, 0xba, 0x0a,
+	0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x12,
+	0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4d, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x64, 0x4d,
+	0x61, 0x69, 0x6c, 0x12, 0x10, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x69,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x12, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a,
+	0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x41, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x31,
+	0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x52, 0x61, 0x77, 0x4d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30,
+	0x01, 0x12, 0x2f, 0x0a, 0x08, 0x4d, 0x61, 0x72, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x2e,
+	0x4d, 0x61, 0x72, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x11, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x4d, 0x6f, 0x76, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x10,
+	0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x69,
+	0x6c, 0x12, 0x12, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61,
+	0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x53, 0x65,
+	0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x69,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d,
+	0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x11, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x53, 0x65,
+	0x6e, 0x64, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x2e,
+	0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75, 0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x77, 0x4a, 0x75,
+	0x6e, 0x6b, 0x4d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34,
+	0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x12,
+	0x15, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x52, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4a, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a,
+	0x12, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1a, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x17,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44,
 	0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x15, 0x5a, 0x13, 0x67, 0x6f, 0x6d,
-	0x61, 0x69, 0x6c, 0x61, 0x70, 0x69, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x13, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1b, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x0f, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x3d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0f, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x38, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x0f, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x15, 0x5a, 0x13, 0x67, 0x6f,
+	0x6d, 0x61, 0x69, 0x6c, 0x61, 0x70, 0x69, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1489,75 +4401,181 @@ func file_proto_server_proto_rawDescGZIP() []byte {
 	return file_proto_server_proto_rawDescData
 }
 
-var file_proto_server_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_server_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_proto_server_proto_goTypes = []any{
 	(ServiceProvider)(0),                    // 0: ServiceProvider
 	(ProtocolType)(0),                       // 1: ProtocolType
-	(*MailInfo)(nil),                        // 2: MailInfo
-	(*EmailAddress)(nil),                    // 3: EmailAddress
-	(*Email)(nil),                           // 4: Email
-	(*GetNewMailRequest)(nil),               // 5: GetNewMailRequest
-	(*GetNewMailResponse)(nil),              // 6: GetNewMailResponse
-	(*FindMailRequest)(nil),                 // 7: FindMailRequest
-	(*FindMailResponse)(nil),                // 8: FindMailResponse
-	(*GetNewJunkMailRequest)(nil),           // 9: GetNewJunkMailRequest
-	(*GetNewJunkMailResponse)(nil),          // 10: GetNewJunkMailResponse
-	(*SubscribeMailRequest)(nil),            // 11: SubscribeMailRequest
-	(*MailEvent)(nil),                       // 12: MailEvent
-	(*RefreshTokenRequest)(nil),             // 13: RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),            // 14: RefreshTokenResponse
-	(*BatchRefreshTokenRequest)(nil),        // 15: BatchRefreshTokenRequest
-	(*BatchRefreshResult)(nil),              // 16: BatchRefreshResult
-	(*BatchRefreshTokenResponse)(nil),       // 17: BatchRefreshTokenResponse
-	(*DetectProtocolTypeRequest)(nil),       // 18: DetectProtocolTypeRequest
-	(*DetectProtocolTypeResponse)(nil),      // 19: DetectProtocolTypeResponse
-	(*BatchDetectProtocolTypeRequest)(nil),  // 20: BatchDetectProtocolTypeRequest
-	(*BatchDetectProtocolTypeResult)(nil),   // 21: BatchDetectProtocolTypeResult
-	(*BatchDetectProtocolTypeResponse)(nil), // 22: BatchDetectProtocolTypeResponse
+	(SecurityMode)(0),                       // 2: SecurityMode
+	(AuthMechanism)(0),                      // 3: AuthMechanism
+	(ReplyMode)(0),                          // 4: ReplyMode
+	(*MailInfo)(nil),                        // 5: MailInfo
+	(*ServerSettings)(nil),                  // 6: ServerSettings
+	(*EmailAddress)(nil),                    // 7: EmailAddress
+	(*Email)(nil),                           // 8: Email
+	(*Attachment)(nil),                      // 9: Attachment
+	(*GetNewMailRequest)(nil),               // 10: GetNewMailRequest
+	(*GetNewMailResponse)(nil),              // 11: GetNewMailResponse
+	(*FindMailRequest)(nil),                 // 12: FindMailRequest
+	(*FindMailResponse)(nil),                // 13: FindMailResponse
+	(*ListMailRequest)(nil),                 // 14: ListMailRequest
+	(*ListMailResponse)(nil),                // 15: ListMailResponse
+	(*SearchCriteria)(nil),                  // 16: SearchCriteria
+	(*SearchMailRequest)(nil),               // 17: SearchMailRequest
+	(*Folder)(nil),                          // 18: Folder
+	(*ListFoldersRequest)(nil),              // 19: ListFoldersRequest
+	(*ListFoldersResponse)(nil),             // 20: ListFoldersResponse
+	(*ExportMailRequest)(nil),               // 21: ExportMailRequest
+	(*RawMailChunk)(nil),                    // 22: RawMailChunk
+	(*DownloadAttachmentRequest)(nil),       // 23: DownloadAttachmentRequest
+	(*AttachmentChunk)(nil),                 // 24: AttachmentChunk
+	(*MarkMailRequest)(nil),                 // 25: MarkMailRequest
+	(*MarkMailResponse)(nil),                // 26: MarkMailResponse
+	(*MoveMailRequest)(nil),                 // 27: MoveMailRequest
+	(*MoveMailResponse)(nil),                // 28: MoveMailResponse
+	(*DeleteMailRequest)(nil),               // 29: DeleteMailRequest
+	(*DeleteMailResponse)(nil),              // 30: DeleteMailResponse
+	(*OutgoingAttachment)(nil),              // 31: OutgoingAttachment
+	(*OutgoingEmail)(nil),                   // 32: OutgoingEmail
+	(*ReplyMailRequest)(nil),                // 33: ReplyMailRequest
+	(*SendMailRequest)(nil),                 // 34: SendMailRequest
+	(*SendMailResponse)(nil),                // 35: SendMailResponse
+	(*GetNewJunkMailRequest)(nil),           // 36: GetNewJunkMailRequest
+	(*GetNewJunkMailResponse)(nil),          // 37: GetNewJunkMailResponse
+	(*SubscribeMailRequest)(nil),            // 38: SubscribeMailRequest
+	(*MailEvent)(nil),                       // 39: MailEvent
+	(*RefreshTokenRequest)(nil),             // 40: RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),            // 41: RefreshTokenResponse
+	(*BatchRefreshTokenRequest)(nil),        // 42: BatchRefreshTokenRequest
+	(*BatchRefreshResult)(nil),              // 43: BatchRefreshResult
+	(*BatchRefreshTokenResponse)(nil),       // 44: BatchRefreshTokenResponse
+	(*DetectProtocolTypeRequest)(nil),       // 45: DetectProtocolTypeRequest
+	(*DetectProtocolTypeResponse)(nil),      // 46: DetectProtocolTypeResponse
+	(*BatchDetectProtocolTypeRequest)(nil),  // 47: BatchDetectProtocolTypeRequest
+	(*BatchDetectProtocolTypeResult)(nil),   // 48: BatchDetectProtocolTypeResult
+	(*BatchDetectProtocolTypeResponse)(nil), // 49: BatchDetectProtocolTypeResponse
+	(*DeviceAuthorizationRequest)(nil),      // 50: DeviceAuthorizationRequest
+	(*DeviceAuthorizationEvent)(nil),        // 51: DeviceAuthorizationEvent
+	(*RegisterAccountRequest)(nil),          // 52: RegisterAccountRequest
+	(*AccountRequest)(nil),                  // 53: AccountRequest
+	(*Account)(nil),                         // 54: Account
+	(*TokenRotation)(nil),                   // 55: TokenRotation
+	(*AccountHistoryResponse)(nil),          // 56: AccountHistoryResponse
+	(*DeleteAccountResponse)(nil),           // 57: DeleteAccountResponse
 }
 var file_proto_server_proto_depIdxs = []int32{
 	1,  // 0: MailInfo.proto_type:type_name -> ProtocolType
 	0,  // 1: MailInfo.service_provider:type_name -> ServiceProvider
-	3,  // 2: Email.from:type_name -> EmailAddress
-	3,  // 3: Email.to:type_name -> EmailAddress
-	2,  // 4: GetNewMailRequest.mail_info:type_name -> MailInfo
-	4,  // 5: GetNewMailResponse.email:type_name -> Email
-	2,  // 6: FindMailRequest.mail_info:type_name -> MailInfo
-	4,  // 7: FindMailResponse.email:type_name -> Email
-	2,  // 8: GetNewJunkMailRequest.mail_info:type_name -> MailInfo
-	4,  // 9: GetNewJunkMailResponse.email:type_name -> Email
-	2,  // 10: SubscribeMailRequest.mail_info:type_name -> MailInfo
-	4,  // 11: MailEvent.email:type_name -> Email
-	2,  // 12: RefreshTokenRequest.mail_info:type_name -> MailInfo
-	2,  // 13: BatchRefreshTokenRequest.mail_infos:type_name -> MailInfo
-	16, // 14: BatchRefreshTokenResponse.results:type_name -> BatchRefreshResult
-	2,  // 15: DetectProtocolTypeRequest.mail_info:type_name -> MailInfo
-	1,  // 16: DetectProtocolTypeResponse.proto_type:type_name -> ProtocolType
-	2,  // 17: BatchDetectProtocolTypeRequest.mail_infos:type_name -> MailInfo
-	1,  // 18: BatchDetectProtocolTypeResult.proto_type:type_name -> ProtocolType
-	21, // 19: BatchDetectProtocolTypeResponse.results:type_name -> BatchDetectProtocolTypeResult
-	5,  // 20: MailService.GetLatestMail:input_type -> GetNewMailRequest
-	7,  // 21: MailService.FindMail:input_type -> FindMailRequest
-	9,  // 22: MailService.GetJunkMail:input_type -> GetNewJunkMailRequest
-	11, // 23: MailService.SubscribeMail:input_type -> SubscribeMailRequest
-	13, // 24: MailService.RefreshToken:input_type -> RefreshTokenRequest
-	15, // 25: MailService.BatchRefreshToken:input_type -> BatchRefreshTokenRequest
-	18, // 26: MailService.DetectProtocolType:input_type -> DetectProtocolTypeRequest
-	20, // 27: MailService.BatchDetectProtocolType:input_type -> BatchDetectProtocolTypeRequest
-	6,  // 28: MailService.GetLatestMail:output_type -> GetNewMailResponse
-	8,  // 29: MailService.FindMail:output_type -> FindMailResponse
-	10, // 30: MailService.GetJunkMail:output_type -> GetNewJunkMailResponse
-	12, // 31: MailService.SubscribeMail:output_type -> MailEvent
-	14, // 32: MailService.RefreshToken:output_type -> RefreshTokenResponse
-	17, // 33: MailService.BatchRefreshToken:output_type -> BatchRefreshTokenResponse
-	19, // 34: MailService.DetectProtocolType:output_type -> DetectProtocolTypeResponse
-	22, // 35: MailService.BatchDetectProtocolType:output_type -> BatchDetectProtocolTypeResponse
-	28, // [28:36] is the sub-list for method output_type
-	20, // [20:28] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	3,  // 2: MailInfo.auth_mechanism:type_name -> AuthMechanism
+	6,  // 3: MailInfo.imap:type_name -> ServerSettings
+	6,  // 4: MailInfo.smtp:type_name -> ServerSettings
+	6,  // 5: MailInfo.pop3:type_name -> ServerSettings
+	2,  // 6: ServerSettings.security:type_name -> SecurityMode
+	7,  // 7: Email.from:type_name -> EmailAddress
+	7,  // 8: Email.to:type_name -> EmailAddress
+	9,  // 9: Email.attachments:type_name -> Attachment
+	7,  // 10: Email.sender:type_name -> EmailAddress
+	7,  // 11: Email.to_recipients:type_name -> EmailAddress
+	7,  // 12: Email.cc_recipients:type_name -> EmailAddress
+	7,  // 13: Email.bcc_recipients:type_name -> EmailAddress
+	7,  // 14: Email.reply_to:type_name -> EmailAddress
+	5,  // 15: GetNewMailRequest.mail_info:type_name -> MailInfo
+	8,  // 16: GetNewMailResponse.email:type_name -> Email
+	5,  // 17: FindMailRequest.mail_info:type_name -> MailInfo
+	8,  // 18: FindMailResponse.email:type_name -> Email
+	5,  // 19: ListMailRequest.mail_info:type_name -> MailInfo
+	8,  // 20: ListMailResponse.emails:type_name -> Email
+	5,  // 21: SearchMailRequest.mail_info:type_name -> MailInfo
+	16, // 22: SearchMailRequest.criteria:type_name -> SearchCriteria
+	5,  // 23: ListFoldersRequest.mail_info:type_name -> MailInfo
+	18, // 24: ListFoldersResponse.folders:type_name -> Folder
+	5,  // 25: ExportMailRequest.mail_info:type_name -> MailInfo
+	5,  // 26: DownloadAttachmentRequest.mail_info:type_name -> MailInfo
+	9,  // 27: AttachmentChunk.attachment:type_name -> Attachment
+	5,  // 28: MarkMailRequest.mail_info:type_name -> MailInfo
+	5,  // 29: MoveMailRequest.mail_info:type_name -> MailInfo
+	5,  // 30: DeleteMailRequest.mail_info:type_name -> MailInfo
+	7,  // 31: OutgoingEmail.to:type_name -> EmailAddress
+	7,  // 32: OutgoingEmail.cc:type_name -> EmailAddress
+	7,  // 33: OutgoingEmail.bcc:type_name -> EmailAddress
+	7,  // 34: OutgoingEmail.reply_to:type_name -> EmailAddress
+	31, // 35: OutgoingEmail.attachments:type_name -> OutgoingAttachment
+	5,  // 36: ReplyMailRequest.mail_info:type_name -> MailInfo
+	4,  // 37: ReplyMailRequest.mode:type_name -> ReplyMode
+	7,  // 38: ReplyMailRequest.to:type_name -> EmailAddress
+	7,  // 39: ReplyMailRequest.cc:type_name -> EmailAddress
+	7,  // 40: ReplyMailRequest.bcc:type_name -> EmailAddress
+	31, // 41: ReplyMailRequest.attachments:type_name -> OutgoingAttachment
+	5,  // 42: SendMailRequest.mail_info:type_name -> MailInfo
+	32, // 43: SendMailRequest.message:type_name -> OutgoingEmail
+	5,  // 44: GetNewJunkMailRequest.mail_info:type_name -> MailInfo
+	8,  // 45: GetNewJunkMailResponse.email:type_name -> Email
+	5,  // 46: SubscribeMailRequest.mail_info:type_name -> MailInfo
+	8,  // 47: MailEvent.email:type_name -> Email
+	5,  // 48: RefreshTokenRequest.mail_info:type_name -> MailInfo
+	5,  // 49: BatchRefreshTokenRequest.mail_infos:type_name -> MailInfo
+	43, // 50: BatchRefreshTokenResponse.results:type_name -> BatchRefreshResult
+	5,  // 51: DetectProtocolTypeRequest.mail_info:type_name -> MailInfo
+	1,  // 52: DetectProtocolTypeResponse.proto_type:type_name -> ProtocolType
+	5,  // 53: BatchDetectProtocolTypeRequest.mail_infos:type_name -> MailInfo
+	1,  // 54: BatchDetectProtocolTypeResult.proto_type:type_name -> ProtocolType
+	48, // 55: BatchDetectProtocolTypeResponse.results:type_name -> BatchDetectProtocolTypeResult
+	0,  // 56: DeviceAuthorizationRequest.service_provider:type_name -> ServiceProvider
+	5,  // 57: DeviceAuthorizationEvent.mail_info:type_name -> MailInfo
+	5,  // 58: RegisterAccountRequest.mail_info:type_name -> MailInfo
+	0,  // 59: Account.service_provider:type_name -> ServiceProvider
+	1,  // 60: Account.proto_type:type_name -> ProtocolType
+	54, // 61: AccountHistoryResponse.account:type_name -> Account
+	55, // 62: AccountHistoryResponse.history:type_name -> TokenRotation
+	10, // 63: MailService.GetLatestMail:input_type -> GetNewMailRequest
+	12, // 64: MailService.FindMail:input_type -> FindMailRequest
+	14, // 65: MailService.ListMail:input_type -> ListMailRequest
+	17, // 66: MailService.SearchMail:input_type -> SearchMailRequest
+	19, // 67: MailService.ListFolders:input_type -> ListFoldersRequest
+	23, // 68: MailService.DownloadAttachment:input_type -> DownloadAttachmentRequest
+	21, // 69: MailService.ExportMail:input_type -> ExportMailRequest
+	25, // 70: MailService.MarkMail:input_type -> MarkMailRequest
+	27, // 71: MailService.MoveMail:input_type -> MoveMailRequest
+	29, // 72: MailService.DeleteMail:input_type -> DeleteMailRequest
+	34, // 73: MailService.SendMail:input_type -> SendMailRequest
+	33, // 74: MailService.ReplyMail:input_type -> ReplyMailRequest
+	36, // 75: MailService.GetJunkMail:input_type -> GetNewJunkMailRequest
+	38, // 76: MailService.SubscribeMail:input_type -> SubscribeMailRequest
+	40, // 77: MailService.RefreshToken:input_type -> RefreshTokenRequest
+	42, // 78: MailService.BatchRefreshToken:input_type -> BatchRefreshTokenRequest
+	45, // 79: MailService.DetectProtocolType:input_type -> DetectProtocolTypeRequest
+	47, // 80: MailService.BatchDetectProtocolType:input_type -> BatchDetectProtocolTypeRequest
+	50, // 81: MailService.DeviceAuthorization:input_type -> DeviceAuthorizationRequest
+	52, // 82: MailService.RegisterAccount:input_type -> RegisterAccountRequest
+	53, // 83: MailService.GetAccountHistory:input_type -> AccountRequest
+	53, // 84: MailService.DeleteAccount:input_type -> AccountRequest
+	11, // 85: MailService.GetLatestMail:output_type -> GetNewMailResponse
+	13, // 86: MailService.FindMail:output_type -> FindMailResponse
+	15, // 87: MailService.ListMail:output_type -> ListMailResponse
+	15, // 88: MailService.SearchMail:output_type -> ListMailResponse
+	20, // 89: MailService.ListFolders:output_type -> ListFoldersResponse
+	24, // 90: MailService.DownloadAttachment:output_type -> AttachmentChunk
+	22, // 91: MailService.ExportMail:output_type -> RawMailChunk
+	26, // 92: MailService.MarkMail:output_type -> MarkMailResponse
+	28, // 93: MailService.MoveMail:output_type -> MoveMailResponse
+	30, // 94: MailService.DeleteMail:output_type -> DeleteMailResponse
+	35, // 95: MailService.SendMail:output_type -> SendMailResponse
+	35, // 96: MailService.ReplyMail:output_type -> SendMailResponse
+	37, // 97: MailService.GetJunkMail:output_type -> GetNewJunkMailResponse
+	39, // 98: MailService.SubscribeMail:output_type -> MailEvent
+	41, // 99: MailService.RefreshToken:output_type -> RefreshTokenResponse
+	44, // 100: MailService.BatchRefreshToken:output_type -> BatchRefreshTokenResponse
+	46, // 101: MailService.DetectProtocolType:output_type -> DetectProtocolTypeResponse
+	49, // 102: MailService.BatchDetectProtocolType:output_type -> BatchDetectProtocolTypeResponse
+	51, // 103: MailService.DeviceAuthorization:output_type -> DeviceAuthorizationEvent
+	54, // 104: MailService.RegisterAccount:output_type -> Account
+	56, // 105: MailService.GetAccountHistory:output_type -> AccountHistoryResponse
+	57, // 106: MailService.DeleteAccount:output_type -> DeleteAccountResponse
+	85, // [85:107] is the sub-list for method output_type
+	63, // [63:85] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_proto_server_proto_init() }
@@ -1565,19 +4583,25 @@ func file_proto_server_proto_init() {
 	if File_proto_server_proto != nil {
 		return
 	}
-	file_proto_server_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[3].OneofWrappers = []any{}
 	file_proto_server_proto_msgTypes[6].OneofWrappers = []any{}
 	file_proto_server_proto_msgTypes[8].OneofWrappers = []any{}
 	file_proto_server_proto_msgTypes[10].OneofWrappers = []any{}
-	file_proto_server_proto_msgTypes[14].OneofWrappers = []any{}
 	file_proto_server_proto_msgTypes[19].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[20].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[30].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[32].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[34].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[38].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[43].OneofWrappers = []any{}
+	file_proto_server_proto_msgTypes[46].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_server_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   21,
+			NumEnums:      5,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,12 +21,26 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	MailService_GetLatestMail_FullMethodName           = "/MailService/GetLatestMail"
 	MailService_FindMail_FullMethodName                = "/MailService/FindMail"
+	MailService_ListMail_FullMethodName                = "/MailService/ListMail"
+	MailService_SearchMail_FullMethodName              = "/MailService/SearchMail"
+	MailService_ListFolders_FullMethodName             = "/MailService/ListFolders"
+	MailService_DownloadAttachment_FullMethodName      = "/MailService/DownloadAttachment"
+	MailService_ExportMail_FullMethodName              = "/MailService/ExportMail"
+	MailService_MarkMail_FullMethodName                = "/MailService/MarkMail"
+	MailService_MoveMail_FullMethodName                = "/MailService/MoveMail"
+	MailService_DeleteMail_FullMethodName              = "/MailService/DeleteMail"
+	MailService_SendMail_FullMethodName                = "/MailService/SendMail"
+	MailService_ReplyMail_FullMethodName               = "/MailService/ReplyMail"
 	MailService_GetJunkMail_FullMethodName             = "/MailService/GetJunkMail"
 	MailService_SubscribeMail_FullMethodName           = "/MailService/SubscribeMail"
 	MailService_RefreshToken_FullMethodName            = "/MailService/RefreshToken"
 	MailService_BatchRefreshToken_FullMethodName       = "/MailService/BatchRefreshToken"
 	MailService_DetectProtocolType_FullMethodName      = "/MailService/DetectProtocolType"
 	MailService_BatchDetectProtocolType_FullMethodName = "/MailService/BatchDetectProtocolType"
+	MailService_DeviceAuthorization_FullMethodName     = "/MailService/DeviceAuthorization"
+	MailService_RegisterAccount_FullMethodName         = "/MailService/RegisterAccount"
+	MailService_GetAccountHistory_FullMethodName       = "/MailService/GetAccountHistory"
+	MailService_DeleteAccount_FullMethodName           = "/MailService/DeleteAccount"
 )
 
 // MailServiceClient is the client API for MailService service.
@@ -39,6 +53,26 @@ type MailServiceClient interface {
 	GetLatestMail(ctx context.Context, in *GetNewMailRequest, opts ...grpc.CallOption) (*GetNewMailResponse, error)
 	// 查找特定邮件
 	FindMail(ctx context.Context, in *FindMailRequest, opts ...grpc.CallOption) (*FindMailResponse, error)
+	// 分页获取邮件列表
+	ListMail(ctx context.Context, in *ListMailRequest, opts ...grpc.CallOption) (*ListMailResponse, error)
+	// 按条件搜索邮件
+	SearchMail(ctx context.Context, in *SearchMailRequest, opts ...grpc.CallOption) (*ListMailResponse, error)
+	// 获取文件夹列表
+	ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	// 下载附件（分块流式返回）
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachmentChunk], error)
+	// 导出邮件原始内容（RFC 822 / EML，分块流式返回）
+	ExportMail(ctx context.Context, in *ExportMailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RawMailChunk], error)
+	// 修改邮件状态（已读/未读、旗标）
+	MarkMail(ctx context.Context, in *MarkMailRequest, opts ...grpc.CallOption) (*MarkMailResponse, error)
+	// 移动邮件到其他文件夹
+	MoveMail(ctx context.Context, in *MoveMailRequest, opts ...grpc.CallOption) (*MoveMailResponse, error)
+	// 删除邮件
+	DeleteMail(ctx context.Context, in *DeleteMailRequest, opts ...grpc.CallOption) (*DeleteMailResponse, error)
+	// 发送邮件（IMAP 账户通过 SMTP 发送，Graph 账户通过 sendMail 发送）
+	SendMail(ctx context.Context, in *SendMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error)
+	// 回复、回复全部或转发邮件
+	ReplyMail(ctx context.Context, in *ReplyMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error)
 	// 获取垃圾邮件
 	GetJunkMail(ctx context.Context, in *GetNewJunkMailRequest, opts ...grpc.CallOption) (*GetNewJunkMailResponse, error)
 	// 邮件订阅流（SSE 替代方案）
@@ -51,6 +85,14 @@ type MailServiceClient interface {
 	DetectProtocolType(ctx context.Context, in *DetectProtocolTypeRequest, opts ...grpc.CallOption) (*DetectProtocolTypeResponse, error)
 	// 批量检测协议类型
 	BatchDetectProtocolType(ctx context.Context, in *BatchDetectProtocolTypeRequest, opts ...grpc.CallOption) (*BatchDetectProtocolTypeResponse, error)
+	// 设备码授权流（推送用户代码，用户完成登录后返回邮箱信息）
+	DeviceAuthorization(ctx context.Context, in *DeviceAuthorizationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeviceAuthorizationEvent], error)
+	// 保存账户（需要启用 refresh token 存储），之后的请求可以只传 account_id 代替邮箱信息
+	RegisterAccount(ctx context.Context, in *RegisterAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	// 获取已保存账户的基本信息和 refreshToken 变更记录
+	GetAccountHistory(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountHistoryResponse, error)
+	// 删除已保存的账户及其变更记录
+	DeleteAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
 }
 
 type mailServiceClient struct {
@@ -81,6 +123,124 @@ func (c *mailServiceClient) FindMail(ctx context.Context, in *FindMailRequest, o
 	return out, nil
 }
 
+func (c *mailServiceClient) ListMail(ctx context.Context, in *ListMailRequest, opts ...grpc.CallOption) (*ListMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMailResponse)
+	err := c.cc.Invoke(ctx, MailService_ListMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) SearchMail(ctx context.Context, in *SearchMailRequest, opts ...grpc.CallOption) (*ListMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMailResponse)
+	err := c.cc.Invoke(ctx, MailService_SearchMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, MailService_ListFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachmentChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MailService_ServiceDesc.Streams[0], MailService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, AttachmentChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MailService_DownloadAttachmentClient = grpc.ServerStreamingClient[AttachmentChunk]
+
+func (c *mailServiceClient) ExportMail(ctx context.Context, in *ExportMailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RawMailChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MailService_ServiceDesc.Streams[1], MailService_ExportMail_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportMailRequest, RawMailChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MailService_ExportMailClient = grpc.ServerStreamingClient[RawMailChunk]
+
+func (c *mailServiceClient) MarkMail(ctx context.Context, in *MarkMailRequest, opts ...grpc.CallOption) (*MarkMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkMailResponse)
+	err := c.cc.Invoke(ctx, MailService_MarkMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) MoveMail(ctx context.Context, in *MoveMailRequest, opts ...grpc.CallOption) (*MoveMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveMailResponse)
+	err := c.cc.Invoke(ctx, MailService_MoveMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) DeleteMail(ctx context.Context, in *DeleteMailRequest, opts ...grpc.CallOption) (*DeleteMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMailResponse)
+	err := c.cc.Invoke(ctx, MailService_DeleteMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) SendMail(ctx context.Context, in *SendMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMailResponse)
+	err := c.cc.Invoke(ctx, MailService_SendMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) ReplyMail(ctx context.Context, in *ReplyMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMailResponse)
+	err := c.cc.Invoke(ctx, MailService_ReplyMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mailServiceClient) GetJunkMail(ctx context.Context, in *GetNewJunkMailRequest, opts ...grpc.CallOption) (*GetNewJunkMailResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetNewJunkMailResponse)
@@ -93,7 +253,7 @@ func (c *mailServiceClient) GetJunkMail(ctx context.Context, in *GetNewJunkMailR
 
 func (c *mailServiceClient) SubscribeMail(ctx context.Context, in *SubscribeMailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MailEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &MailService_ServiceDesc.Streams[0], MailService_SubscribeMail_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &MailService_ServiceDesc.Streams[2], MailService_SubscribeMail_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +310,55 @@ func (c *mailServiceClient) BatchDetectProtocolType(ctx context.Context, in *Bat
 	return out, nil
 }
 
+func (c *mailServiceClient) DeviceAuthorization(ctx context.Context, in *DeviceAuthorizationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeviceAuthorizationEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MailService_ServiceDesc.Streams[3], MailService_DeviceAuthorization_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DeviceAuthorizationRequest, DeviceAuthorizationEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MailService_DeviceAuthorizationClient = grpc.ServerStreamingClient[DeviceAuthorizationEvent]
+
+func (c *mailServiceClient) RegisterAccount(ctx context.Context, in *RegisterAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, MailService_RegisterAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) GetAccountHistory(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountHistoryResponse)
+	err := c.cc.Invoke(ctx, MailService_GetAccountHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) DeleteAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, MailService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MailServiceServer is the server API for MailService service.
 // All implementations must embed UnimplementedMailServiceServer
 // for forward compatibility.
@@ -160,6 +369,26 @@ type MailServiceServer interface {
 	GetLatestMail(context.Context, *GetNewMailRequest) (*GetNewMailResponse, error)
 	// 查找特定邮件
 	FindMail(context.Context, *FindMailRequest) (*FindMailResponse, error)
+	// 分页获取邮件列表
+	ListMail(context.Context, *ListMailRequest) (*ListMailResponse, error)
+	// 按条件搜索邮件
+	SearchMail(context.Context, *SearchMailRequest) (*ListMailResponse, error)
+	// 获取文件夹列表
+	ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error)
+	// 下载附件（分块流式返回）
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[AttachmentChunk]) error
+	// 导出邮件原始内容（RFC 822 / EML，分块流式返回）
+	ExportMail(*ExportMailRequest, grpc.ServerStreamingServer[RawMailChunk]) error
+	// 修改邮件状态（已读/未读、旗标）
+	MarkMail(context.Context, *MarkMailRequest) (*MarkMailResponse, error)
+	// 移动邮件到其他文件夹
+	MoveMail(context.Context, *MoveMailRequest) (*MoveMailResponse, error)
+	// 删除邮件
+	DeleteMail(context.Context, *DeleteMailRequest) (*DeleteMailResponse, error)
+	// 发送邮件（IMAP 账户通过 SMTP 发送，Graph 账户通过 sendMail 发送）
+	SendMail(context.Context, *SendMailRequest) (*SendMailResponse, error)
+	// 回复、回复全部或转发邮件
+	ReplyMail(context.Context, *ReplyMailRequest) (*SendMailResponse, error)
 	// 获取垃圾邮件
 	GetJunkMail(context.Context, *GetNewJunkMailRequest) (*GetNewJunkMailResponse, error)
 	// 邮件订阅流（SSE 替代方案）
@@ -172,6 +401,14 @@ type MailServiceServer interface {
 	DetectProtocolType(context.Context, *DetectProtocolTypeRequest) (*DetectProtocolTypeResponse, error)
 	// 批量检测协议类型
 	BatchDetectProtocolType(context.Context, *BatchDetectProtocolTypeRequest) (*BatchDetectProtocolTypeResponse, error)
+	// 设备码授权流（推送用户代码，用户完成登录后返回邮箱信息）
+	DeviceAuthorization(*DeviceAuthorizationRequest, grpc.ServerStreamingServer[DeviceAuthorizationEvent]) error
+	// 保存账户（需要启用 refresh token 存储），之后的请求可以只传 account_id 代替邮箱信息
+	RegisterAccount(context.Context, *RegisterAccountRequest) (*Account, error)
+	// 获取已保存账户的基本信息和 refreshToken 变更记录
+	GetAccountHistory(context.Context, *AccountRequest) (*AccountHistoryResponse, error)
+	// 删除已保存的账户及其变更记录
+	DeleteAccount(context.Context, *AccountRequest) (*DeleteAccountResponse, error)
 	mustEmbedUnimplementedMailServiceServer()
 }
 
@@ -188,6 +425,36 @@ func (UnimplementedMailServiceServer) GetLatestMail(context.Context, *GetNewMail
 func (UnimplementedMailServiceServer) FindMail(context.Context, *FindMailRequest) (*FindMailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindMail not implemented")
 }
+func (UnimplementedMailServiceServer) ListMail(context.Context, *ListMailRequest) (*ListMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMail not implemented")
+}
+func (UnimplementedMailServiceServer) SearchMail(context.Context, *SearchMailRequest) (*ListMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMail not implemented")
+}
+func (UnimplementedMailServiceServer) ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedMailServiceServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[AttachmentChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedMailServiceServer) ExportMail(*ExportMailRequest, grpc.ServerStreamingServer[RawMailChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMail not implemented")
+}
+func (UnimplementedMailServiceServer) MarkMail(context.Context, *MarkMailRequest) (*MarkMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkMail not implemented")
+}
+func (UnimplementedMailServiceServer) MoveMail(context.Context, *MoveMailRequest) (*MoveMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveMail not implemented")
+}
+func (UnimplementedMailServiceServer) DeleteMail(context.Context, *DeleteMailRequest) (*DeleteMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMail not implemented")
+}
+func (UnimplementedMailServiceServer) SendMail(context.Context, *SendMailRequest) (*SendMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMail not implemented")
+}
+func (UnimplementedMailServiceServer) ReplyMail(context.Context, *ReplyMailRequest) (*SendMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplyMail not implemented")
+}
 func (UnimplementedMailServiceServer) GetJunkMail(context.Context, *GetNewJunkMailRequest) (*GetNewJunkMailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetJunkMail not implemented")
 }
@@ -206,6 +473,18 @@ func (UnimplementedMailServiceServer) DetectProtocolType(context.Context, *Detec
 func (UnimplementedMailServiceServer) BatchDetectProtocolType(context.Context, *BatchDetectProtocolTypeRequest) (*BatchDetectProtocolTypeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchDetectProtocolType not implemented")
 }
+func (UnimplementedMailServiceServer) DeviceAuthorization(*DeviceAuthorizationRequest, grpc.ServerStreamingServer[DeviceAuthorizationEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method DeviceAuthorization not implemented")
+}
+func (UnimplementedMailServiceServer) RegisterAccount(context.Context, *RegisterAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAccount not implemented")
+}
+func (UnimplementedMailServiceServer) GetAccountHistory(context.Context, *AccountRequest) (*AccountHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountHistory not implemented")
+}
+func (UnimplementedMailServiceServer) DeleteAccount(context.Context, *AccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
 func (UnimplementedMailServiceServer) mustEmbedUnimplementedMailServiceServer() {}
 func (UnimplementedMailServiceServer) testEmbeddedByValue()                     {}
 
@@ -263,6 +542,172 @@ func _MailService_FindMail_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MailService_ListMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ListMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ListMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ListMail(ctx, req.(*ListMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_SearchMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).SearchMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_SearchMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).SearchMail(ctx, req.(*SearchMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ListFolders(ctx, req.(*ListFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MailServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, AttachmentChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MailService_DownloadAttachmentServer = grpc.ServerStreamingServer[AttachmentChunk]
+
+func _MailService_ExportMail_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportMailRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MailServiceServer).ExportMail(m, &grpc.GenericServerStream[ExportMailRequest, RawMailChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MailService_ExportMailServer = grpc.ServerStreamingServer[RawMailChunk]
+
+func _MailService_MarkMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).MarkMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_MarkMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).MarkMail(ctx, req.(*MarkMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_MoveMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).MoveMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_MoveMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).MoveMail(ctx, req.(*MoveMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_DeleteMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).DeleteMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_DeleteMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).DeleteMail(ctx, req.(*DeleteMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_SendMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).SendMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_SendMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).SendMail(ctx, req.(*SendMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_ReplyMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplyMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ReplyMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ReplyMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ReplyMail(ctx, req.(*ReplyMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MailService_GetJunkMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNewJunkMailRequest)
 	if err := dec(in); err != nil {
@@ -364,6 +809,71 @@ func _MailService_BatchDetectProtocolType_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MailService_DeviceAuthorization_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeviceAuthorizationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MailServiceServer).DeviceAuthorization(m, &grpc.GenericServerStream[DeviceAuthorizationRequest, DeviceAuthorizationEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MailService_DeviceAuthorizationServer = grpc.ServerStreamingServer[DeviceAuthorizationEvent]
+
+func _MailService_RegisterAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).RegisterAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_RegisterAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).RegisterAccount(ctx, req.(*RegisterAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_GetAccountHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).GetAccountHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_GetAccountHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).GetAccountHistory(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).DeleteAccount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MailService_ServiceDesc is the grpc.ServiceDesc for MailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -379,6 +889,38 @@ var MailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindMail",
 			Handler:    _MailService_FindMail_Handler,
 		},
+		{
+			MethodName: "ListMail",
+			Handler:    _MailService_ListMail_Handler,
+		},
+		{
+			MethodName: "SearchMail",
+			Handler:    _MailService_SearchMail_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _MailService_ListFolders_Handler,
+		},
+		{
+			MethodName: "MarkMail",
+			Handler:    _MailService_MarkMail_Handler,
+		},
+		{
+			MethodName: "MoveMail",
+			Handler:    _MailService_MoveMail_Handler,
+		},
+		{
+			MethodName: "DeleteMail",
+			Handler:    _MailService_DeleteMail_Handler,
+		},
+		{
+			MethodName: "SendMail",
+			Handler:    _MailService_SendMail_Handler,
+		},
+		{
+			MethodName: "ReplyMail",
+			Handler:    _MailService_ReplyMail_Handler,
+		},
 		{
 			MethodName: "GetJunkMail",
 			Handler:    _MailService_GetJunkMail_Handler,
@@ -399,13 +941,40 @@ var MailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchDetectProtocolType",
 			Handler:    _MailService_BatchDetectProtocolType_Handler,
 		},
+		{
+			MethodName: "RegisterAccount",
+			Handler:    _MailService_RegisterAccount_Handler,
+		},
+		{
+			MethodName: "GetAccountHistory",
+			Handler:    _MailService_GetAccountHistory_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _MailService_DeleteAccount_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _MailService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportMail",
+			Handler:       _MailService_ExportMail_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeMail",
 			Handler:       _MailService_SubscribeMail_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DeviceAuthorization",
+			Handler:       _MailService_DeviceAuthorization_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/server.proto",
 }